@@ -3,10 +3,16 @@ package apis
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/mollie/go-apicurio-registry/client"
+	"github.com/mollie/go-apicurio-registry/internal/plumbing"
 	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/pkg/errors"
 )
 
 type AdminAPI struct {
@@ -23,6 +29,8 @@ func NewAdminAPI(client *client.Client) *AdminAPI {
 // GET /admin/rules
 // See: https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Global-rules/operation/listGlobalRules
 func (api *AdminAPI) ListGlobalRules(ctx context.Context) ([]models.Rule, error) {
+	ctx = client.WithOperationName(ctx, "ListGlobalRules")
+
 	url := fmt.Sprintf("%s/admin/rules", api.Client.BaseURL)
 	resp, err := api.executeRequest(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -45,6 +53,8 @@ func (api *AdminAPI) CreateGlobalRule(
 	rule models.Rule,
 	level models.RuleLevel,
 ) error {
+	ctx = client.WithOperationName(ctx, "CreateGlobalRule")
+
 	url := fmt.Sprintf("%s/admin/rules", api.Client.BaseURL)
 
 	// Prepare the request body
@@ -64,6 +74,8 @@ func (api *AdminAPI) CreateGlobalRule(
 // DELETE /admin/rules
 // See: https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Global-rules/operation/deleteAllGlobalRules
 func (api *AdminAPI) DeleteAllGlobalRule(ctx context.Context) error {
+	ctx = client.WithOperationName(ctx, "DeleteAllGlobalRule")
+
 	url := fmt.Sprintf("%s/admin/rules", api.Client.BaseURL)
 	resp, err := api.executeRequest(ctx, http.MethodDelete, url, nil)
 	if err != nil {
@@ -80,6 +92,8 @@ func (api *AdminAPI) GetGlobalRule(
 	ctx context.Context,
 	rule models.Rule,
 ) (models.RuleLevel, error) {
+	ctx = client.WithOperationName(ctx, "GetGlobalRule")
+
 	url := fmt.Sprintf("%s/admin/rules/%s", api.Client.BaseURL, rule)
 	resp, err := api.executeRequest(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -102,6 +116,8 @@ func (api *AdminAPI) UpdateGlobalRule(
 	rule models.Rule,
 	level models.RuleLevel,
 ) error {
+	ctx = client.WithOperationName(ctx, "UpdateGlobalRule")
+
 	url := fmt.Sprintf("%s/admin/rules/%s", api.Client.BaseURL, rule)
 
 	// Prepare the request body
@@ -126,6 +142,8 @@ func (api *AdminAPI) UpdateGlobalRule(
 // DELETE /admin/rules/{rule}
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Global-rules/operation/deleteGlobalRule
 func (api *AdminAPI) DeleteGlobalRule(ctx context.Context, rule models.Rule) error {
+	ctx = client.WithOperationName(ctx, "DeleteGlobalRule")
+
 	url := fmt.Sprintf("%s/admin/rules/%s", api.Client.BaseURL, rule)
 	resp, err := api.executeRequest(ctx, http.MethodDelete, url, nil)
 	if err != nil {
@@ -139,6 +157,8 @@ func (api *AdminAPI) DeleteGlobalRule(ctx context.Context, rule models.Rule) err
 // GET admin/config/artifactTypes
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Artifact-Type/operation/listArtifactTypes
 func (api *AdminAPI) ListArtifactTypes(ctx context.Context) ([]models.ArtifactType, error) {
+	ctx = client.WithOperationName(ctx, "ListArtifactTypes")
+
 	url := fmt.Sprintf("%s/admin/config/artifactTypes", api.Client.BaseURL)
 	resp, err := api.executeRequest(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -159,6 +179,357 @@ func (api *AdminAPI) ListArtifactTypes(ctx context.Context) ([]models.ArtifactTy
 
 }
 
+// SupportedArtifactTypes Returns the set of artifact types supported by the registry.
+// The set is fetched once with ListArtifactTypes and cached on the client; CreateArtifact then rejects
+// unsupported types locally. Use InvalidateSupportedArtifactTypes to fetch it again.
+func (api *AdminAPI) SupportedArtifactTypes(ctx context.Context) (map[models.ArtifactType]bool, error) {
+	ctx = client.WithOperationName(ctx, "SupportedArtifactTypes")
+
+	if types, ok := plumbing.Of(api.Client).CachedArtifactTypes(); ok {
+		return types, nil
+	}
+
+	artifactTypes, err := api.ListArtifactTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plumbing.Of(api.Client).CacheArtifactTypes(artifactTypes)
+
+	types, _ := plumbing.Of(api.Client).CachedArtifactTypes()
+	return types, nil
+}
+
+// InvalidateSupportedArtifactTypes Clears the cached set of supported artifact types.
+func (api *AdminAPI) InvalidateSupportedArtifactTypes() {
+	plumbing.Of(api.Client).InvalidateArtifactTypes()
+}
+
+// ListLoggers Lists all the loggers with an explicitly configured level.
+// GET /admin/loggers
+// See https://www.apicur.io/registry/docs/apicurio-registry/2.6.x/assets-attachments/registry-rest-api.htm#tag/Admin/operation/listLogConfigurations
+func (api *AdminAPI) ListLoggers(ctx context.Context) ([]models.LoggerConfig, error) {
+	ctx = client.WithOperationName(ctx, "ListLoggers")
+
+	url := fmt.Sprintf("%s/admin/loggers", api.Client.BaseURL)
+	resp, err := api.executeRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var loggers []models.LoggerConfig
+	if err := handleResponse(resp, http.StatusOK, &loggers); err != nil {
+		return nil, err
+	}
+
+	return loggers, nil
+}
+
+// GetLogger Returns the configured level of a single logger.
+// GET /admin/loggers/{logger}
+// See https://www.apicur.io/registry/docs/apicurio-registry/2.6.x/assets-attachments/registry-rest-api.htm#tag/Admin/operation/getLogConfiguration
+func (api *AdminAPI) GetLogger(ctx context.Context, name string) (*models.LoggerConfig, error) {
+	ctx = client.WithOperationName(ctx, "GetLogger")
+
+	if name == "" {
+		return nil, errors.New("logger name cannot be empty")
+	}
+
+	urlPath := fmt.Sprintf("%s/admin/loggers/%s", api.Client.BaseURL, url.PathEscape(name))
+	resp, err := api.executeRequest(ctx, http.MethodGet, urlPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var logger models.LoggerConfig
+	if err := handleResponse(resp, http.StatusOK, &logger); err != nil {
+		return nil, err
+	}
+
+	return &logger, nil
+}
+
+// SetLogger Overrides the level of a single logger at runtime.
+// PUT /admin/loggers/{logger}
+// See https://www.apicur.io/registry/docs/apicurio-registry/2.6.x/assets-attachments/registry-rest-api.htm#tag/Admin/operation/setLogConfiguration
+func (api *AdminAPI) SetLogger(
+	ctx context.Context,
+	name string,
+	level models.LogLevel,
+) (*models.LoggerConfig, error) {
+	ctx = client.WithOperationName(ctx, "SetLogger")
+
+	if name == "" {
+		return nil, errors.New("logger name cannot be empty")
+	}
+
+	body := models.UpdateLoggerRequest{Level: level}
+	if err := body.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid log level provided")
+	}
+
+	urlPath := fmt.Sprintf("%s/admin/loggers/%s", api.Client.BaseURL, url.PathEscape(name))
+	resp, err := api.executeRequest(ctx, http.MethodPut, urlPath, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var logger models.LoggerConfig
+	if err := handleResponse(resp, http.StatusOK, &logger); err != nil {
+		return nil, err
+	}
+
+	return &logger, nil
+}
+
+// RemoveLoggerOverride Removes the runtime level override of a single logger, restoring its default.
+// DELETE /admin/loggers/{logger}
+// See https://www.apicur.io/registry/docs/apicurio-registry/2.6.x/assets-attachments/registry-rest-api.htm#tag/Admin/operation/removeLogConfiguration
+func (api *AdminAPI) RemoveLoggerOverride(ctx context.Context, name string) (*models.LoggerConfig, error) {
+	ctx = client.WithOperationName(ctx, "RemoveLoggerOverride")
+
+	if name == "" {
+		return nil, errors.New("logger name cannot be empty")
+	}
+
+	urlPath := fmt.Sprintf("%s/admin/loggers/%s", api.Client.BaseURL, url.PathEscape(name))
+	resp, err := api.executeRequest(ctx, http.MethodDelete, urlPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var logger models.LoggerConfig
+	if err := handleResponse(resp, http.StatusOK, &logger); err != nil {
+		return nil, err
+	}
+
+	return &logger, nil
+}
+
+// ListConfigPropertyDefinitions Lists the dynamic configuration properties of the registry with their current
+// value, type, label and description, e.g. to build a configuration UI.
+// GET /admin/config/properties
+// See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Admin/operation/listConfigProperties
+func (api *AdminAPI) ListConfigPropertyDefinitions(ctx context.Context) ([]models.ConfigPropertyDefinition, error) {
+	ctx = client.WithOperationName(ctx, "ListConfigPropertyDefinitions")
+
+	url := fmt.Sprintf("%s/admin/config/properties", api.Client.BaseURL)
+	resp, err := api.executeRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var definitions []models.ConfigPropertyDefinition
+	if err := handleResponse(resp, http.StatusOK, &definitions); err != nil {
+		return nil, err
+	}
+
+	return definitions, nil
+}
+
+// ExportData Exports registry data as a ZIP archive. The archive is streamed rather than buffered, so
+// exports of any size can be copied to disk; the caller must close the returned reader.
+// When the registry runs the export as a long-running task it answers 202 Accepted instead; the archive
+// is then nil and the returned operation can be passed to WaitForOperation.
+// GET /admin/export
+// See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Admin/operation/exportData
+func (api *AdminAPI) ExportData(ctx context.Context) (io.ReadCloser, *models.AsyncOperation, error) {
+	ctx = client.WithOperationName(ctx, "ExportData")
+
+	urlPath := fmt.Sprintf("%s/admin/export", api.Client.BaseURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlPath, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create HTTP request")
+	}
+	req.Header.Set("Accept", ContentTypeZip)
+
+	resp, err := api.Client.Do(req)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to execute HTTP request")
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return streamBody(ctx, resp.Body), nil, nil
+	case http.StatusAccepted:
+		op, err := asyncOperation(resp)
+		return nil, op, err
+	default:
+		return nil, nil, handleResponse(resp, http.StatusOK, nil)
+	}
+}
+
+// ImportData Imports registry data from a ZIP archive previously created by ExportData.
+// The archive is streamed to the registry as it is read from data. Params choose whether global and content
+// IDs are preserved; nil keeps the registry's defaults. A registry that already holds conflicting data
+// answers 409 Conflict, returned as a *models.APIError.
+// A nil operation means the import completed synchronously. When the registry answers 202 Accepted,
+// the returned operation can be passed to WaitForOperation.
+// POST /admin/import
+// See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Admin/operation/importData
+func (api *AdminAPI) ImportData(
+	ctx context.Context,
+	data io.Reader,
+	params *models.ImportParams,
+) (*models.AsyncOperation, error) {
+	ctx = client.WithOperationName(ctx, "ImportData")
+
+	if data == nil {
+		return nil, errors.New("import data cannot be nil")
+	}
+
+	urlPath := fmt.Sprintf("%s/admin/import", api.Client.BaseURL)
+	if params != nil {
+		urlPath += "?" + params.ToQuery().Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, urlPath, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create HTTP request")
+	}
+	req.Header.Set("Content-Type", ContentTypeZip)
+	if params != nil {
+		for name, values := range params.ToHeaders() {
+			req.Header[name] = values
+		}
+	}
+
+	resp, err := api.Client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute HTTP request")
+	}
+
+	if resp.StatusCode == http.StatusAccepted {
+		return asyncOperation(resp)
+	}
+
+	return nil, handleResponse(resp, http.StatusNoContent, nil)
+}
+
+// WaitForOperation Polls the status URL of an operation accepted with 202 Accepted every pollInterval
+// until the registry reports completion with any other 2xx status. An error status ends the wait
+// with the registry's error, and cancelling ctx ends it with the context's error.
+// A status URL on another scheme or host than the client's BaseURL is rejected, so that the
+// client's credentials are never sent elsewhere.
+func (api *AdminAPI) WaitForOperation(
+	ctx context.Context,
+	op *models.AsyncOperation,
+	pollInterval time.Duration,
+) error {
+	ctx = client.WithOperationName(ctx, "WaitForOperation")
+
+	if op == nil || op.StatusURL == "" {
+		return errors.New("operation status URL cannot be empty")
+	}
+	if pollInterval <= 0 {
+		return errors.New("poll interval must be positive")
+	}
+	if err := sameOrigin(api.Client.BaseURL, op.StatusURL); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		resp, err := api.executeRequest(ctx, http.MethodGet, op.StatusURL, nil)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusAccepted:
+			plumbing.DrainBody(resp)
+		case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+			plumbing.DrainBody(resp)
+			return nil
+		default:
+			return handleResponse(resp, http.StatusOK, nil)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// sameOrigin returns an error unless statusURL has the scheme and host of baseURL.
+func sameOrigin(baseURL, statusURL string) error {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return errors.Wrap(err, "invalid base URL")
+	}
+	status, err := url.Parse(statusURL)
+	if err != nil {
+		return errors.Wrap(err, "invalid operation status URL")
+	}
+	if !strings.EqualFold(status.Scheme, base.Scheme) || !strings.EqualFold(status.Host, base.Host) {
+		return errors.Errorf("operation status URL %s is not on the registry at %s://%s", statusURL, base.Scheme, base.Host)
+	}
+	return nil
+}
+
+// asyncOperation builds the operation of a 202 Accepted response from its Location header,
+// resolving a relative location against the request URL.
+func asyncOperation(resp *http.Response) (*models.AsyncOperation, error) {
+	defer plumbing.DrainBody(resp)
+
+	location, err := resp.Location()
+	if err != nil {
+		return nil, errors.Wrap(err, "accepted operation has no status location")
+	}
+
+	return &models.AsyncOperation{StatusURL: location.String()}, nil
+}
+
+// ImportArtifacts Imports artifacts one by one, resolving collisions with existing artifacts using strategy.
+// SKIP leaves existing artifacts untouched, OVERWRITE adds the imported content as a new latest version,
+// and FAIL stops at the first collision. Other conflicts, such as rule violations, fail the import with any
+// strategy. On error, the summary of the artifacts processed so far is returned.
+func (api *AdminAPI) ImportArtifacts(
+	ctx context.Context,
+	artifacts []models.ImportArtifact,
+	strategy models.ImportConflictStrategy,
+) (*models.ImportSummary, error) {
+	ctx = client.WithOperationName(ctx, "ImportArtifacts")
+
+	ifExists := models.IfExistsFail
+	switch strategy {
+	case models.ImportConflictSkip, models.ImportConflictFail:
+	case models.ImportConflictOverwrite:
+		ifExists = models.IfExistsCreate
+	default:
+		return nil, errors.Errorf("invalid import conflict strategy: '%s'", strategy)
+	}
+
+	artifactsAPI := NewArtifactsAPI(api.Client)
+	params := &models.CreateArtifactParams{IfExists: ifExists}
+	summary := &models.ImportSummary{}
+
+	for _, item := range artifacts {
+		id := fmt.Sprintf("%s/%s", item.GroupID, item.Artifact.ArtifactID)
+
+		_, err := artifactsAPI.CreateArtifact(ctx, item.GroupID, item.Artifact, params)
+		if err != nil {
+			var apiErr *models.APIError
+			if strategy == models.ImportConflictSkip && errors.As(err, &apiErr) && isArtifactExists(apiErr) {
+				summary.Skipped = append(summary.Skipped, id)
+				continue
+			}
+			return summary, errors.Wrapf(err, "failed to import artifact %s", id)
+		}
+		summary.Imported = append(summary.Imported, id)
+	}
+
+	return summary, nil
+}
+
+// isArtifactExists reports whether apiErr is the conflict of an artifact that already exists, as opposed to
+// other conflicts such as rule violations.
+func isArtifactExists(apiErr *models.APIError) bool {
+	return apiErr.Status == http.StatusConflict && apiErr.Name == "ArtifactAlreadyExistsException"
+}
+
 // executeRequest handles the creation and execution of an HTTP request.
 func (api *AdminAPI) executeRequest(
 	ctx context.Context,
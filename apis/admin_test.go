@@ -1,9 +1,16 @@
 package apis_test
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mollie/go-apicurio-registry/apis"
 	"github.com/mollie/go-apicurio-registry/client"
@@ -443,6 +450,622 @@ func TestAdminAPI_ListArtifactTypes(t *testing.T) {
 	})
 }
 
+func TestAdminAPI_SupportedArtifactTypes(t *testing.T) {
+	t.Run("Cached And Enforced On Create", func(t *testing.T) {
+		typeRequests, createRequests := 0, 0
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /admin/config/artifactTypes", func(w http.ResponseWriter, r *http.Request) {
+			typeRequests++
+			err := json.NewEncoder(w).Encode([]models.ArtifactTypeResponse{{Name: models.Avro}, {Name: models.Json}})
+			assert.NoError(t, err)
+		})
+		mux.HandleFunc("POST /groups/{groupId}/artifacts", func(w http.ResponseWriter, r *http.Request) {
+			createRequests++
+			assert.NoError(t, json.NewEncoder(w).Encode(models.CreateArtifactResponse{}))
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		adminAPI := apis.NewAdminAPI(mockClient)
+		artifactsAPI := apis.NewArtifactsAPI(mockClient)
+
+		types, err := adminAPI.SupportedArtifactTypes(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, map[models.ArtifactType]bool{models.Avro: true, models.Json: true}, types)
+
+		_, err = adminAPI.SupportedArtifactTypes(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, typeRequests)
+
+		artifact := models.CreateArtifactRequest{
+			ArtifactID:   stubArtifactId,
+			ArtifactType: models.Protobuf,
+			FirstVersion: models.CreateVersionRequest{
+				Content: models.CreateContentRequest{Content: stubArtifactContent, ContentType: "application/json"},
+			},
+		}
+		result, err := artifactsAPI.CreateArtifact(context.Background(), stubGroupId, artifact, nil)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, models.ErrUnsupportedArtifactType)
+		assert.Equal(t, 0, createRequests)
+
+		artifact.ArtifactType = models.Json
+		_, err = artifactsAPI.CreateArtifact(context.Background(), stubGroupId, artifact, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, createRequests)
+
+		adminAPI.InvalidateSupportedArtifactTypes()
+		_, err = adminAPI.SupportedArtifactTypes(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 2, typeRequests)
+	})
+}
+
+func TestAdminAPI_ListLoggers(t *testing.T) {
+	mockResponse := []models.LoggerConfig{
+		{Name: "io.apicurio", Level: models.LogLevelDebug},
+		{Name: "io.quarkus", Level: models.LogLevelWarn},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		server := setupMockServer(t, http.StatusOK, mockResponse, "/admin/loggers", http.MethodGet)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		result, err := api.ListLoggers(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, mockResponse, result)
+	})
+
+	t.Run("InternalServerError", func(t *testing.T) {
+		errorResponse := models.APIError{
+			Status: http.StatusInternalServerError,
+			Title:  TitleInternalServerError,
+		}
+		server := setupMockServer(
+			t,
+			http.StatusInternalServerError,
+			errorResponse,
+			"/admin/loggers",
+			http.MethodGet,
+		)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		result, err := api.ListLoggers(context.Background())
+		assert.Nil(t, result)
+		assertAPIError(t, err, http.StatusInternalServerError, TitleInternalServerError)
+	})
+}
+
+func TestAdminAPI_SetLogger(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := models.LoggerConfig{Name: "io.apicurio", Level: models.LogLevelTrace}
+		server := setupMockServer(
+			t,
+			http.StatusOK,
+			mockResponse,
+			"/admin/loggers/io.apicurio",
+			http.MethodPut,
+		)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		result, err := api.SetLogger(context.Background(), "io.apicurio", models.LogLevelTrace)
+		assert.NoError(t, err)
+		assert.Equal(t, mockResponse, *result)
+	})
+
+	t.Run("Invalid Level", func(t *testing.T) {
+		mockClient := &client.Client{}
+		api := apis.NewAdminAPI(mockClient)
+
+		result, err := api.SetLogger(context.Background(), "io.apicurio", "LOUD")
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid log level")
+	})
+
+	t.Run("Empty Name", func(t *testing.T) {
+		mockClient := &client.Client{}
+		api := apis.NewAdminAPI(mockClient)
+
+		result, err := api.SetLogger(context.Background(), "", models.LogLevelInfo)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestAdminAPI_RemoveLoggerOverride(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := models.LoggerConfig{Name: "io.apicurio", Level: models.LogLevelInfo}
+		server := setupMockServer(
+			t,
+			http.StatusOK,
+			mockResponse,
+			"/admin/loggers/io.apicurio",
+			http.MethodDelete,
+		)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		result, err := api.RemoveLoggerOverride(context.Background(), "io.apicurio")
+		assert.NoError(t, err)
+		assert.Equal(t, models.LogLevelInfo, result.Level)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		errorResponse := models.APIError{Status: http.StatusNotFound, Title: TitleNotFound}
+		server := setupMockServer(
+			t,
+			http.StatusNotFound,
+			errorResponse,
+			"/admin/loggers/unknown",
+			http.MethodDelete,
+		)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		result, err := api.RemoveLoggerOverride(context.Background(), "unknown")
+		assert.Nil(t, result)
+		assertAPIError(t, err, http.StatusNotFound, TitleNotFound)
+	})
+}
+
+func TestAdminAPI_ImportData(t *testing.T) {
+	t.Run("Accepted", func(t *testing.T) {
+		polls := 0
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /admin/import", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/zip", r.Header.Get("Content-Type"))
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, "zip-data", string(body))
+
+			w.Header().Set("Location", "/admin/import/status/42")
+			w.WriteHeader(http.StatusAccepted)
+		})
+		mux.HandleFunc("GET /admin/import/status/42", func(w http.ResponseWriter, r *http.Request) {
+			polls++
+			if polls < 3 {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		op, err := api.ImportData(context.Background(), strings.NewReader("zip-data"), nil)
+		assert.NoError(t, err)
+		if assert.NotNil(t, op) {
+			assert.Equal(t, server.URL+"/admin/import/status/42", op.StatusURL)
+		}
+
+		err = api.WaitForOperation(context.Background(), op, time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, polls)
+	})
+
+	t.Run("Completed", func(t *testing.T) {
+		server := setupMockServer(t, http.StatusNoContent, nil, "/admin/import", http.MethodPost)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		op, err := api.ImportData(context.Background(), strings.NewReader("zip-data"), nil)
+		assert.NoError(t, err)
+		assert.Nil(t, op)
+	})
+
+	t.Run("Preserve IDs", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "true", r.Header.Get("X-Registry-Preserve-GlobalId"))
+			assert.Equal(t, "false", r.Header.Get("X-Registry-Preserve-ContentId"))
+			assert.Equal(t, "true", r.URL.Query().Get("preserveGlobalId"))
+			assert.Equal(t, "false", r.URL.Query().Get("preserveContentId"))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		params := &models.ImportParams{PreserveGlobalID: true}
+		op, err := api.ImportData(context.Background(), strings.NewReader("zip-data"), params)
+		assert.NoError(t, err)
+		assert.Nil(t, op)
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		server := setupMockServer(
+			t,
+			http.StatusConflict,
+			models.APIError{Status: http.StatusConflict, Title: TitleConflict},
+			"/admin/import",
+			http.MethodPost,
+		)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		op, err := api.ImportData(context.Background(), strings.NewReader("zip-data"), nil)
+		assert.Nil(t, op)
+		assertAPIError(t, err, http.StatusConflict, TitleConflict)
+	})
+
+	t.Run("Streaming Body", func(t *testing.T) {
+		chunks := []string{"PK\x03\x04", "first-entry", "second-entry"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/zip", r.Header.Get("Content-Type"))
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, strings.Join(chunks, ""), string(body))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		pr, pw := io.Pipe()
+		go func() {
+			for _, chunk := range chunks {
+				_, _ = pw.Write([]byte(chunk))
+			}
+			_ = pw.Close()
+		}()
+
+		op, err := api.ImportData(context.Background(), pr, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, op)
+	})
+
+	t.Run("Rate Limited", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			_, _ = io.Copy(io.Discard, r.Body)
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"status": 429, "title": "Too many requests"}`))
+		}))
+		defer server.Close()
+
+		api := apis.NewAdminAPI(client.NewClient(
+			server.URL,
+			client.WithMaxRetries(2),
+			client.WithRequestCompression(1),
+		))
+
+		t.Run("Buffered", func(t *testing.T) {
+			attempts = 0
+			op, err := api.ImportData(context.Background(), strings.NewReader("zip-data"), nil)
+			assert.Nil(t, op)
+			assertAPIError(t, err, http.StatusTooManyRequests, "Too many requests")
+			assert.Equal(t, 3, attempts)
+		})
+
+		t.Run("Streamed", func(t *testing.T) {
+			attempts = 0
+			pr, pw := io.Pipe()
+			go func() {
+				_, _ = pw.Write([]byte("zip-data"))
+				_ = pw.Close()
+			}()
+
+			op, err := api.ImportData(context.Background(), pr, nil)
+			assert.Nil(t, op)
+			assertAPIError(t, err, http.StatusTooManyRequests, "Too many requests")
+			assert.Equal(t, 1, attempts, "a consumed stream is not replayed")
+		})
+	})
+
+	t.Run("Operation Failed", func(t *testing.T) {
+		server := setupMockServer(
+			t,
+			http.StatusInternalServerError,
+			models.APIError{Status: http.StatusInternalServerError, Title: TitleInternalServerError},
+			"/admin/import/status/42",
+			http.MethodGet,
+		)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		op := &models.AsyncOperation{StatusURL: server.URL + "/admin/import/status/42"}
+		err := api.WaitForOperation(context.Background(), op, time.Millisecond)
+		assertAPIError(t, err, http.StatusInternalServerError, TitleInternalServerError)
+	})
+
+	t.Run("Foreign Status URL", func(t *testing.T) {
+		var polls int
+		foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			polls++
+		}))
+		defer foreign.Close()
+
+		mockClient := &client.Client{BaseURL: "http://registry.example.com/apis/registry/v3", HTTPClient: foreign.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		for _, statusURL := range []string{foreign.URL + "/admin/import/status/42", "https://registry.example.com/status/42"} {
+			op := &models.AsyncOperation{StatusURL: statusURL}
+			err := api.WaitForOperation(context.Background(), op, time.Millisecond)
+			assert.ErrorContains(t, err, "is not on the registry at http://registry.example.com")
+		}
+		assert.Zero(t, polls, "the status URL must not be requested")
+	})
+}
+
+func TestAdminAPI_ExportData(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		f, err := zw.Create("artifacts/test-group/test-artifact.json")
+		assert.NoError(t, err)
+		_, _ = f.Write([]byte(stubArtifactContent))
+		assert.NoError(t, zw.Close())
+		archiveBytes := buf.Bytes()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/admin/export", r.URL.Path)
+			assert.Equal(t, "application/zip", r.Header.Get("Accept"))
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write(archiveBytes)
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		archive, op, err := api.ExportData(context.Background())
+		assert.NoError(t, err)
+		assert.Nil(t, op)
+		if assert.NotNil(t, archive) {
+			defer archive.Close()
+			data, err := io.ReadAll(archive)
+			assert.NoError(t, err)
+			assert.Equal(t, archiveBytes, data, "the archive is passed through unmodified")
+		}
+	})
+
+	t.Run("Internal Server Error", func(t *testing.T) {
+		errorResponse := models.APIError{Status: http.StatusInternalServerError, Title: TitleInternalServerError}
+		server := setupMockServer(t, http.StatusInternalServerError, errorResponse, "/admin/export", http.MethodGet)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		archive, op, err := api.ExportData(context.Background())
+		assert.Nil(t, archive)
+		assert.Nil(t, op)
+		assertAPIError(t, err, http.StatusInternalServerError, TitleInternalServerError)
+	})
+
+	t.Run("Accepted", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Location", "https://registry.example.com/admin/export/status/7")
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		archive, op, err := api.ExportData(context.Background())
+		assert.NoError(t, err)
+		assert.Nil(t, archive)
+		if assert.NotNil(t, op) {
+			assert.Equal(t, "https://registry.example.com/admin/export/status/7", op.StatusURL)
+		}
+	})
+}
+
+func TestAdminAPI_ImportArtifacts(t *testing.T) {
+	newArtifact := func(artifactID string) models.ImportArtifact {
+		return models.ImportArtifact{
+			GroupID: stubGroupId,
+			Artifact: models.CreateArtifactRequest{
+				ArtifactID:   artifactID,
+				ArtifactType: models.Json,
+				FirstVersion: models.CreateVersionRequest{
+					Content: models.CreateContentRequest{Content: stubArtifactContent, ContentType: "application/json"},
+				},
+			},
+		}
+	}
+
+	t.Run("Skip Existing", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "FAIL", r.URL.Query().Get("ifExists"))
+
+			var request models.CreateArtifactRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+			w.Header().Set("Content-Type", "application/json")
+			switch request.ArtifactID {
+			case "existing":
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(models.APIError{
+					Status: http.StatusConflict,
+					Title:  TitleConflict,
+					Name:   "ArtifactAlreadyExistsException",
+				})
+				return
+			case "invalid":
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(models.APIError{
+					Status: http.StatusConflict,
+					Title:  "Rule violation",
+					Name:   "RuleViolationException",
+				})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(models.CreateArtifactResponse{
+				Artifact: models.ArtifactDetail{GroupID: stubGroupId, ArtifactID: request.ArtifactID},
+			})
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		summary, err := api.ImportArtifacts(
+			context.Background(),
+			[]models.ImportArtifact{newArtifact("existing"), newArtifact("new")},
+			models.ImportConflictSkip,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{stubGroupId + "/new"}, summary.Imported)
+		assert.Equal(t, []string{stubGroupId + "/existing"}, summary.Skipped)
+
+		// Only an artifact that already exists is skipped; other conflicts fail the import.
+		summary, err = api.ImportArtifacts(
+			context.Background(),
+			[]models.ImportArtifact{newArtifact("existing"), newArtifact("invalid"), newArtifact("new")},
+			models.ImportConflictSkip,
+		)
+		assertAPIError(t, err, http.StatusConflict, "Rule violation")
+		assert.Empty(t, summary.Imported)
+		assert.Equal(t, []string{stubGroupId + "/existing"}, summary.Skipped)
+	})
+
+	t.Run("Overwrite Creates Version", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "CREATE_VERSION", r.URL.Query().Get("ifExists"))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(models.CreateArtifactResponse{
+				Artifact: models.ArtifactDetail{GroupID: stubGroupId, ArtifactID: "existing"},
+			})
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		summary, err := api.ImportArtifacts(
+			context.Background(),
+			[]models.ImportArtifact{newArtifact("existing")},
+			models.ImportConflictOverwrite,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{stubGroupId + "/existing"}, summary.Imported)
+		assert.Empty(t, summary.Skipped)
+	})
+
+	t.Run("Fail On Conflict", func(t *testing.T) {
+		server := setupMockServer(
+			t,
+			http.StatusConflict,
+			models.APIError{Status: http.StatusConflict, Title: TitleConflict},
+			"/groups/"+stubGroupId+"/artifacts",
+			http.MethodPost,
+		)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		summary, err := api.ImportArtifacts(
+			context.Background(),
+			[]models.ImportArtifact{newArtifact("existing")},
+			models.ImportConflictFail,
+		)
+		assert.Empty(t, summary.Imported)
+		assertAPIError(t, err, http.StatusConflict, TitleConflict)
+	})
+
+	t.Run("Invalid Strategy", func(t *testing.T) {
+		api := apis.NewAdminAPI(&client.Client{})
+
+		summary, err := api.ImportArtifacts(context.Background(), nil, "MERGE")
+		assert.Nil(t, summary)
+		assert.Error(t, err)
+	})
+}
+
+func TestAdminAPI_ListConfigPropertyDefinitions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/admin/config/properties", r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[
+				{
+					"name": "apicurio.rest.deletion.artifact.enabled",
+					"value": "false",
+					"type": "java.lang.Boolean",
+					"label": "Delete artifact",
+					"description": "Enables the deletion of artifacts"
+				},
+				{
+					"name": "apicurio.ccompat.legacy-id-mode.enabled",
+					"value": "false",
+					"type": "java.lang.Boolean",
+					"label": "Legacy ID mode",
+					"description": "Uses global IDs in the Confluent compatible API",
+					"allowedValues": ["true", "false"]
+				}
+			]`))
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		result, err := api.ListConfigPropertyDefinitions(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ConfigPropertyDefinition{
+			{
+				Name:        "apicurio.rest.deletion.artifact.enabled",
+				Value:       "false",
+				Type:        "java.lang.Boolean",
+				Label:       "Delete artifact",
+				Description: "Enables the deletion of artifacts",
+			},
+			{
+				Name:          "apicurio.ccompat.legacy-id-mode.enabled",
+				Value:         "false",
+				Type:          "java.lang.Boolean",
+				Label:         "Legacy ID mode",
+				Description:   "Uses global IDs in the Confluent compatible API",
+				AllowedValues: []string{"true", "false"},
+			},
+		}, result)
+	})
+
+	t.Run("InternalServerError", func(t *testing.T) {
+		errorResponse := models.APIError{Status: http.StatusInternalServerError, Title: TitleInternalServerError}
+		server := setupMockServer(t, http.StatusInternalServerError, errorResponse, "/admin/config/properties", http.MethodGet)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewAdminAPI(mockClient)
+
+		result, err := api.ListConfigPropertyDefinitions(context.Background())
+		assert.Nil(t, result)
+		assertAPIError(t, err, http.StatusInternalServerError, TitleInternalServerError)
+	})
+}
+
 /***********************/
 /***** Integration *****/
 /***********************/
@@ -1,12 +1,17 @@
 package apis
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/mollie/go-apicurio-registry/client"
+	"github.com/mollie/go-apicurio-registry/internal/plumbing"
 	"github.com/mollie/go-apicurio-registry/models"
 	"github.com/pkg/errors"
 )
@@ -22,23 +27,40 @@ func NewArtifactsAPI(client *client.Client) *ArtifactsAPI {
 }
 
 // GetArtifactByGlobalID Gets the content for an artifact version in the registry using its globally unique identifier.
+// The group and artifact IDs of the version are filled in when the registry reports them in the response headers.
+// When the client's content cache is enabled, content without reference handling is served from and stored in the cache.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Artifacts/operation/getContentByGlobalId
 func (api *ArtifactsAPI) GetArtifactByGlobalID(
 	ctx context.Context,
 	globalID int64,
 	params *models.GetArtifactByGlobalIDParams,
 ) (*models.ArtifactContent, error) {
+	ctx = client.WithOperationName(ctx, "GetArtifactByGlobalID")
+
 	returnArtifactType := false
-	query := ""
+	var expectedType models.ArtifactType
+	query := url.Values{}
 	if params != nil {
 		if err := params.Validate(); err != nil {
 			return nil, errors.Wrap(err, "invalid parameters provided")
 		}
 		returnArtifactType = params.ReturnArtifactType
-		query = "?" + params.ToQuery().Encode()
+		expectedType = params.ExpectedArtifactType
+		query = params.ToQuery()
+	}
+
+	cacheable := plumbing.Of(api.Client).ContentCacheEnabled() && (params == nil || params.HandleReferencesType == "")
+	if cacheable {
+		if cached, ok := plumbing.Of(api.Client).CachedContent(globalID); ok {
+			return cachedArtifactContent(cached, returnArtifactType, expectedType)
+		}
+		query.Set("returnType", "true")
 	}
 
-	urlPath := fmt.Sprintf("%s/ids/globalIds/%d%s", api.Client.BaseURL, globalID, query)
+	urlPath := fmt.Sprintf("%s/ids/globalIds/%d", api.Client.BaseURL, globalID)
+	if encoded := query.Encode(); encoded != "" {
+		urlPath += "?" + encoded
+	}
 	resp, err := api.executeRequest(ctx, http.MethodGet, urlPath, nil)
 	if err != nil {
 		return nil, err
@@ -48,6 +70,24 @@ func (api *ArtifactsAPI) GetArtifactByGlobalID(
 	if err != nil {
 		return nil, err
 	}
+	if err := validateRetrievedContent(api.Client, resp, content); err != nil {
+		return nil, err
+	}
+
+	groupID, artifactID := contentProvenance(resp)
+	if cacheable {
+		cachedType, _ := models.ParseArtifactType(resp.Header.Get("X-Registry-ArtifactType"))
+		plumbing.Of(api.Client).CacheContent(globalID, plumbing.CachedContent{
+			Content:      content,
+			ArtifactType: cachedType,
+			GroupID:      groupID,
+			ArtifactID:   artifactID,
+		})
+	}
+
+	if err := checkArtifactType(resp, expectedType); err != nil {
+		return nil, err
+	}
 
 	var artifactType models.ArtifactType
 	if returnArtifactType {
@@ -62,9 +102,33 @@ func (api *ArtifactsAPI) GetArtifactByGlobalID(
 	return &models.ArtifactContent{
 		Content:      content,
 		ArtifactType: artifactType,
+		GroupID:      groupID,
+		ArtifactID:   artifactID,
 	}, nil
 }
 
+// cachedArtifactContent builds the GetArtifactByGlobalID result from cached content.
+func cachedArtifactContent(
+	cached plumbing.CachedContent,
+	returnArtifactType bool,
+	expectedType models.ArtifactType,
+) (*models.ArtifactContent, error) {
+	if expectedType != "" && cached.ArtifactType != expectedType {
+		return nil, errors.Wrapf(
+			models.ErrArtifactTypeMismatch,
+			"expected %s, got '%s'",
+			expectedType,
+			cached.ArtifactType,
+		)
+	}
+
+	result := &models.ArtifactContent{Content: cached.Content, GroupID: cached.GroupID, ArtifactID: cached.ArtifactID}
+	if returnArtifactType {
+		result.ArtifactType = cached.ArtifactType
+	}
+	return result, nil
+}
+
 // SearchArtifacts - Search for artifacts using the given filter parameters.
 // Search for artifacts using the given filter parameters.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Artifacts/operation/searchArtifacts
@@ -72,16 +136,30 @@ func (api *ArtifactsAPI) SearchArtifacts(
 	ctx context.Context,
 	params *models.SearchArtifactsParams,
 ) ([]models.SearchedArtifact, error) {
-	query := ""
+	result, err := api.searchArtifacts(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Artifacts, nil
+}
+
+// searchArtifacts runs a search and returns the page together with the total number of matches.
+func (api *ArtifactsAPI) searchArtifacts(
+	ctx context.Context,
+	params *models.SearchArtifactsParams,
+) (*models.SearchArtifactsAPIResponse, error) {
+	ctx = client.WithOperationName(ctx, "SearchArtifacts")
+
+	query := url.Values{}
 	if params != nil {
 		if err := params.Validate(); err != nil {
 			return nil, errors.Wrap(err, "invalid parameters provided")
 		}
-		query = "?" + params.ToQuery().Encode()
+		query = params.ToQuery()
 	}
 
-	urlPath := fmt.Sprintf("%s/search/artifacts%s", api.Client.BaseURL, query)
-	resp, err := api.executeRequest(ctx, http.MethodGet, urlPath, nil)
+	resp, err := executeSearchRequest(ctx, api.Client, "/search/artifacts", query)
 	if err != nil {
 		return nil, err
 	}
@@ -91,28 +169,40 @@ func (api *ArtifactsAPI) SearchArtifacts(
 		return nil, err
 	}
 
-	return result.Artifacts, nil
+	return &result, nil
 }
 
 // SearchArtifactsByContent searches for artifacts that match the provided content.
 // Returns a paginated list of all artifacts with at least one version that matches the posted content.
+// The content is posted unmodified, with the content type of the artifact type filter when one is set.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Artifacts/operation/searchArtifactsByContent
 func (api *ArtifactsAPI) SearchArtifactsByContent(
 	ctx context.Context,
 	content []byte,
 	params *models.SearchArtifactsByContentParams,
 ) ([]models.SearchedArtifact, error) {
+	ctx = client.WithRetrySafe(client.WithOperationName(ctx, "SearchArtifactsByContent"))
+
 	// Convert params to query string
 	query := ""
+	var artifactType models.ArtifactType
 	if params != nil {
 		if err := params.Validate(); err != nil {
 			return nil, errors.Wrap(err, "invalid parameters provided")
 		}
+		artifactType = models.ArtifactType(params.ArtifactType)
 		query = "?" + params.ToQuery().Encode()
 	}
 
 	url := fmt.Sprintf("%s/search/artifacts%s", api.Client.BaseURL, query)
-	resp, err := api.executeRequest(ctx, http.MethodPost, url, content)
+	resp, err := executeRequestRaw(
+		ctx,
+		api.Client,
+		http.MethodPost,
+		url,
+		bytes.NewReader(content),
+		contentTypeFor(api.Client, artifactType),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -131,6 +221,8 @@ func (api *ArtifactsAPI) ListArtifactReferences(
 	ctx context.Context,
 	contentID int64,
 ) (*[]models.ArtifactReference, error) {
+	ctx = client.WithOperationName(ctx, "ListArtifactReferences")
+
 	urlPath := fmt.Sprintf("%s/ids/contentId/%d/references", api.Client.BaseURL, contentID)
 	resp, err := api.executeRequest(ctx, http.MethodGet, urlPath, nil)
 	if err != nil {
@@ -152,6 +244,8 @@ func (api *ArtifactsAPI) ListArtifactReferencesByGlobalID(
 	globalID int64,
 	params *models.ListArtifactReferencesByGlobalIDParams,
 ) (*[]models.ArtifactReference, error) {
+	ctx = client.WithOperationName(ctx, "ListArtifactReferencesByGlobalID")
+
 	query := ""
 	if params != nil {
 		if err := params.Validate(); err != nil {
@@ -180,6 +274,8 @@ func (api *ArtifactsAPI) ListArtifactReferencesByHash(
 	ctx context.Context,
 	contentHash string,
 ) ([]models.ArtifactReference, error) {
+	ctx = client.WithOperationName(ctx, "ListArtifactReferencesByHash")
+
 	urlPath := fmt.Sprintf(
 		"%s/ids/contentHashes/%s/references",
 		api.Client.BaseURL,
@@ -205,6 +301,8 @@ func (api *ArtifactsAPI) ListArtifactsInGroup(
 	groupID string,
 	params *models.ListArtifactsInGroupParams,
 ) (*models.ListArtifactsResponse, error) {
+	ctx = client.WithOperationName(ctx, "ListArtifactsInGroup")
+
 	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return nil, err
 	}
@@ -236,6 +334,95 @@ func (api *ArtifactsAPI) ListArtifactsInGroup(
 	return &result, nil
 }
 
+// AllArtifactsInGroup Pages through ListArtifactsInGroup and returns every artifact in the group.
+// When the client's MaxElapsedTime budget runs out, the artifacts fetched so far are returned with models.ErrBudgetExceeded.
+func (api *ArtifactsAPI) AllArtifactsInGroup(
+	ctx context.Context,
+	groupID string,
+) ([]models.SearchedArtifact, error) {
+	ctx = client.WithOperationName(ctx, "AllArtifactsInGroup")
+
+	budgetCtx, cancel := withBudget(ctx, api.Client)
+	defer cancel()
+
+	var artifacts []models.SearchedArtifact
+	for {
+		page, err := api.ListArtifactsInGroup(budgetCtx, groupID, &models.ListArtifactsInGroupParams{
+			Offset: len(artifacts),
+			Limit:  pageSize,
+		})
+		if err != nil {
+			return artifacts, budgetError(ctx, budgetCtx, err)
+		}
+
+		artifacts = append(artifacts, page.Artifacts...)
+		if len(page.Artifacts) == 0 || len(artifacts) >= page.Count {
+			return artifacts, nil
+		}
+	}
+}
+
+// ListArtifactsInGroupAll Returns an iterator over every artifact in the group, fetching pages of
+// ListArtifactsInGroup as it advances. Iteration starts at params.Offset, params.Limit sets the page size
+// (100 when zero) and the ordering parameters apply to every page. Pages are fetched with the context passed
+// to Next.
+func (api *ArtifactsAPI) ListArtifactsInGroupAll(
+	ctx context.Context,
+	groupID string,
+	params *models.ListArtifactsInGroupParams,
+) *Iterator[models.SearchedArtifact] {
+	var p models.ListArtifactsInGroupParams
+	if params != nil {
+		p = *params
+	}
+	if p.Limit == 0 {
+		p.Limit = pageSize
+	}
+	it := newIterator(p.Offset, func(ctx context.Context, offset int) ([]models.SearchedArtifact, int, error) {
+		p.Offset = offset
+		result, err := api.ListArtifactsInGroup(ctx, groupID, &p)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Artifacts, result.Count, nil
+	})
+	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
+		it.err = err
+	} else if err := p.Validate(); err != nil {
+		it.err = errors.Wrap(err, "invalid parameters provided")
+	}
+	return it
+}
+
+// SearchArtifactsAll Returns an iterator over every artifact matching the search, fetching pages of
+// SearchArtifacts as it advances. Iteration starts at params.Offset, params.Limit sets the page size
+// (100 when zero) and the filters and ordering apply to every page. Pages are fetched with the context
+// passed to Next.
+func (api *ArtifactsAPI) SearchArtifactsAll(
+	ctx context.Context,
+	params *models.SearchArtifactsParams,
+) *Iterator[models.SearchedArtifact] {
+	var p models.SearchArtifactsParams
+	if params != nil {
+		p = *params
+	}
+	if p.Limit == 0 {
+		p.Limit = pageSize
+	}
+	it := newIterator(p.Offset, func(ctx context.Context, offset int) ([]models.SearchedArtifact, int, error) {
+		p.Offset = offset
+		result, err := api.searchArtifacts(ctx, &p)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Artifacts, result.Count, nil
+	})
+	if err := p.Validate(); err != nil {
+		it.err = errors.Wrap(err, "invalid parameters provided")
+	}
+	return it
+}
+
 // GetArtifactContentByHash Gets the content for an artifact version in the registry using the SHA-256 hash of the content
 // This content hash may be shared by multiple artifact versions in the case where the artifact versions have identical content.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Artifacts/operation/getContentByHash
@@ -243,6 +430,8 @@ func (api *ArtifactsAPI) GetArtifactContentByHash(
 	ctx context.Context,
 	contentHash string,
 ) (*models.ArtifactContent, error) {
+	ctx = client.WithOperationName(ctx, "GetArtifactContentByHash")
+
 	urlPath := fmt.Sprintf(
 		"%s/ids/contentHashes/%s",
 		api.Client.BaseURL,
@@ -263,10 +452,16 @@ func (api *ArtifactsAPI) GetArtifactContentByHash(
 	if err != nil {
 		return nil, err
 	}
+	if err := plumbing.Of(api.Client).ValidateRetrievedContent(artifactType, content); err != nil {
+		return nil, err
+	}
 
+	groupID, artifactID := contentProvenance(resp)
 	return &models.ArtifactContent{
 		Content:      content,
 		ArtifactType: artifactType,
+		GroupID:      groupID,
+		ArtifactID:   artifactID,
 	}, nil
 }
 
@@ -277,6 +472,8 @@ func (api *ArtifactsAPI) GetArtifactContentByID(
 	ctx context.Context,
 	contentID int64,
 ) (*models.ArtifactContent, error) {
+	ctx = client.WithOperationName(ctx, "GetArtifactContentByID")
+
 	urlPath := fmt.Sprintf("%s/ids/contentIds/%d", api.Client.BaseURL, contentID)
 	resp, err := api.executeRequest(ctx, http.MethodGet, urlPath, nil)
 	if err != nil {
@@ -293,17 +490,235 @@ func (api *ArtifactsAPI) GetArtifactContentByID(
 	if err != nil {
 		return nil, err
 	}
+	if err := plumbing.Of(api.Client).ValidateRetrievedContent(artifactType, content); err != nil {
+		return nil, err
+	}
 
+	groupID, artifactID := contentProvenance(resp)
 	return &models.ArtifactContent{
 		Content:      content,
 		ArtifactType: artifactType,
+		GroupID:      groupID,
+		ArtifactID:   artifactID,
 	}, nil
 }
 
+// GetArtifactContentByIDStream Gets the content for an artifact version by its content ID without buffering it.
+// It is the streaming counterpart of GetArtifactContentByID for large content such as protobuf descriptor sets.
+// The caller must close the returned reader.
+func (api *ArtifactsAPI) GetArtifactContentByIDStream(
+	ctx context.Context,
+	contentID int64,
+) (io.ReadCloser, models.ArtifactType, error) {
+	ctx = client.WithOperationName(ctx, "GetArtifactContentByIDStream")
+
+	urlPath := fmt.Sprintf("%s/ids/contentIds/%d", api.Client.BaseURL, contentID)
+	resp, err := api.executeRequest(ctx, http.MethodGet, urlPath, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", handleResponse(resp, http.StatusOK, nil)
+	}
+
+	artifactType, err := parseArtifactTypeHeader(resp)
+	if err != nil {
+		plumbing.DrainBody(resp)
+		return nil, "", err
+	}
+
+	return streamBody(ctx, resp.Body), artifactType, nil
+}
+
+// GetBundledJSONSchema Returns a JSON Schema version as a single self-contained document.
+// Every $ref that names one of the version's registry references is resolved, recursively, and the referenced
+// schema is inlined under "$defs" of the root document, with the $ref rewritten to point there. A schema
+// referenced several times is inlined once, and cycles between schemas are resolved to the same definition.
+// References that are not registry references, such as absolute URLs, are left untouched.
+func (api *ArtifactsAPI) GetBundledJSONSchema(
+	ctx context.Context,
+	groupID, artifactID, version string,
+) ([]byte, error) {
+	ctx = client.WithOperationName(ctx, "GetBundledJSONSchema")
+
+	b := &schemaBundler{
+		versions: NewVersionsAPI(api.Client),
+		defs:     make(map[string]interface{}),
+		names:    make(map[string]string),
+	}
+
+	root, refs, err := b.fetch(ctx, models.ArtifactReference{GroupID: groupID, ArtifactID: artifactID, Version: version})
+	if err != nil {
+		return nil, err
+	}
+	doc, ok := root.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("JSON schema must be an object")
+	}
+	if existing, ok := doc["$defs"].(map[string]interface{}); ok {
+		for name, def := range existing {
+			b.defs[name] = def
+		}
+	}
+
+	if err := b.rewrite(ctx, doc, refs, ""); err != nil {
+		return nil, err
+	}
+	if len(b.defs) > 0 {
+		doc["$defs"] = b.defs
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, errors.Wrap(err, "failed to serialize bundled schema")
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// schemaBundler inlines referenced JSON schemas into the $defs of a root schema.
+type schemaBundler struct {
+	versions *VersionsAPI
+	defs     map[string]interface{} // Definitions of the bundled document
+	names    map[string]string      // Definition name per "groupId/artifactId/version" already inlined
+}
+
+// fetch returns the parsed content of a schema version and its references by name.
+func (b *schemaBundler) fetch(
+	ctx context.Context,
+	ref models.ArtifactReference,
+) (interface{}, map[string]models.ArtifactReference, error) {
+	version := ref.Version
+	if version == "" {
+		version = latestVersionExpression
+	}
+
+	content, err := b.versions.GetArtifactVersionContent(ctx, ref.GroupID, ref.ArtifactID, version, nil)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to fetch schema %s/%s", ref.GroupID, ref.ArtifactID)
+	}
+	references, err := b.versions.GetArtifactVersionReferences(ctx, ref.GroupID, ref.ArtifactID, version,
+		&models.ArtifactVersionReferencesParams{RefType: models.OutBound})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to fetch references of %s/%s", ref.GroupID, ref.ArtifactID)
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(content.Content))
+	decoder.UseNumber()
+	var schema interface{}
+	if err := decoder.Decode(&schema); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to parse schema %s/%s", ref.GroupID, ref.ArtifactID)
+	}
+
+	byName := make(map[string]models.ArtifactReference, len(references))
+	for _, reference := range references {
+		byName[reference.Name] = reference
+	}
+	return schema, byName, nil
+}
+
+// rewrite walks a schema and rewrites its $ref values to point into the bundled $defs. prefix is the
+// location of the schema in the bundled document, which local references ("#/...") are resolved against.
+func (b *schemaBundler) rewrite(
+	ctx context.Context,
+	node interface{},
+	refs map[string]models.ArtifactReference,
+	prefix string,
+) error {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				rewritten, err := b.resolve(ctx, ref, refs, prefix)
+				if err != nil {
+					return err
+				}
+				v[key] = rewritten
+				continue
+			}
+			if err := b.rewrite(ctx, child, refs, prefix); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if err := b.rewrite(ctx, child, refs, prefix); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolve returns the bundled form of a single $ref, inlining the referenced schema on first use.
+func (b *schemaBundler) resolve(
+	ctx context.Context,
+	ref string,
+	refs map[string]models.ArtifactReference,
+	prefix string,
+) (string, error) {
+	base, fragment, _ := strings.Cut(ref, "#")
+	if base == "" {
+		return "#" + prefix + fragment, nil
+	}
+
+	reference, ok := refs[base]
+	if !ok {
+		return ref, nil
+	}
+
+	key := fmt.Sprintf("%s/%s/%s", reference.GroupID, reference.ArtifactID, reference.Version)
+	name, ok := b.names[key]
+	if !ok {
+		name = b.defName(reference.ArtifactID)
+		// Registered before recursing, so a cycle back to this schema resolves to the same definition.
+		b.names[key] = name
+		b.defs[name] = true
+
+		schema, nested, err := b.fetch(ctx, reference)
+		if err != nil {
+			return "", err
+		}
+		if doc, ok := schema.(map[string]interface{}); ok {
+			// Embedded schemas must not change the base URI or dialect their references resolve against.
+			delete(doc, "$id")
+			delete(doc, "$schema")
+		}
+		if err := b.rewrite(ctx, schema, nested, "/$defs/"+name); err != nil {
+			return "", err
+		}
+		b.defs[name] = schema
+	}
+
+	return "#/$defs/" + name + fragment, nil
+}
+
+// defName derives a definition name from an artifact ID that is unique within the bundled $defs.
+func (b *schemaBundler) defName(artifactID string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-' {
+			return r
+		}
+		return '_'
+	}, artifactID)
+
+	unique := name
+	for i := 2; ; i++ {
+		if _, taken := b.defs[unique]; !taken {
+			return unique
+		}
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+}
+
 // DeleteArtifactsInGroup deletes all artifacts in a given group.
 // Deletes all the artifacts that exist in a given group.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Artifacts/operation/deleteArtifactsInGroup
 func (api *ArtifactsAPI) DeleteArtifactsInGroup(ctx context.Context, groupID string) error {
+	ctx = client.WithOperationName(ctx, "DeleteArtifactsInGroup")
+
 	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
 	}
@@ -317,10 +732,51 @@ func (api *ArtifactsAPI) DeleteArtifactsInGroup(ctx context.Context, groupID str
 	return handleResponse(resp, http.StatusNoContent, nil)
 }
 
+// DeleteArtifactsInGroupWithResult deletes all artifacts in a given group and reports how many were deleted.
+// The registry answers with 204 No Content, so the artifacts are counted right before deletion; artifacts created
+// concurrently may make the count inexact. A count returned by the server in a 200 response takes precedence.
+func (api *ArtifactsAPI) DeleteArtifactsInGroupWithResult(
+	ctx context.Context,
+	groupID string,
+) (*models.DeleteGroupResult, error) {
+	ctx = client.WithOperationName(ctx, "DeleteArtifactsInGroupWithResult")
+
+	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
+		return nil, err
+	}
+
+	page, err := api.ListArtifactsInGroup(ctx, groupID, &models.ListArtifactsInGroupParams{Limit: 1})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to count artifacts in group %s", groupID)
+	}
+
+	urlPath := fmt.Sprintf("%s/groups/%s/artifacts", api.Client.BaseURL, url.PathEscape(groupID))
+	resp, err := api.executeRequest(ctx, http.MethodDelete, urlPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		var result models.DeleteGroupResult
+		if err := handleResponse(resp, http.StatusOK, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
+
+	if err := handleResponse(resp, http.StatusNoContent, nil); err != nil {
+		return nil, err
+	}
+
+	return &models.DeleteGroupResult{Deleted: page.Count}, nil
+}
+
 // DeleteArtifact deletes a specific artifact identified by groupId and artifactId.
 // Deletes an artifact completely, resulting in all versions of the artifact also being deleted. This may fail for one of the following reasons:
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Artifacts/operation/deleteArtifact
 func (api *ArtifactsAPI) DeleteArtifact(ctx context.Context, groupID, artifactId string) error {
+	ctx = client.WithOperationName(ctx, "DeleteArtifact")
+
 	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
 	}
@@ -343,6 +799,10 @@ func (api *ArtifactsAPI) DeleteArtifact(ctx context.Context, groupID, artifactId
 }
 
 // CreateArtifact Creates a new artifact.
+// Once AdminAPI.SupportedArtifactTypes has been fetched, artifact types the registry does not support are rejected locally.
+// An empty content type is derived from the artifact type; see client.WithContentTypeMap.
+// With params.ValidateLocally, the content is checked with models.ValidateContent before it is sent, which
+// validates JSON Schema, Avro and Protobuf content.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Artifacts/operation/createArtifact
 func (api *ArtifactsAPI) CreateArtifact(
 	ctx context.Context,
@@ -350,14 +810,63 @@ func (api *ArtifactsAPI) CreateArtifact(
 	artifact models.CreateArtifactRequest,
 	params *models.CreateArtifactParams,
 ) (*models.ArtifactDetail, error) {
+	ctx = client.WithOperationName(ctx, "CreateArtifact")
+
+	response, err := api.createArtifact(ctx, groupId, artifact, params)
+	if err != nil {
+		return nil, err
+	}
+	return &response.Artifact, nil
+}
+
+// CreateArtifactDetailed Creates a new artifact like CreateArtifact, and also returns the metadata of the version
+// that was created, or found with models.IfExistsFindOrCreateVersion, including its global ID.
+// Registry releases that return the created artifact at the top level of the response do not report the version.
+func (api *ArtifactsAPI) CreateArtifactDetailed(
+	ctx context.Context,
+	groupId string,
+	artifact models.CreateArtifactRequest,
+	params *models.CreateArtifactParams,
+) (*models.CreateArtifactResponse, error) {
+	ctx = client.WithOperationName(ctx, "CreateArtifactDetailed")
+	return api.createArtifact(ctx, groupId, artifact, params)
+}
+
+// createArtifact implements CreateArtifact and CreateArtifactDetailed.
+func (api *ArtifactsAPI) createArtifact(
+	ctx context.Context,
+	groupId string,
+	artifact models.CreateArtifactRequest,
+	params *models.CreateArtifactParams,
+) (*models.CreateArtifactResponse, error) {
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return nil, err
 	}
 
+	if artifact.FirstVersion.Content.ContentType == "" && artifact.FirstVersion.Content.Content != "" {
+		artifact.FirstVersion.Content.ContentType = api.Client.ContentTypeFor(artifact.ArtifactType)
+	}
+
+	content, err := plumbing.Of(api.Client).CanonicalizeForCreate(artifact.ArtifactType, artifact.FirstVersion.Content.Content)
+	if err != nil {
+		return nil, err
+	}
+	artifact.FirstVersion.Content.Content = plumbing.Of(api.Client).MinifyForWrite(artifact.FirstVersion.Content.ContentType, content)
+
 	if err := artifact.Validate(); err != nil {
 		return nil, errors.Wrap(err, "invalid artifact provided")
 	}
+	if params != nil && params.ValidateLocally {
+		if err := models.ValidateContent(artifact.ArtifactType, []byte(content)); err != nil {
+			return nil, errors.Wrap(err, "invalid artifact provided")
+		}
+	}
+
+	if types, ok := plumbing.Of(api.Client).CachedArtifactTypes(); ok && artifact.ArtifactType != "" && !types[artifact.ArtifactType] {
+		return nil, errors.Wrapf(models.ErrUnsupportedArtifactType, "artifact type %s", artifact.ArtifactType)
+	}
 
+	params = withDefaultIfExists(api.Client, params)
 	query := ""
 	if params != nil {
 		if err := params.Validate(); err != nil {
@@ -382,7 +891,21 @@ func (api *ArtifactsAPI) CreateArtifact(
 		return nil, err
 	}
 
-	return &response.Artifact, nil
+	return &response, nil
+}
+
+// withDefaultIfExists applies the client's default IfExists policy to params without modifying the caller's value.
+func withDefaultIfExists(c *client.Client, params *models.CreateArtifactParams) *models.CreateArtifactParams {
+	if c.DefaultIfExists == "" || (params != nil && params.IfExists != "") {
+		return params
+	}
+
+	withDefault := models.CreateArtifactParams{}
+	if params != nil {
+		withDefault = *params
+	}
+	withDefault.IfExists = c.DefaultIfExists
+	return &withDefault
 }
 
 // ListArtifactRules lists all artifact rules for a given artifact.
@@ -391,6 +914,8 @@ func (api *ArtifactsAPI) ListArtifactRules(
 	ctx context.Context,
 	groupID, artifactId string,
 ) ([]models.Rule, error) {
+	ctx = client.WithOperationName(ctx, "ListArtifactRules")
+
 	urlPath := fmt.Sprintf(
 		"%s/groups/%s/artifacts/%s/rules",
 		api.Client.BaseURL,
@@ -418,6 +943,8 @@ func (api *ArtifactsAPI) CreateArtifactRule(
 	rule models.Rule,
 	level models.RuleLevel,
 ) error {
+	ctx = client.WithOperationName(ctx, "CreateArtifactRule")
+
 	urlPath := fmt.Sprintf(
 		"%s/groups/%s/artifacts/%s/rules",
 		api.Client.BaseURL,
@@ -444,6 +971,8 @@ func (api *ArtifactsAPI) DeleteAllArtifactRule(
 	ctx context.Context,
 	groupID, artifactId string,
 ) error {
+	ctx = client.WithOperationName(ctx, "DeleteAllArtifactRule")
+
 	urlPath := fmt.Sprintf(
 		"%s/groups/%s/artifacts/%s/rules",
 		api.Client.BaseURL,
@@ -465,6 +994,8 @@ func (api *ArtifactsAPI) GetArtifactRule(
 	groupID, artifactId string,
 	rule models.Rule,
 ) (models.RuleLevel, error) {
+	ctx = client.WithOperationName(ctx, "GetArtifactRule")
+
 	urlPath := fmt.Sprintf(
 		"%s/groups/%s/artifacts/%s/rules/%s",
 		api.Client.BaseURL,
@@ -493,6 +1024,8 @@ func (api *ArtifactsAPI) UpdateArtifactRule(
 	rule models.Rule,
 	level models.RuleLevel,
 ) error {
+	ctx = client.WithOperationName(ctx, "UpdateArtifactRule")
+
 	urlPath := fmt.Sprintf(
 		"%s/groups/%s/artifacts/%s/rules/%s",
 		api.Client.BaseURL,
@@ -526,6 +1059,8 @@ func (api *ArtifactsAPI) DeleteArtifactRule(
 	groupID, artifactId string,
 	rule models.Rule,
 ) error {
+	ctx = client.WithOperationName(ctx, "DeleteArtifactRule")
+
 	urlPath := fmt.Sprintf(
 		"%s/groups/%s/artifacts/%s/rules/%s",
 		api.Client.BaseURL,
@@ -1,11 +1,18 @@
 package apis_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -104,6 +111,278 @@ func TestArtifactsAPI_GetArtifactByGlobalID(t *testing.T) {
 	})
 }
 
+func TestArtifactsAPI_GetArtifactByGlobalID_Provenance(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/ids/globalIds/42", r.URL.Path)
+		w.Header().Set("X-Registry-ArtifactType", string(models.Avro))
+		w.Header().Set("X-Registry-GroupId", stubGroupId)
+		w.Header().Set("X-Registry-ArtifactId", stubArtifactId)
+		_, _ = w.Write([]byte(stubArtifactContent))
+	}))
+	defer server.Close()
+
+	mockClient := client.NewClient(
+		server.URL,
+		client.WithHTTPClient(server.Client()),
+		client.WithContentCache(10),
+	)
+	api := apis.NewArtifactsAPI(mockClient)
+
+	for i := 0; i < 2; i++ {
+		result, err := api.GetArtifactByGlobalID(context.Background(), 42, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, stubGroupId, result.GroupID)
+		assert.Equal(t, stubArtifactId, result.ArtifactID)
+	}
+	assert.Equal(t, 1, requests, "provenance must be served from the cache as well")
+}
+
+func TestArtifactsAPI_GetArtifactByGlobalID_ContentCache(t *testing.T) {
+	t.Run("WarmUp Preloads Cache", func(t *testing.T) {
+		var requests []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.URL.Path)
+			switch r.URL.Path {
+			case "/system/info":
+				assert.NoError(t, json.NewEncoder(w).Encode(models.SystemInfoResponse{Name: "Apicurio Registry"}))
+			case "/ids/globalIds/1", "/ids/globalIds/2":
+				assert.Equal(t, "true", r.URL.Query().Get("returnType"))
+				w.Header().Set("X-Registry-ArtifactType", string(models.Avro))
+				_, _ = w.Write([]byte(stubArtifactContent))
+			default:
+				t.Errorf("unexpected request to %s", r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		mockClient := client.NewClient(
+			server.URL,
+			client.WithHTTPClient(server.Client()),
+			client.WithContentCache(10),
+		)
+		assert.NoError(t, mockClient.WarmUp(context.Background(), 1, 2))
+		assert.Equal(t, []string{"/system/info", "/ids/globalIds/1", "/ids/globalIds/2"}, requests)
+
+		api := apis.NewArtifactsAPI(mockClient)
+		result, err := api.GetArtifactByGlobalID(
+			context.Background(),
+			1,
+			&models.GetArtifactByGlobalIDParams{ReturnArtifactType: true},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, stubArtifactContent, result.Content)
+		assert.Equal(t, models.Avro, result.ArtifactType)
+
+		_, err = api.GetArtifactByGlobalID(
+			context.Background(),
+			2,
+			&models.GetArtifactByGlobalIDParams{ExpectedArtifactType: models.Protobuf},
+		)
+		assert.ErrorIs(t, err, models.ErrArtifactTypeMismatch)
+		assert.Len(t, requests, 3, "cached content must not hit the server")
+	})
+
+	t.Run("WarmUp Without Cache Only Pings", func(t *testing.T) {
+		var requests []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.URL.Path)
+			assert.NoError(t, json.NewEncoder(w).Encode(models.SystemInfoResponse{}))
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		assert.NoError(t, mockClient.WarmUp(context.Background(), 1))
+		assert.Equal(t, []string{"/system/info"}, requests)
+	})
+}
+
+func TestArtifactsAPI_GetArtifactContentByIDStream(t *testing.T) {
+	t.Run("Streams Without Buffering", func(t *testing.T) {
+		chunk := bytes.Repeat([]byte("x"), 64*1024)
+		const chunks = 128 // 8 MiB in total
+		release := make(chan struct{})
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/ids/contentIds/1", r.URL.Path)
+			w.Header().Set("X-Registry-ArtifactType", string(models.Protobuf))
+			_, _ = w.Write(chunk)
+			w.(http.Flusher).Flush()
+
+			// The rest is only sent once the client has consumed the first chunk, which a buffering
+			// implementation never does before returning.
+			select {
+			case <-release:
+			case <-r.Context().Done():
+				return
+			}
+			for i := 1; i < chunks; i++ {
+				_, _ = w.Write(chunk)
+			}
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		body, artifactType, err := api.GetArtifactContentByIDStream(ctx, 1)
+		assert.NoError(t, err)
+		defer body.Close()
+		assert.Equal(t, models.Protobuf, artifactType)
+
+		first := make([]byte, len(chunk))
+		_, err = io.ReadFull(body, first)
+		assert.NoError(t, err)
+		close(release)
+
+		rest, err := io.Copy(io.Discard, body)
+		assert.NoError(t, err)
+		assert.Equal(t, int64((chunks-1)*len(chunk)), rest)
+	})
+
+	t.Run("Cancelled Mid-Stream", func(t *testing.T) {
+		chunk := bytes.Repeat([]byte("x"), 64*1024)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Registry-ArtifactType", string(models.Protobuf))
+			// Two chunks are flushed so data is still buffered on the client when the context is cancelled.
+			_, _ = w.Write(chunk)
+			_, _ = w.Write(chunk)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		body, _, err := api.GetArtifactContentByIDStream(ctx, 1)
+		assert.NoError(t, err)
+		defer body.Close()
+
+		first := make([]byte, len(chunk))
+		_, err = io.ReadFull(body, first)
+		assert.NoError(t, err)
+
+		cancel()
+		n, err := body.Read(first)
+		assert.Zero(t, n)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockErrorResponse := models.APIError{Status: http.StatusNotFound, Title: TitleNotFound}
+		server := setupMockServer(t, http.StatusNotFound, mockErrorResponse, "/ids/contentIds/1", http.MethodGet)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		body, _, err := api.GetArtifactContentByIDStream(context.Background(), 1)
+		assert.Nil(t, body)
+		assertAPIError(t, err, http.StatusNotFound, TitleNotFound)
+	})
+}
+
+func TestArtifactsAPI_GetBundledJSONSchema(t *testing.T) {
+	// serve registers the content and references of schema versions keyed by artifact ID.
+	serve := func(t *testing.T, schemas map[string]string, references map[string][]models.ArtifactReference) *httptest.Server {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /groups/{groupId}/artifacts/{artifactId}/versions/{version}/content", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, stubGroupId, r.PathValue("groupId"))
+			assert.Equal(t, "1", r.PathValue("version"))
+			w.Header().Set("X-Registry-ArtifactType", string(models.Json))
+			_, _ = w.Write([]byte(schemas[r.PathValue("artifactId")]))
+		})
+		mux.HandleFunc("GET /groups/{groupId}/artifacts/{artifactId}/versions/{version}/references", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "OUTBOUND", r.URL.Query().Get("refType"))
+			refs := references[r.PathValue("artifactId")]
+			if refs == nil {
+				refs = []models.ArtifactReference{}
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(refs))
+		})
+		return httptest.NewServer(mux)
+	}
+
+	t.Run("Inlines External Reference", func(t *testing.T) {
+		server := serve(t, map[string]string{
+			"person": `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "object",
+				"properties": {
+					"home": {"$ref": "address.json"},
+					"work": {"$ref": "address.json"},
+					"name": {"$ref": "#/$defs/name"}
+				},
+				"$defs": {"name": {"type": "string"}}
+			}`,
+			"address": `{
+				"$id": "https://example.com/address.json",
+				"type": "object",
+				"properties": {"zip": {"$ref": "#/definitions/zip"}},
+				"definitions": {"zip": {"type": "string", "pattern": "^[0-9]{4}$"}}
+			}`,
+		}, map[string][]models.ArtifactReference{
+			"person": {{GroupID: stubGroupId, ArtifactID: "address", Version: "1", Name: "address.json"}},
+		})
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		bundled, err := api.GetBundledJSONSchema(context.Background(), stubGroupId, "person", "1")
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"home": {"$ref": "#/$defs/address"},
+				"work": {"$ref": "#/$defs/address"},
+				"name": {"$ref": "#/$defs/name"}
+			},
+			"$defs": {
+				"name": {"type": "string"},
+				"address": {
+					"type": "object",
+					"properties": {"zip": {"$ref": "#/$defs/address/definitions/zip"}},
+					"definitions": {"zip": {"type": "string", "pattern": "^[0-9]{4}$"}}
+				}
+			}
+		}`, string(bundled))
+	})
+
+	t.Run("Cyclic References", func(t *testing.T) {
+		server := serve(t, map[string]string{
+			"root": `{"$ref": "node.json"}`,
+			"node": `{"type": "object", "properties": {"children": {"type": "array", "items": {"$ref": "node.json"}}}}`,
+		}, map[string][]models.ArtifactReference{
+			"root": {{GroupID: stubGroupId, ArtifactID: "node", Version: "1", Name: "node.json"}},
+			"node": {{GroupID: stubGroupId, ArtifactID: "node", Version: "1", Name: "node.json"}},
+		})
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		bundled, err := api.GetBundledJSONSchema(context.Background(), stubGroupId, "root", "1")
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$ref": "#/$defs/node",
+			"$defs": {
+				"node": {"type": "object", "properties": {"children": {"type": "array", "items": {"$ref": "#/$defs/node"}}}}
+			}
+		}`, string(bundled))
+	})
+}
+
 func TestArtifactsAPI_SearchArtifacts(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockResponse := models.SearchArtifactsAPIResponse{
@@ -165,6 +444,72 @@ func TestArtifactsAPI_SearchArtifacts(t *testing.T) {
 		assert.Nil(t, result)
 		assertAPIError(t, err, http.StatusInternalServerError, TitleInternalServerError)
 	})
+
+	t.Run("Labels Parsed", func(t *testing.T) {
+		response := `{"artifacts": [{"groupId": "test-group", "artifactId": "artifact-1", "artifactType": "AVRO",
+			"description": "Payment events", "labels": {"team": "payments", "tier": "gold"}}], "count": 1}`
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "team:payments", r.URL.Query().Get("labels"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(response))
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		result, err := api.SearchArtifacts(
+			context.Background(),
+			&models.SearchArtifactsParams{Labels: []string{"team:payments"}},
+		)
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "Payment events", result[0].Description)
+		assert.Equal(t, map[string]string{"team": "payments", "tier": "gold"}, result[0].Labels)
+	})
+
+	t.Run("Long Query Is Sent By Default", func(t *testing.T) {
+		labels := make([]string, 0, 500)
+		for i := 0; i < 500; i++ {
+			labels = append(labels, fmt.Sprintf("team-%d:payments-platform", i))
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, strings.Join(labels, ","), r.URL.Query().Get("labels"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"count": 0, "artifacts": []}`))
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		result, err := api.SearchArtifacts(context.Background(), &models.SearchArtifactsParams{Labels: labels})
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Long Query Is Rejected", func(t *testing.T) {
+		labels := make([]string, 0, 50)
+		for i := 0; i < 50; i++ {
+			labels = append(labels, fmt.Sprintf("team-%d:payments-platform", i))
+		}
+
+		var requests int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client(), MaxURLLength: 512}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		result, err := api.SearchArtifacts(context.Background(), &models.SearchArtifactsParams{Labels: labels})
+		assert.ErrorIs(t, err, models.ErrQueryTooLong)
+		assert.Nil(t, result)
+		assert.Zero(t, requests)
+	})
 }
 
 func TestArtifactsAPI_SearchArtifactsByContent(t *testing.T) {
@@ -208,6 +553,28 @@ func TestArtifactsAPI_SearchArtifactsByContent(t *testing.T) {
 		assert.Equal(t, models.Avro, result[0].ArtifactType)
 	})
 
+	t.Run("Raw Body Sent Unmodified", func(t *testing.T) {
+		// Protobuf content is not valid JSON and must not be wrapped or escaped.
+		content := []byte("syntax = \"proto3\";\nmessage Ping { string id = 1; }\n\x00\xff")
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, content, body)
+			assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+
+			assert.NoError(t, json.NewEncoder(w).Encode(models.SearchArtifactsAPIResponse{}))
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		params := &models.SearchArtifactsByContentParams{ArtifactType: string(models.Protobuf)}
+		_, err := api.SearchArtifactsByContent(context.Background(), content, params)
+		assert.NoError(t, err)
+	})
+
 	t.Run("Invalid Content", func(t *testing.T) {
 		errorResponse := models.APIError{Status: http.StatusBadRequest, Title: TitleBadRequest}
 		server := setupMockServer(
@@ -452,6 +819,191 @@ func TestArtifactsAPI_ListArtifactsInGroup(t *testing.T) {
 	})
 }
 
+func TestArtifactsAPI_AllArtifactsInGroup(t *testing.T) {
+	t.Run("All Pages", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			offset := r.URL.Query().Get("offset")
+			artifacts := []models.SearchedArtifact{{ArtifactId: "artifact-" + offset, ArtifactType: models.Avro}}
+			assert.NoError(t, json.NewEncoder(w).Encode(models.ListArtifactsResponse{Artifacts: artifacts, Count: 3}))
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		artifacts, err := api.AllArtifactsInGroup(context.Background(), stubGroupId)
+		assert.NoError(t, err)
+		assert.Len(t, artifacts, 3)
+		assert.Equal(t, "artifact-2", artifacts[2].ArtifactId)
+	})
+}
+
+func TestArtifactsAPI_ListArtifactsInGroupAll(t *testing.T) {
+	// Three pages of two artifacts; failAt makes the request for that offset fail.
+	newServer := func(t *testing.T, failAt string) (*httptest.Server, *[]string) {
+		var requested []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			offset := r.URL.Query().Get("offset")
+			if offset == "" {
+				offset = "0"
+			}
+			requested = append(requested, offset)
+			assert.Equal(t, "2", r.URL.Query().Get("limit"))
+			assert.Equal(t, "asc", r.URL.Query().Get("order"))
+
+			if offset == failAt {
+				w.WriteHeader(http.StatusInternalServerError)
+				assert.NoError(t, json.NewEncoder(w).Encode(models.APIError{
+					Status: http.StatusInternalServerError,
+					Title:  TitleInternalServerError,
+				}))
+				return
+			}
+
+			start, _ := strconv.Atoi(offset)
+			var artifacts []models.SearchedArtifact
+			for i := start; i < start+2 && i < 6; i++ {
+				artifacts = append(artifacts, models.SearchedArtifact{ArtifactId: fmt.Sprintf("artifact-%d", i), ArtifactType: models.Avro})
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(models.ListArtifactsResponse{Artifacts: artifacts, Count: 6}))
+		}))
+		return server, &requested
+	}
+	params := &models.ListArtifactsInGroupParams{Limit: 2, Order: models.OrderAsc}
+
+	t.Run("Three Pages", func(t *testing.T) {
+		server, requested := newServer(t, "")
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		var ids []string
+		it := api.ListArtifactsInGroupAll(context.Background(), stubGroupId, params)
+		for it.Next(context.Background()) {
+			ids = append(ids, it.Value().ArtifactId)
+		}
+		assert.NoError(t, it.Err())
+		assert.Equal(t, []string{"artifact-0", "artifact-1", "artifact-2", "artifact-3", "artifact-4", "artifact-5"}, ids)
+		assert.Equal(t, []string{"0", "2", "4"}, *requested, "no request is made past the last page")
+		assert.False(t, it.Next(context.Background()))
+	})
+
+	t.Run("Error Mid-Iteration", func(t *testing.T) {
+		server, _ := newServer(t, "2")
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		var ids []string
+		it := api.ListArtifactsInGroupAll(context.Background(), stubGroupId, params)
+		for it.Next(context.Background()) {
+			ids = append(ids, it.Value().ArtifactId)
+		}
+		assert.Equal(t, []string{"artifact-0", "artifact-1"}, ids)
+		assertAPIError(t, it.Err(), http.StatusInternalServerError, TitleInternalServerError)
+	})
+
+	t.Run("Empty Group ID", func(t *testing.T) {
+		mockClient := &client.Client{BaseURL: "http://localhost:9999", HTTPClient: http.DefaultClient}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		it := api.ListArtifactsInGroupAll(context.Background(), "", nil)
+		assert.False(t, it.Next(context.Background()))
+		assert.ErrorIs(t, it.Err(), models.ErrInvalidInput)
+	})
+}
+
+func TestArtifactsAPI_SearchArtifactsAll(t *testing.T) {
+	// Serves total matching artifacts in pages of two and records the requested offsets.
+	newServer := func(t *testing.T, total int) (*httptest.Server, *[]string) {
+		var requested []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/search/artifacts", r.URL.Path)
+			offset := r.URL.Query().Get("offset")
+			if offset == "" {
+				offset = "0"
+			}
+			requested = append(requested, offset)
+			assert.Equal(t, "2", r.URL.Query().Get("limit"))
+			assert.Equal(t, "orders", r.URL.Query().Get("name"))
+
+			start, _ := strconv.Atoi(offset)
+			var artifacts []models.SearchedArtifact
+			for i := start; i < start+2 && i < total; i++ {
+				artifacts = append(artifacts, models.SearchedArtifact{ArtifactId: fmt.Sprintf("artifact-%d", i), ArtifactType: models.Avro})
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(models.SearchArtifactsAPIResponse{Artifacts: artifacts, Count: total}))
+		}))
+		return server, &requested
+	}
+	params := &models.SearchArtifactsParams{Name: "orders", Limit: 2}
+
+	collect := func(it *apis.Iterator[models.SearchedArtifact]) []string {
+		ids := []string{}
+		for it.Next(context.Background()) {
+			ids = append(ids, it.Value().ArtifactId)
+		}
+		return ids
+	}
+
+	t.Run("Empty Result", func(t *testing.T) {
+		server, requested := newServer(t, 0)
+		defer server.Close()
+
+		api := apis.NewArtifactsAPI(&client.Client{BaseURL: server.URL, HTTPClient: server.Client()})
+
+		it := api.SearchArtifactsAll(context.Background(), params)
+		assert.Empty(t, collect(it))
+		assert.NoError(t, it.Err())
+		assert.Equal(t, []string{"0"}, *requested)
+	})
+
+	t.Run("Single Page", func(t *testing.T) {
+		server, requested := newServer(t, 2)
+		defer server.Close()
+
+		api := apis.NewArtifactsAPI(&client.Client{BaseURL: server.URL, HTTPClient: server.Client()})
+
+		it := api.SearchArtifactsAll(context.Background(), params)
+		assert.Equal(t, []string{"artifact-0", "artifact-1"}, collect(it))
+		assert.NoError(t, it.Err())
+		assert.Equal(t, []string{"0"}, *requested)
+	})
+
+	t.Run("Multiple Pages", func(t *testing.T) {
+		server, requested := newServer(t, 5)
+		defer server.Close()
+
+		api := apis.NewArtifactsAPI(&client.Client{BaseURL: server.URL, HTTPClient: server.Client()})
+
+		it := api.SearchArtifactsAll(context.Background(), params)
+		assert.Equal(t, []string{"artifact-0", "artifact-1", "artifact-2", "artifact-3", "artifact-4"}, collect(it))
+		assert.NoError(t, it.Err())
+		assert.Equal(t, []string{"0", "2", "4"}, *requested)
+	})
+
+	t.Run("Context Canceled Between Pages", func(t *testing.T) {
+		server, requested := newServer(t, 5)
+		defer server.Close()
+
+		api := apis.NewArtifactsAPI(&client.Client{BaseURL: server.URL, HTTPClient: server.Client()})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		it := api.SearchArtifactsAll(ctx, params)
+		var ids []string
+		for it.Next(ctx) {
+			ids = append(ids, it.Value().ArtifactId)
+			cancel()
+		}
+		assert.Equal(t, []string{"artifact-0", "artifact-1"}, ids)
+		assert.ErrorIs(t, it.Err(), context.Canceled)
+		assert.Equal(t, []string{"0"}, *requested)
+	})
+}
+
 func TestArtifactsAPI_GetArtifactContentByHash(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockContent := models.ArtifactContent{
@@ -477,6 +1029,26 @@ func TestArtifactsAPI_GetArtifactContentByHash(t *testing.T) {
 		assert.NotNil(t, result)
 		assert.Equal(t, "{\"key\":\"value\"}", result.Content)
 		assert.Equal(t, models.Json, result.ArtifactType)
+		assert.Empty(t, result.GroupID)
+		assert.Empty(t, result.ArtifactID)
+	})
+
+	t.Run("Provenance Headers", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Registry-ArtifactType", "JSON")
+			w.Header().Set("X-Registry-GroupId", stubGroupId)
+			w.Header().Set("X-Registry-ArtifactId", stubArtifactId)
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		result, err := api.GetArtifactContentByHash(context.Background(), "hash-123")
+		assert.NoError(t, err)
+		assert.Equal(t, stubGroupId, result.GroupID)
+		assert.Equal(t, stubArtifactId, result.ArtifactID)
 	})
 
 	t.Run("Not Found", func(t *testing.T) {
@@ -654,6 +1226,64 @@ func TestArtifactsAPI_DeleteArtifactsInGroup(t *testing.T) {
 	})
 }
 
+func TestArtifactsAPI_DeleteArtifactsInGroupWithResult(t *testing.T) {
+	t.Run("Counts Before Deletion", func(t *testing.T) {
+		deleted := false
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /groups/{groupId}/artifacts", func(w http.ResponseWriter, r *http.Request) {
+			assert.False(t, deleted, "count must be taken before deletion")
+			assert.Equal(t, "1", r.URL.Query().Get("limit"))
+			assert.NoError(t, json.NewEncoder(w).Encode(models.ListArtifactsResponse{Count: 5}))
+		})
+		mux.HandleFunc("DELETE /groups/{groupId}/artifacts", func(w http.ResponseWriter, r *http.Request) {
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		result, err := api.DeleteArtifactsInGroupWithResult(context.Background(), "group-1")
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		assert.Equal(t, &models.DeleteGroupResult{Deleted: 5}, result)
+	})
+
+	t.Run("Server Count", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /groups/{groupId}/artifacts", func(w http.ResponseWriter, r *http.Request) {
+			assert.NoError(t, json.NewEncoder(w).Encode(models.ListArtifactsResponse{Count: 5}))
+		})
+		mux.HandleFunc("DELETE /groups/{groupId}/artifacts", func(w http.ResponseWriter, r *http.Request) {
+			assert.NoError(t, json.NewEncoder(w).Encode(models.DeleteGroupResult{Deleted: 4}))
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		result, err := api.DeleteArtifactsInGroupWithResult(context.Background(), "group-1")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.DeleteGroupResult{Deleted: 4}, result)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		errorResponse := models.APIError{Status: http.StatusNotFound, Title: TitleNotFound}
+		server := setupMockServer(t, http.StatusNotFound, errorResponse, "/groups/group-1/artifacts", http.MethodGet)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		result, err := api.DeleteArtifactsInGroupWithResult(context.Background(), "group-1")
+		assert.Nil(t, result)
+		assertAPIError(t, err, http.StatusNotFound, TitleNotFound)
+	})
+}
+
 func TestArtifactsAPI_DeleteArtifact(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		server := setupMockServer(
@@ -779,6 +1409,246 @@ func TestArtifactsAPI_CreateArtifact(t *testing.T) {
 		assert.Equal(t, "New Artifact", result.Name)
 	})
 
+	t.Run("Detailed", func(t *testing.T) {
+		server := setupMockServer(t, http.StatusOK, models.CreateArtifactResponse{
+			Artifact: models.ArtifactDetail{GroupID: "test-group", ArtifactID: "artifact-1"},
+			Version:  &models.ArtifactVersionMetadata{Version: "1.0.0", GlobalID: 42},
+		}, "/groups/test-group/artifacts", http.MethodPost)
+		defer server.Close()
+
+		api := apis.NewArtifactsAPI(&client.Client{BaseURL: server.URL, HTTPClient: server.Client()})
+		artifact := models.CreateArtifactRequest{
+			ArtifactID:   "artifact-1",
+			ArtifactType: models.Json,
+			FirstVersion: models.CreateVersionRequest{
+				Content: models.CreateContentRequest{Content: "{}", ContentType: "application/json"},
+			},
+		}
+
+		result, err := api.CreateArtifactDetailed(context.Background(), "test-group", artifact, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "artifact-1", result.Artifact.ArtifactID)
+		if assert.NotNil(t, result.Version) {
+			assert.Equal(t, int64(42), result.Version.GlobalID)
+		}
+	})
+
+	t.Run("Content Type Derived From Artifact Type", func(t *testing.T) {
+		for _, tc := range []struct {
+			name         string
+			options      []client.Option
+			artifactType models.ArtifactType
+			expected     string
+		}{
+			{name: "Default Mapping", artifactType: models.Avro, expected: "application/json"},
+			{name: "Default Protobuf", artifactType: models.Protobuf, expected: "application/x-protobuf"},
+			{
+				name:         "Custom Mapping",
+				options:      []client.Option{client.WithContentTypeMap(map[models.ArtifactType]string{models.Avro: "application/vnd.apache.avro+json"})},
+				artifactType: models.Avro,
+				expected:     "application/vnd.apache.avro+json",
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var body models.CreateArtifactRequest
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, tc.expected, body.FirstVersion.Content.ContentType)
+
+					assert.NoError(t, json.NewEncoder(w).Encode(models.CreateArtifactResponse{}))
+				}))
+				defer server.Close()
+
+				options := append([]client.Option{client.WithHTTPClient(server.Client())}, tc.options...)
+				api := apis.NewArtifactsAPI(client.NewClient(server.URL, options...))
+
+				artifact := models.CreateArtifactRequest{
+					ArtifactID:   stubArtifactId,
+					ArtifactType: tc.artifactType,
+					FirstVersion: models.CreateVersionRequest{
+						Content: models.CreateContentRequest{Content: stubArtifactContent},
+					},
+				}
+				_, err := api.CreateArtifact(context.Background(), stubGroupId, artifact, nil)
+				assert.NoError(t, err)
+			})
+		}
+	})
+
+	t.Run("Properties Merged Into Labels", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Labels       map[string]string `json:"labels"`
+				FirstVersion struct {
+					Labels map[string]string `json:"labels"`
+				} `json:"firstVersion"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]string{"team": "payments", "commit": "abc123"}, body.Labels)
+			assert.Equal(t, map[string]string{"pipeline": "42"}, body.FirstVersion.Labels)
+
+			w.WriteHeader(http.StatusOK)
+			assert.NoError(t, json.NewEncoder(w).Encode(models.CreateArtifactResponse{}))
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		artifact := models.CreateArtifactRequest{
+			ArtifactID:   stubArtifactId,
+			ArtifactType: models.Json,
+			Labels:       map[string]string{"team": "payments"},
+			Properties:   map[string]string{"commit": "abc123", "team": "ignored"},
+			FirstVersion: models.CreateVersionRequest{
+				Content: models.CreateContentRequest{
+					Content:     "{\"key\":\"value\"}",
+					ContentType: "application/json",
+				},
+				Properties: map[string]string{"pipeline": "42"},
+			},
+		}
+
+		_, err := api.CreateArtifact(context.Background(), "test-group", artifact, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Default IfExists", func(t *testing.T) {
+		var ifExists []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ifExists = append(ifExists, r.URL.Query().Get("ifExists"))
+			assert.Equal(t, "true", r.URL.Query().Get("canonical"), "other params must be kept")
+			w.WriteHeader(http.StatusOK)
+			assert.NoError(t, json.NewEncoder(w).Encode(models.CreateArtifactResponse{}))
+		}))
+		defer server.Close()
+
+		mockClient := client.NewClient(
+			server.URL,
+			client.WithHTTPClient(server.Client()),
+			client.WithDefaultIfExists(models.IfExistsReturnOrUpdate),
+		)
+		api := apis.NewArtifactsAPI(mockClient)
+
+		artifact := models.CreateArtifactRequest{
+			ArtifactID:   stubArtifactId,
+			ArtifactType: models.Json,
+			FirstVersion: models.CreateVersionRequest{
+				Content: models.CreateContentRequest{Content: stubArtifactContent, ContentType: "application/json"},
+			},
+		}
+
+		params := &models.CreateArtifactParams{Canonical: true}
+		_, err := api.CreateArtifact(context.Background(), "test-group", artifact, params)
+		assert.NoError(t, err)
+		assert.Empty(t, params.IfExists, "caller params must not be modified")
+
+		params = &models.CreateArtifactParams{Canonical: true, IfExists: models.IfExistsFail}
+		_, err = api.CreateArtifact(context.Background(), "test-group", artifact, params)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []string{"FIND_OR_CREATE_VERSION", "FAIL"}, ifExists)
+	})
+
+	t.Run("Validate Locally", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			assert.NoError(t, json.NewEncoder(w).Encode(models.CreateArtifactResponse{}))
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewArtifactsAPI(mockClient)
+
+		create := func(artifactType models.ArtifactType, content string) error {
+			artifact := models.CreateArtifactRequest{
+				ArtifactID:   stubArtifactId,
+				ArtifactType: artifactType,
+				FirstVersion: models.CreateVersionRequest{
+					Content: models.CreateContentRequest{Content: content, ContentType: "application/json"},
+				},
+			}
+			params := &models.CreateArtifactParams{IfExists: models.IfExistsFail, ValidateLocally: true}
+			_, err := api.CreateArtifact(context.Background(), stubGroupId, artifact, params)
+			return err
+		}
+
+		err := create(models.Json, `{"type": "object", "properties": {"id": {"$ref": "#/$defs/id"}}}`)
+		var schemaErr *models.SchemaError
+		if assert.ErrorAs(t, err, &schemaErr) {
+			assert.Equal(t, "/properties/id/$ref", schemaErr.Path)
+		}
+		err = create(models.Avro, `{"type": "record", "name": "Test", "fields": [{"name": "field1", "type": "text"}]}`)
+		if assert.ErrorAs(t, err, &schemaErr) {
+			assert.Equal(t, "Avro schema", schemaErr.Format)
+			assert.Equal(t, "/fields/0/type", schemaErr.Path)
+		}
+		err = create(models.Protobuf, "syntax = \"proto3\";\nmessage Test {\n  string field1 = 1\n}\n")
+		if assert.ErrorAs(t, err, &schemaErr) {
+			assert.Equal(t, "Protobuf schema", schemaErr.Format)
+			assert.Equal(t, 4, schemaErr.Line)
+		}
+		assert.Equal(t, 0, requests, "an invalid schema is not sent")
+
+		assert.NoError(t, create(models.Json, `{"type": "object", "properties": {"id": {"type": "string"}}}`))
+		assert.NoError(t, create(models.Avro, stubArtifactContent))
+		assert.NoError(t, create(models.Protobuf, "syntax = \"proto3\";\nmessage Test {\n  string field1 = 1;\n}\n"))
+		assert.Equal(t, 3, requests)
+	})
+
+	t.Run("Canonicalize On Create", func(t *testing.T) {
+		var stored []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var request models.CreateArtifactRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			stored = append(stored, request.FirstVersion.Content.Content)
+			w.WriteHeader(http.StatusOK)
+			assert.NoError(t, json.NewEncoder(w).Encode(models.CreateArtifactResponse{}))
+		}))
+		defer server.Close()
+
+		mockClient := client.NewClient(
+			server.URL,
+			client.WithHTTPClient(server.Client()),
+			client.WithCanonicalizeOnCreate(),
+		)
+		api := apis.NewArtifactsAPI(mockClient)
+
+		create := func(artifactType models.ArtifactType, content string) {
+			artifact := models.CreateArtifactRequest{
+				ArtifactID:   stubArtifactId,
+				ArtifactType: artifactType,
+				FirstVersion: models.CreateVersionRequest{
+					Content: models.CreateContentRequest{Content: content, ContentType: "application/json"},
+				},
+			}
+			_, err := api.CreateArtifact(context.Background(), "test-group", artifact, nil)
+			assert.NoError(t, err)
+		}
+
+		create(models.Json, `{"type": "object", "properties": {"id": {"type": "string"}}}`)
+		create(models.Json, "{\n  \"properties\": {\"id\": {\"type\": \"string\"}},\n  \"type\": \"object\"\n}")
+		create(models.OpenAPI, `{"openapi": "3.0.0", "info": {}}`)
+
+		if assert.Len(t, stored, 3) {
+			assert.Equal(t, `{"properties":{"id":{"type":"string"}},"type":"object"}`, stored[0])
+			assert.Equal(t, stored[0], stored[1])
+			assert.Equal(t, `{"openapi": "3.0.0", "info": {}}`, stored[2], "unsupported types must be left untouched")
+		}
+
+		artifact := models.CreateArtifactRequest{
+			ArtifactID:   stubArtifactId,
+			ArtifactType: models.Avro,
+			FirstVersion: models.CreateVersionRequest{
+				Content: models.CreateContentRequest{Content: `{"type": "record",`, ContentType: "application/json"},
+			},
+		}
+		_, err := api.CreateArtifact(context.Background(), "test-group", artifact, nil)
+		assert.Error(t, err)
+		assert.Len(t, stored, 3, "invalid content must not be uploaded")
+	})
+
 	t.Run("Invalid Artifact", func(t *testing.T) {
 		mockResponse := models.CreateArtifactResponse{
 			Artifact: models.ArtifactDetail{
@@ -1504,6 +2374,54 @@ func TestArtifactsAPI_DeleteArtifactRule(t *testing.T) {
 	})
 }
 
+func TestAPIs_ShareTokenSource(t *testing.T) {
+	var tokenRequests atomic.Int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := tokenRequests.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		_, _ = fmt.Fprintf(w, `{"access_token": "token-%d", "expires_in": 3600}`, n)
+	}))
+	defer tokenServer.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /groups/{groupId}/artifacts/{artifactId}/versions", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token-1", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"count": 0, "versions": []}`))
+	})
+	mux.HandleFunc("GET /groups/{groupId}/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token-1", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"count": 0, "artifacts": []}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mockClient := client.NewClient(
+		server.URL,
+		client.WithHTTPClient(server.Client()),
+		client.WithClientCredentials(client.ClientCredentials{ClientID: "id", ClientSecret: "secret", TokenURL: tokenServer.URL}),
+	)
+	artifactsAPI := apis.NewArtifactsAPI(mockClient)
+	versionsAPI := apis.NewVersionsAPI(mockClient)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := artifactsAPI.ListArtifactsInGroup(context.Background(), stubGroupId, nil)
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := versionsAPI.ListArtifactVersions(context.Background(), stubGroupId, stubArtifactId, nil)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), tokenRequests.Load())
+}
+
 /***********************/
 /***** Integration *****/
 /***********************/
@@ -29,6 +29,37 @@ func (api *BranchAPI) ListBranches(
 	groupId, artifactId string,
 	params *models.ListBranchesParams,
 ) ([]models.BranchInfo, error) {
+	result, err := api.listBranches(ctx, groupId, artifactId, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Branches, nil
+}
+
+// ListBranchesPage Returns a page of branches like ListBranches, together with the total number of branches.
+// The count is the one reported by the registry, so it includes system branches even when ExcludeSystem is set.
+func (api *BranchAPI) ListBranchesPage(
+	ctx context.Context,
+	groupId, artifactId string,
+	params *models.ListBranchesParams,
+) (*models.Page[models.BranchInfo], error) {
+	result, err := api.listBranches(ctx, groupId, artifactId, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Page[models.BranchInfo]{Items: result.Branches, Count: result.Count}, nil
+}
+
+// listBranches returns a page of branches together with the total number of branches.
+func (api *BranchAPI) listBranches(
+	ctx context.Context,
+	groupId, artifactId string,
+	params *models.ListBranchesParams,
+) (*models.BranchesInfoResponse, error) {
+	ctx = client.WithOperationName(ctx, "ListBranches")
+
 	if err := validateInput(artifactId, regexGroupIDArtifactID, "Artifact ID"); err != nil {
 		return nil, err
 	}
@@ -61,8 +92,21 @@ func (api *BranchAPI) ListBranches(
 		return nil, err
 	}
 
-	return result.Branches, nil
+	if params != nil && params.ExcludeSystem {
+		result.Branches = userBranches(result.Branches)
+	}
+	return &result, nil
+}
 
+// userBranches returns the branches that are not system-defined.
+func userBranches(branches []models.BranchInfo) []models.BranchInfo {
+	filtered := make([]models.BranchInfo, 0, len(branches))
+	for _, b := range branches {
+		if !b.IsSystem() {
+			filtered = append(filtered, b)
+		}
+	}
+	return filtered
 }
 
 // CreateBranch Creates a new branch for the artifact.
@@ -73,6 +117,8 @@ func (api *BranchAPI) CreateBranch(
 	groupId, artifactId string,
 	branch *models.CreateBranchRequest,
 ) (*models.BranchInfo, error) {
+	ctx = client.WithOperationName(ctx, "CreateBranch")
+
 	if err := validateInput(artifactId, regexGroupIDArtifactID, "Artifact ID"); err != nil {
 		return nil, err
 	}
@@ -109,12 +155,15 @@ func (api *BranchAPI) GetBranchMetaData(
 	ctx context.Context,
 	groupId, artifactId, branchId string,
 ) (*models.BranchInfo, error) {
+	ctx = client.WithOperationName(ctx, "GetBranchMetaData")
+
 	if err := validateInput(artifactId, regexGroupIDArtifactID, "Artifact ID"); err != nil {
 		return nil, err
 	}
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return nil, err
 	}
+	branchId = api.branchOrDefault(branchId)
 	if err := validateInput(branchId, regexBranchID, "Branch ID"); err != nil {
 		return nil, err
 	}
@@ -139,12 +188,54 @@ func (api *BranchAPI) GetBranchMetaData(
 	return &result, nil
 }
 
+// GetDefaultBranch Get the metaData of the system-defined "latest" branch, which tracks the most recent version.
+func (api *BranchAPI) GetDefaultBranch(
+	ctx context.Context,
+	groupId, artifactId string,
+) (*models.BranchInfo, error) {
+	ctx = client.WithOperationName(ctx, "GetDefaultBranch")
+
+	return api.GetBranchMetaData(ctx, groupId, artifactId, models.BranchLatest)
+}
+
+// GetDraftsBranch Get the metaData of the system-defined "drafts" branch, which tracks versions in DRAFT state.
+func (api *BranchAPI) GetDraftsBranch(
+	ctx context.Context,
+	groupId, artifactId string,
+) (*models.BranchInfo, error) {
+	ctx = client.WithOperationName(ctx, "GetDraftsBranch")
+
+	return api.GetBranchMetaData(ctx, groupId, artifactId, models.BranchDrafts)
+}
+
+// GetBranchLatestContent Retrieves the content of the version at the tip of the branch.
+// An empty branchId uses the client's default branch (see client.WithDefaultBranch), or "latest" when none is set.
+func (api *BranchAPI) GetBranchLatestContent(
+	ctx context.Context,
+	groupId, artifactId, branchId string,
+	params *models.ArtifactReferenceParams,
+) (*models.ArtifactContent, error) {
+	ctx = client.WithOperationName(ctx, "GetBranchLatestContent")
+
+	branchId = api.branchOrDefault(branchId)
+	if branchId == "" {
+		branchId = models.BranchLatest
+	}
+	if err := validateInput(branchId, regexBranchID, "Branch ID"); err != nil {
+		return nil, err
+	}
+
+	return NewVersionsAPI(api.Client).GetArtifactVersionContent(ctx, groupId, artifactId, "branch="+branchId, params)
+}
+
 // UpdateBranchMetaData Update branch metaData
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Branches/operation/updateBranchMetaData
 func (api *BranchAPI) UpdateBranchMetaData(
 	ctx context.Context,
 	groupId, artifactId, branchId, description string,
 ) error {
+	ctx = client.WithOperationName(ctx, "UpdateBranchMetaData")
+
 	if err := validateInput(artifactId, regexGroupIDArtifactID, "Artifact ID"); err != nil {
 		return err
 	}
@@ -185,6 +276,8 @@ func (api *BranchAPI) DeleteBranch(
 	ctx context.Context,
 	groupId, artifactId, branchId string,
 ) error {
+	ctx = client.WithOperationName(ctx, "DeleteBranch")
+
 	if err := validateInput(artifactId, regexGroupIDArtifactID, "Artifact ID"); err != nil {
 		return err
 	}
@@ -222,12 +315,15 @@ func (api *BranchAPI) GetVersionsInBranch(
 	groupId, artifactId, branchId string,
 	params *models.ListBranchesParams,
 ) ([]models.ArtifactVersion, error) {
+	ctx = client.WithOperationName(ctx, "GetVersionsInBranch")
+
 	if err := validateInput(artifactId, regexGroupIDArtifactID, "Artifact ID"); err != nil {
 		return nil, err
 	}
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return nil, err
 	}
+	branchId = api.branchOrDefault(branchId)
 	if err := validateInput(branchId, regexBranchID, "Branch ID"); err != nil {
 		return nil, err
 	}
@@ -269,12 +365,15 @@ func (api *BranchAPI) ReplaceVersionsInBranch(
 	groupId, artifactId, branchId string,
 	versions []string,
 ) error {
+	ctx = client.WithOperationName(ctx, "ReplaceVersionsInBranch")
+
 	if err := validateInput(artifactId, regexGroupIDArtifactID, "Artifact ID"); err != nil {
 		return err
 	}
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
 	}
+	branchId = api.branchOrDefault(branchId)
 	if err := validateInput(branchId, regexBranchID, "Branch ID"); err != nil {
 		return err
 	}
@@ -321,12 +420,15 @@ func (api *BranchAPI) AddVersionToBranch(
 	ctx context.Context,
 	groupId, artifactId, branchId, version string,
 ) error {
+	ctx = client.WithOperationName(ctx, "AddVersionToBranch")
+
 	if err := validateInput(artifactId, regexGroupIDArtifactID, "Artifact ID"); err != nil {
 		return err
 	}
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
 	}
+	branchId = api.branchOrDefault(branchId)
 	if err := validateInput(branchId, regexBranchID, "Branch ID"); err != nil {
 		return err
 	}
@@ -357,6 +459,14 @@ func (api *BranchAPI) AddVersionToBranch(
 	return nil
 }
 
+// branchOrDefault returns branchId, or the client's default branch when branchId is empty.
+func (api *BranchAPI) branchOrDefault(branchId string) string {
+	if branchId == "" {
+		return api.Client.DefaultBranch
+	}
+	return branchId
+}
+
 // executeRequest handles the creation and execution of an HTTP request.
 func (api *BranchAPI) executeRequest(
 	ctx context.Context,
@@ -3,6 +3,7 @@ package apis_test
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/mollie/go-apicurio-registry/apis"
@@ -46,6 +47,39 @@ func TestBranchAPI_ListBranches(t *testing.T) {
 		assert.Equal(t, stubDescription, branches[0].Description)
 	})
 
+	t.Run("System Branches", func(t *testing.T) {
+		mockResponse := models.BranchesInfoResponse{
+			Branches: []models.BranchInfo{
+				{GroupId: stubGroupId, ArtifactId: stubArtifactId, BranchId: models.BranchDrafts, SystemDefined: true},
+				{GroupId: stubGroupId, ArtifactId: stubArtifactId, BranchId: models.BranchLatest, SystemDefined: true},
+				{GroupId: stubGroupId, ArtifactId: stubArtifactId, BranchId: stubBranchID},
+			},
+			Count: 3,
+		}
+		server := setupMockServer(t, http.StatusOK, mockResponse, expectedURL, http.MethodGet)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewBranchAPI(mockClient)
+
+		branches, err := api.ListBranches(context.Background(), stubGroupId, stubArtifactId, nil)
+		assert.NoError(t, err)
+		assert.Len(t, branches, 3)
+		assert.True(t, branches[0].IsSystem())
+		assert.True(t, branches[1].IsSystem())
+		assert.False(t, branches[2].IsSystem())
+
+		branches, err = api.ListBranches(
+			context.Background(),
+			stubGroupId,
+			stubArtifactId,
+			&models.ListBranchesParams{ExcludeSystem: true},
+		)
+		assert.NoError(t, err)
+		assert.Len(t, branches, 1)
+		assert.Equal(t, stubBranchID, branches[0].BranchId)
+	})
+
 	t.Run("Validation Errors", func(t *testing.T) {
 		mockClient := &client.Client{BaseURL: "http://mock.server", HTTPClient: http.DefaultClient}
 		api := apis.NewBranchAPI(mockClient)
@@ -107,6 +141,38 @@ func TestBranchAPI_ListBranches(t *testing.T) {
 	})
 }
 
+func TestBranchAPI_ListBranchesPage(t *testing.T) {
+	mockResponse := models.BranchesInfoResponse{
+		Branches: []models.BranchInfo{
+			{GroupId: stubGroupId, ArtifactId: stubArtifactId, BranchId: models.BranchLatest, SystemDefined: true},
+			{GroupId: stubGroupId, ArtifactId: stubArtifactId, BranchId: stubBranchID},
+		},
+		Count: 12,
+	}
+	expectedURL := "/groups/" + stubGroupId + "/artifacts/" + stubArtifactId + "/branches"
+	server := setupMockServer(t, http.StatusOK, mockResponse, expectedURL, http.MethodGet)
+	defer server.Close()
+
+	mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+	api := apis.NewBranchAPI(mockClient)
+
+	t.Run("Count", func(t *testing.T) {
+		page, err := api.ListBranchesPage(context.Background(), stubGroupId, stubArtifactId, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 12, page.Count)
+		assert.Equal(t, mockResponse.Branches, page.Items)
+	})
+
+	t.Run("Exclude System", func(t *testing.T) {
+		params := &models.ListBranchesParams{ExcludeSystem: true}
+		page, err := api.ListBranchesPage(context.Background(), stubGroupId, stubArtifactId, params)
+		assert.NoError(t, err)
+		assert.Equal(t, 12, page.Count)
+		assert.Len(t, page.Items, 1)
+		assert.Equal(t, stubBranchID, page.Items[0].BranchId)
+	})
+}
+
 func TestBranchAPI_CreateBranch(t *testing.T) {
 	expectedURL := "/groups/" + stubGroupId + "/artifacts/" + stubArtifactId + "/branches"
 
@@ -373,6 +439,100 @@ func TestBranchAPI_GetBranchMetaData(t *testing.T) {
 
 }
 
+func TestBranchAPI_GetSystemBranches(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		branchID string
+		get      func(api *apis.BranchAPI) (*models.BranchInfo, error)
+	}{
+		{
+			name:     "Default Branch",
+			branchID: models.BranchLatest,
+			get: func(api *apis.BranchAPI) (*models.BranchInfo, error) {
+				return api.GetDefaultBranch(context.Background(), stubGroupId, stubArtifactId)
+			},
+		},
+		{
+			name:     "Drafts Branch",
+			branchID: models.BranchDrafts,
+			get: func(api *apis.BranchAPI) (*models.BranchInfo, error) {
+				return api.GetDraftsBranch(context.Background(), stubGroupId, stubArtifactId)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			expectedURL := "/groups/" + stubGroupId + "/artifacts/" + stubArtifactId + "/branches/" + tc.branchID
+			mockResponse := models.BranchInfo{
+				GroupId:       stubGroupId,
+				ArtifactId:    stubArtifactId,
+				BranchId:      tc.branchID,
+				SystemDefined: true,
+			}
+			server := setupMockServer(t, http.StatusOK, mockResponse, expectedURL, http.MethodGet)
+			defer server.Close()
+
+			mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+			branch, err := tc.get(apis.NewBranchAPI(mockClient))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.branchID, branch.BranchId)
+			assert.True(t, branch.IsSystem())
+		})
+	}
+}
+
+func TestBranchAPI_GetBranchLatestContent(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		defaultBranch string
+		branchID      string
+		expected      string
+	}{
+		{name: "Default Branch", defaultBranch: "main", expected: "branch=main"},
+		{name: "Explicit Branch Wins", defaultBranch: "main", branchID: stubBranchID, expected: "branch=" + stubBranchID},
+		{name: "Latest Without Default", expected: "branch=latest"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /groups/{groupId}/artifacts/{artifactId}/versions/{version}/content", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tc.expected, r.PathValue("version"))
+				w.Header().Set("X-Registry-Version", "2")
+				_, _ = w.Write([]byte(stubArtifactContent))
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockClient := client.NewClient(
+				server.URL,
+				client.WithHTTPClient(server.Client()),
+				client.WithDefaultBranch(tc.defaultBranch),
+			)
+			api := apis.NewBranchAPI(mockClient)
+
+			content, err := api.GetBranchLatestContent(context.Background(), stubGroupId, stubArtifactId, tc.branchID, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, stubArtifactContent, content.Content)
+			assert.Equal(t, "2", content.Version)
+		})
+	}
+
+	t.Run("Default Branch For Branch Operations", func(t *testing.T) {
+		server := setupMockServer(
+			t,
+			http.StatusOK,
+			models.ArtifactVersionListResponse{},
+			"/groups/"+stubGroupId+"/artifacts/"+stubArtifactId+"/branches/main/versions",
+			http.MethodGet,
+		)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client(), DefaultBranch: "main"}
+		api := apis.NewBranchAPI(mockClient)
+
+		_, err := api.GetVersionsInBranch(context.Background(), stubGroupId, stubArtifactId, "", nil)
+		assert.NoError(t, err)
+	})
+}
+
 func TestBranchAPI_UpdateBranchMetaData(t *testing.T) {
 	expectedURL := "/groups/" + stubGroupId + "/artifacts/" + stubArtifactId + "/branches/" + stubBranchID
 
@@ -24,46 +24,58 @@
 //
 // 6. **SystemAPI**: Offers methods for querying registry status and configuration.
 //
+//  7. **SchemaResolver**: Looks up schema content by global ID or by version coordinates,
+//     keeping recently used schemas in an LRU cache.
+//
 // Example Usage:
 //
-// The following example demonstrates how to use the ArtifactsAPI to create and retrieve an artifact:
+// The following example demonstrates how to use the ArtifactsAPI to create an artifact and the
+// MetadataAPI to retrieve its metadata:
 //
 //	package main
 //
 //	import (
+//		"context"
 //		"fmt"
+//
 //		"github.com/mollie/go-apicurio-registry/apis"
 //		"github.com/mollie/go-apicurio-registry/client"
 //		"github.com/mollie/go-apicurio-registry/models"
 //	)
 //
 //	func main() {
+//		ctx := context.Background()
+//
 //		// Initialize the API client
-//		config := client.Config{
-//			BaseURL: "https://my-registry.example.com",
-//			AuthToken: "my-token",
-//		}
-//		apiClient := client.NewApicurioClient(config)
+//		apiClient := client.NewClient(
+//			"https://my-registry.example.com/apis/registry/v3",
+//			client.WithAuthToken("my-token"),
+//		)
 //
 //		// Access the ArtifactsAPI
 //		artifactsAPI := apis.NewArtifactsAPI(apiClient)
 //
 //		// Create a new artifact
-//		artifact := models.Artifact{
-//			GroupID: "example-group",
-//			ID: "example-artifact",
-//			Content: []byte(`{"type": "record", "name": "Example", "fields": [{"name": "field1", "type": "string"}]}`),
-//			ContentType: "application/json",
+//		artifact := models.CreateArtifactRequest{
+//			ArtifactID:   "example-artifact",
+//			ArtifactType: models.Avro,
+//			FirstVersion: models.CreateVersionRequest{
+//				Content: models.CreateContentRequest{
+//					Content:     `{"type": "record", "name": "Example", "fields": [{"name": "field1", "type": "string"}]}`,
+//					ContentType: "application/json",
+//				},
+//			},
 //		}
-//		response, err := artifactsAPI.Create(artifact)
+//		response, err := artifactsAPI.CreateArtifact(ctx, "example-group", artifact, nil)
 //		if err != nil {
 //			fmt.Printf("Error creating artifact: %v\n", err)
 //			return
 //		}
-//		fmt.Printf("Artifact created with ID: %s\n", response.ID)
+//		fmt.Printf("Artifact created with ID: %s\n", response.ArtifactID)
 //
 //		// Retrieve artifact metadata
-//		metadata, err := artifactsAPI.GetMetadata("example-group", "example-artifact")
+//		metadataAPI := apis.NewMetadataAPI(apiClient)
+//		metadata, err := metadataAPI.GetArtifactMetadata(ctx, "example-group", "example-artifact")
 //		if err != nil {
 //			fmt.Printf("Error retrieving metadata: %v\n", err)
 //			return
@@ -75,4 +87,6 @@
 //
 // Each API can be accessed via its respective constructor function (e.g., `NewArtifactsAPI`,
 // `NewAdminAPI`). These APIs are designed to integrate seamlessly with the client package.
+// APIs created from the same `*client.Client` share its state, including the token cache and
+// refresh, the content cache and the retry settings, so they can be created freely.
 package apis
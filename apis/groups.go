@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
 
 	"github.com/mollie/go-apicurio-registry/client"
 	"github.com/mollie/go-apicurio-registry/models"
@@ -27,6 +28,34 @@ func (api *GroupAPI) ListGroups(
 	ctx context.Context,
 	params *models.ListGroupsParams,
 ) ([]models.GroupInfo, error) {
+	result, err := api.listGroups(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Groups, nil
+}
+
+// ListGroupsPage Returns a page of groups like ListGroups, together with the total number of groups.
+func (api *GroupAPI) ListGroupsPage(
+	ctx context.Context,
+	params *models.ListGroupsParams,
+) (*models.Page[models.GroupInfo], error) {
+	result, err := api.listGroups(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Page[models.GroupInfo]{Items: result.Groups, Count: result.Count}, nil
+}
+
+// listGroups returns a page of groups together with the total number of groups.
+func (api *GroupAPI) listGroups(
+	ctx context.Context,
+	params *models.ListGroupsParams,
+) (*models.GroupInfoResponse, error) {
+	ctx = client.WithOperationName(ctx, "ListGroups")
+
 	query := ""
 	if params != nil {
 		if err := params.Validate(); err != nil {
@@ -46,16 +75,170 @@ func (api *GroupAPI) ListGroups(
 		return nil, err
 	}
 
-	return result.Groups, nil
+	return &result, nil
+}
+
+// ListGroupsWithCounts Returns a page of groups together with the number of artifacts in each group.
+// The artifact counts are fetched concurrently, bounded by the client's MaxConcurrency.
+func (api *GroupAPI) ListGroupsWithCounts(
+	ctx context.Context,
+	params *models.ListGroupsParams,
+) ([]models.GroupSummary, error) {
+	ctx = client.WithOperationName(ctx, "ListGroupsWithCounts")
+
+	groups, err := api.ListGroups(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	artifactsAPI := NewArtifactsAPI(api.Client)
+	summaries := make([]models.GroupSummary, len(groups))
+	err = forEach(ctx, maxConcurrency(api.Client), len(groups), func(ctx context.Context, i int) error {
+		group := groups[i]
+		result, err := artifactsAPI.ListArtifactsInGroup(
+			ctx,
+			group.GroupId,
+			&models.ListArtifactsInGroupParams{Limit: 1},
+		)
+		if err != nil {
+			return errors.Wrapf(err, "failed to count artifacts in group %s", group.GroupId)
+		}
+
+		summaries[i] = models.GroupSummary{
+			GroupId:       group.GroupId,
+			Description:   group.Description,
+			Labels:        group.Labels,
+			ArtifactCount: result.Count,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
+
+// ApplyRuleToAllArtifacts Applies a rule to every artifact in the group, at most Client.MaxConcurrency at a time.
+// The returned map holds the outcome per artifact ID; an artifact that already has the rule (409) counts as a success.
+// The error is only set when the artifacts cannot be listed or ctx is done before all artifacts are processed;
+// when the client's MaxElapsedTime budget runs out, the outcomes so far are returned with models.ErrBudgetExceeded.
+func (api *GroupAPI) ApplyRuleToAllArtifacts(
+	ctx context.Context,
+	groupID string,
+	rule models.Rule,
+	config string,
+) (map[string]error, error) {
+	ctx = client.WithOperationName(ctx, "ApplyRuleToAllArtifacts")
+
+	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
+		return nil, err
+	}
+
+	budgetCtx, cancel := withBudget(ctx, api.Client)
+	defer cancel()
+
+	artifactsAPI := NewArtifactsAPI(api.Client)
+	artifacts, err := artifactsAPI.AllArtifactsInGroup(budgetCtx, groupID)
+	if err != nil {
+		return nil, errors.Wrapf(budgetError(ctx, budgetCtx, err), "failed to list artifacts in group %s", groupID)
+	}
+
+	var mu sync.Mutex
+	results := make(map[string]error, len(artifacts))
+	err = forEach(budgetCtx, maxConcurrency(api.Client), len(artifacts), func(ctx context.Context, i int) error {
+		artifactID := artifacts[i].ArtifactId
+		err := artifactsAPI.CreateArtifactRule(ctx, groupID, artifactID, rule, models.RuleLevel(config))
+
+		var apiErr *models.APIError
+		if errors.As(err, &apiErr) && apiErr.Status == http.StatusConflict {
+			err = nil
+		}
+
+		mu.Lock()
+		results[artifactID] = err
+		mu.Unlock()
+		// Partial failures are reported per artifact and must not cancel the remaining calls.
+		return nil
+	})
+	if err != nil {
+		return results, budgetError(ctx, budgetCtx, err)
+	}
+
+	return results, nil
+}
+
+// ListAllVersionsInGroup Lists the versions of every artifact in the group, keyed by artifact ID.
+// Versions are listed at most Client.MaxConcurrency artifacts at a time. The first failure stops the listing;
+// when the client's MaxElapsedTime budget runs out, the versions listed so far are returned with models.ErrBudgetExceeded.
+// When the group holds more versions than the client's MaxItemsFetched, listing stops once that many have been
+// collected and they are returned with models.ErrTruncated.
+func (api *GroupAPI) ListAllVersionsInGroup(
+	ctx context.Context,
+	groupID string,
+) (map[string][]models.ArtifactVersion, error) {
+	ctx = client.WithOperationName(ctx, "ListAllVersionsInGroup")
+
+	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
+		return nil, err
+	}
+
+	budgetCtx, cancel := withBudget(ctx, api.Client)
+	defer cancel()
+
+	artifacts, err := NewArtifactsAPI(api.Client).AllArtifactsInGroup(budgetCtx, groupID)
+	if err != nil {
+		return nil, errors.Wrapf(budgetError(ctx, budgetCtx, err), "failed to list artifacts in group %s", groupID)
+	}
+
+	versionsAPI := NewVersionsAPI(api.Client)
+	maxItems := api.Client.MaxItemsFetched
+	var mu sync.Mutex
+	var fetched int
+	results := make(map[string][]models.ArtifactVersion, len(artifacts))
+	err = forEach(budgetCtx, maxConcurrency(api.Client), len(artifacts), func(ctx context.Context, i int) error {
+		artifactID := artifacts[i].ArtifactId
+		versions, err := versionsAPI.AllArtifactVersions(ctx, groupID, artifactID)
+		truncated := errors.Is(err, models.ErrTruncated)
+		if err != nil && !truncated {
+			return errors.Wrapf(err, "failed to list versions of %s", artifactID)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if maxItems > 0 && fetched+len(versions) > maxItems {
+			versions = versions[:maxItems-fetched]
+			truncated = true
+		}
+		if len(versions) > 0 || !truncated {
+			results[artifactID] = versions
+		}
+		fetched += len(versions)
+		if truncated {
+			return errors.Wrapf(models.ErrTruncated, "stopped after %d versions", maxItems)
+		}
+		return nil
+	})
+	if errors.Is(err, models.ErrTruncated) {
+		return results, err
+	}
+	if err != nil {
+		return results, budgetError(ctx, budgetCtx, err)
+	}
+
+	return results, nil
 }
 
 // CreateGroup Creates a new group.
+// The client's default group labels (see client.WithDefaultGroupLabels) are added; labels passed here win.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Groups/operation/createGroup
 func (api *GroupAPI) CreateGroup(
 	ctx context.Context,
 	groupId, description string,
 	labels map[string]string,
 ) (*models.GroupInfo, error) {
+	ctx = client.WithOperationName(ctx, "CreateGroup")
+
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return nil, err
 	}
@@ -64,7 +247,7 @@ func (api *GroupAPI) CreateGroup(
 	body := models.CreateGroupRequest{
 		GroupID:     groupId,
 		Description: description,
-		Labels:      labels,
+		Labels:      mergeLabels(api.Client.DefaultGroupLabels, labels),
 	}
 
 	resp, err := api.executeRequest(ctx, http.MethodPost, urlPath, body)
@@ -85,6 +268,8 @@ func (api *GroupAPI) CreateGroup(
 // GetGroupById Returns the group with the specified ID.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Groups/operation/getGroupById
 func (api *GroupAPI) GetGroupById(ctx context.Context, groupId string) (*models.GroupInfo, error) {
+	ctx = client.WithOperationName(ctx, "GetGroupById")
+
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return nil, err
 	}
@@ -113,6 +298,8 @@ func (api *GroupAPI) UpdateGroupMetadata(
 	description string,
 	labels map[string]string,
 ) error {
+	ctx = client.WithOperationName(ctx, "UpdateGroupMetadata")
+
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
 	}
@@ -134,6 +321,8 @@ func (api *GroupAPI) UpdateGroupMetadata(
 // DeleteGroup Deletes the group with the specified ID.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Groups/operation/deleteGroupById
 func (api *GroupAPI) DeleteGroup(ctx context.Context, groupId string) error {
+	ctx = client.WithOperationName(ctx, "DeleteGroup")
+
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
 	}
@@ -155,16 +344,17 @@ func (api *GroupAPI) SearchGroups(
 	ctx context.Context,
 	params *models.SearchGroupsParams,
 ) ([]models.GroupInfo, error) {
-	query := ""
+	ctx = client.WithOperationName(ctx, "SearchGroups")
+
+	query := url.Values{}
 	if params != nil {
 		if err := params.Validate(); err != nil {
 			return nil, errors.Wrap(err, "invalid parameters provided")
 		}
-		query = "?" + params.ToQuery().Encode()
+		query = params.ToQuery()
 	}
 
-	urlPath := fmt.Sprintf("%s/search/groups%s", api.Client.BaseURL, query)
-	resp, err := api.executeRequest(ctx, http.MethodGet, urlPath, nil)
+	resp, err := executeSearchRequest(ctx, api.Client, "/search/groups", query)
 	if err != nil {
 		return nil, err
 	}
@@ -183,6 +373,8 @@ func (api *GroupAPI) SearchGroups(
 // If no rules are configured for a group, the set of globally configured rules are used.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Group-rules/operation/listGroupRules
 func (api *GroupAPI) ListGroupRules(ctx context.Context, groupID string) ([]models.Rule, error) {
+	ctx = client.WithOperationName(ctx, "ListGroupRules")
+
 	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return nil, err
 	}
@@ -210,6 +402,8 @@ func (api *GroupAPI) CreateGroupRule(
 	rule models.Rule,
 	level models.RuleLevel,
 ) error {
+	ctx = client.WithOperationName(ctx, "CreateGroupRule")
+
 	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
 	}
@@ -233,6 +427,8 @@ func (api *GroupAPI) CreateGroupRule(
 // After this is done, the global rules apply to artifacts in the group again.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Group-rules/operation/deleteGroupRules
 func (api *GroupAPI) DeleteAllGroupRule(ctx context.Context, groupID string) error {
+	ctx = client.WithOperationName(ctx, "DeleteAllGroupRule")
+
 	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
 	}
@@ -255,6 +451,8 @@ func (api *GroupAPI) GetGroupRule(
 	groupID string,
 	rule models.Rule,
 ) (models.RuleLevel, error) {
+	ctx = client.WithOperationName(ctx, "GetGroupRule")
+
 	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return "", err
 	}
@@ -287,6 +485,8 @@ func (api *GroupAPI) UpdateGroupRule(
 	rule models.Rule,
 	level models.RuleLevel,
 ) error {
+	ctx = client.WithOperationName(ctx, "UpdateGroupRule")
+
 	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
 	}
@@ -319,6 +519,8 @@ func (api *GroupAPI) UpdateGroupRule(
 // DeleteGroupRule deletes the rule for a given group.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Group-rules/operation/deleteGroupRule
 func (api *GroupAPI) DeleteGroupRule(ctx context.Context, groupID string, rule models.Rule) error {
+	ctx = client.WithOperationName(ctx, "DeleteGroupRule")
+
 	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
 	}
@@ -337,6 +539,222 @@ func (api *GroupAPI) DeleteGroupRule(ctx context.Context, groupID string, rule m
 	return handleResponse(resp, http.StatusNoContent, nil)
 }
 
+// Reconcile Converges the artifacts of the group to the desired state, for declarative (GitOps) management.
+// Missing artifacts and versions are created and artifact rules are created, updated or removed to match.
+// Artifacts of the group that are not desired are only deleted with params.Prune, so a partial desired state
+// leaves the other artifacts alone. Existing versions are never changed or deleted.
+// With params.DryRun the registry is only read and the result lists the actions that would be taken.
+// Changes are made one at a time; on failure the actions taken so far are returned with the error.
+func (api *GroupAPI) Reconcile(
+	ctx context.Context,
+	groupID string,
+	desired []models.DesiredArtifact,
+	params *models.ReconcileParams,
+) (*models.ReconcileResult, error) {
+	ctx = client.WithOperationName(ctx, "Reconcile")
+
+	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]bool, len(desired))
+	for i := range desired {
+		if err := desired[i].Validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid desired artifact provided")
+		}
+		if wanted[desired[i].ArtifactID] {
+			return nil, errors.Errorf("invalid desired artifact provided: artifact %s is listed more than once", desired[i].ArtifactID)
+		}
+		wanted[desired[i].ArtifactID] = true
+	}
+
+	r := &reconciler{
+		groupID:   groupID,
+		artifacts: NewArtifactsAPI(api.Client),
+		versions:  NewVersionsAPI(api.Client),
+		result:    &models.ReconcileResult{DryRun: params != nil && params.DryRun},
+	}
+
+	current, err := r.artifacts.AllArtifactsInGroup(ctx, groupID)
+	var apiErr *models.APIError
+	if err != nil && !(errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound) {
+		return nil, errors.Wrapf(err, "failed to list artifacts in group %s", groupID)
+	}
+	existing := make(map[string]bool, len(current))
+	for _, artifact := range current {
+		existing[artifact.ArtifactId] = true
+	}
+
+	for i := range desired {
+		if existing[desired[i].ArtifactID] {
+			err = r.updateArtifact(ctx, &desired[i])
+		} else {
+			err = r.createArtifact(ctx, &desired[i])
+		}
+		if err != nil {
+			return r.result, err
+		}
+	}
+
+	if params == nil || !params.Prune {
+		return r.result, nil
+	}
+	for _, artifact := range current {
+		artifactID := artifact.ArtifactId
+		if wanted[artifactID] {
+			continue
+		}
+		action := models.ReconcileAction{Kind: models.ReconcileDeleteArtifact, ArtifactID: artifactID}
+		if err := r.apply(action, func() error {
+			return r.artifacts.DeleteArtifact(ctx, groupID, artifactID)
+		}); err != nil {
+			return r.result, err
+		}
+	}
+
+	return r.result, nil
+}
+
+// reconciler carries the state of a single GroupAPI.Reconcile call.
+type reconciler struct {
+	groupID   string
+	artifacts *ArtifactsAPI
+	versions  *VersionsAPI
+	result    *models.ReconcileResult
+}
+
+// apply makes the change unless this is a dry run and records the action once it succeeded.
+func (r *reconciler) apply(action models.ReconcileAction, change func() error) error {
+	if !r.result.DryRun {
+		if err := change(); err != nil {
+			return errors.Wrapf(err, "failed to reconcile %s", action)
+		}
+	}
+	r.result.Actions = append(r.result.Actions, action)
+	return nil
+}
+
+// createArtifact creates the artifact with its first version, then adds the other versions and the rules.
+func (r *reconciler) createArtifact(ctx context.Context, desired *models.DesiredArtifact) error {
+	first := desired.Versions[0]
+	action := models.ReconcileAction{
+		Kind:       models.ReconcileCreateArtifact,
+		ArtifactID: desired.ArtifactID,
+		Version:    first.Version,
+	}
+	err := r.apply(action, func() error {
+		_, err := r.artifacts.CreateArtifact(ctx, r.groupID, models.CreateArtifactRequest{
+			ArtifactID:   desired.ArtifactID,
+			ArtifactType: desired.ArtifactType,
+			FirstVersion: first,
+		}, &models.CreateArtifactParams{IfExists: models.IfExistsFail})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := r.createVersions(ctx, desired, desired.Versions[1:]); err != nil {
+		return err
+	}
+	return r.reconcileRules(ctx, desired, nil)
+}
+
+// updateArtifact adds the desired versions the artifact is missing and brings its rules in line.
+func (r *reconciler) updateArtifact(ctx context.Context, desired *models.DesiredArtifact) error {
+	versions, err := r.versions.AllArtifactVersions(ctx, r.groupID, desired.ArtifactID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list versions of %s", desired.ArtifactID)
+	}
+	present := make(map[string]bool, len(versions))
+	for _, version := range versions {
+		present[version.Version] = true
+	}
+	var missing []models.CreateVersionRequest
+	for _, version := range desired.Versions {
+		if !present[version.Version] {
+			missing = append(missing, version)
+		}
+	}
+	if err := r.createVersions(ctx, desired, missing); err != nil {
+		return err
+	}
+
+	rules, err := r.artifacts.ListArtifactRules(ctx, r.groupID, desired.ArtifactID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list rules of %s", desired.ArtifactID)
+	}
+	levels := make(map[models.Rule]models.RuleLevel, len(rules))
+	for _, rule := range rules {
+		level, err := r.artifacts.GetArtifactRule(ctx, r.groupID, desired.ArtifactID, rule)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get rule %s of %s", rule, desired.ArtifactID)
+		}
+		levels[rule] = level
+	}
+	return r.reconcileRules(ctx, desired, levels)
+}
+
+// createVersions adds the versions to the artifact in order.
+func (r *reconciler) createVersions(
+	ctx context.Context,
+	desired *models.DesiredArtifact,
+	versions []models.CreateVersionRequest,
+) error {
+	for _, version := range versions {
+		action := models.ReconcileAction{
+			Kind:       models.ReconcileCreateVersion,
+			ArtifactID: desired.ArtifactID,
+			Version:    version.Version,
+		}
+		if err := r.apply(action, func() error {
+			_, err := r.versions.CreateArtifactVersion(ctx, r.groupID, desired.ArtifactID, &version, false)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileRules creates, updates and removes artifact rules so the current levels match the desired ones.
+// Rules are processed in a fixed order so the actions are reported deterministically.
+func (r *reconciler) reconcileRules(
+	ctx context.Context,
+	desired *models.DesiredArtifact,
+	current map[models.Rule]models.RuleLevel,
+) error {
+	for _, rule := range []models.Rule{models.RuleValidity, models.RuleCompatibility, models.RuleIntegrity} {
+		want, wanted := desired.Rules[rule]
+		have, exists := current[rule]
+
+		action := models.ReconcileAction{ArtifactID: desired.ArtifactID, Rule: rule, Level: want}
+		var change func() error
+		switch {
+		case wanted && !exists:
+			action.Kind = models.ReconcileCreateRule
+			change = func() error {
+				return r.artifacts.CreateArtifactRule(ctx, r.groupID, desired.ArtifactID, rule, want)
+			}
+		case wanted && have != want:
+			action.Kind = models.ReconcileUpdateRule
+			change = func() error {
+				return r.artifacts.UpdateArtifactRule(ctx, r.groupID, desired.ArtifactID, rule, want)
+			}
+		case !wanted && exists:
+			action.Kind = models.ReconcileDeleteRule
+			change = func() error {
+				return r.artifacts.DeleteArtifactRule(ctx, r.groupID, desired.ArtifactID, rule)
+			}
+		default:
+			continue
+		}
+		if err := r.apply(action, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // executeRequest handles the creation and execution of an HTTP request.
 func (api *GroupAPI) executeRequest(
 	ctx context.Context,
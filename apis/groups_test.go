@@ -2,8 +2,12 @@ package apis_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/mollie/go-apicurio-registry/apis"
@@ -54,6 +58,256 @@ func TestGroupAPI_ListGroups(t *testing.T) {
 	})
 }
 
+func TestGroupAPI_ListGroupsPage(t *testing.T) {
+	mockResponse := models.GroupInfoResponse{
+		Groups: []models.GroupInfo{{GroupId: "group1"}, {GroupId: "group2"}},
+		Count:  345,
+	}
+	server := setupMockServer(t, http.StatusOK, mockResponse, "/groups", http.MethodGet)
+	defer server.Close()
+
+	mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+	groupAPI := apis.NewGroupAPI(mockClient)
+
+	page, err := groupAPI.ListGroupsPage(context.Background(), &models.ListGroupsParams{Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 345, page.Count)
+	assert.Equal(t, mockResponse.Groups, page.Items)
+}
+
+func TestGroupAPI_ListGroupsWithCounts(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		counts := map[string]int{"group1": 3, "group2": 7}
+		mux := http.NewServeMux()
+		mux.HandleFunc("/groups", func(w http.ResponseWriter, r *http.Request) {
+			err := json.NewEncoder(w).Encode(models.GroupInfoResponse{
+				Groups: []models.GroupInfo{
+					{GroupId: "group1", Description: "first", Labels: stubLabels},
+					{GroupId: "group2", Description: "second"},
+				},
+				Count: 2,
+			})
+			assert.NoError(t, err)
+		})
+		mux.HandleFunc("/groups/{groupId}/artifacts", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "1", r.URL.Query().Get("limit"))
+			err := json.NewEncoder(w).Encode(models.ListArtifactsResponse{
+				Count: counts[r.PathValue("groupId")],
+			})
+			assert.NoError(t, err)
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		mockClient := client.NewClient(
+			server.URL,
+			client.WithHTTPClient(server.Client()),
+			client.WithMaxConcurrency(1),
+		)
+		groupAPI := apis.NewGroupAPI(mockClient)
+
+		result, err := groupAPI.ListGroupsWithCounts(context.Background(), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []models.GroupSummary{
+			{GroupId: "group1", Description: "first", Labels: stubLabels, ArtifactCount: 3},
+			{GroupId: "group2", Description: "second", ArtifactCount: 7},
+		}, result)
+	})
+
+	t.Run("Count Error", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/groups", func(w http.ResponseWriter, r *http.Request) {
+			err := json.NewEncoder(w).Encode(models.GroupInfoResponse{
+				Groups: []models.GroupInfo{{GroupId: "group1"}},
+			})
+			assert.NoError(t, err)
+		})
+		mux.HandleFunc("/groups/{groupId}/artifacts", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			err := json.NewEncoder(w).Encode(models.APIError{
+				Status: http.StatusInternalServerError,
+				Title:  TitleInternalServerError,
+			})
+			assert.NoError(t, err)
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		groupAPI := apis.NewGroupAPI(mockClient)
+
+		result, err := groupAPI.ListGroupsWithCounts(context.Background(), nil)
+		assert.Nil(t, result)
+		assertAPIError(t, err, http.StatusInternalServerError, TitleInternalServerError)
+	})
+}
+
+func TestGroupAPI_ApplyRuleToAllArtifacts(t *testing.T) {
+	t.Run("Partial Failure", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /groups/{groupId}/artifacts", func(w http.ResponseWriter, r *http.Request) {
+			err := json.NewEncoder(w).Encode(models.ListArtifactsResponse{
+				Artifacts: []models.SearchedArtifact{
+					{GroupId: stubGroupId, ArtifactId: "artifact1", ArtifactType: models.Avro},
+					{GroupId: stubGroupId, ArtifactId: "artifact2", ArtifactType: models.Avro},
+					{GroupId: stubGroupId, ArtifactId: "artifact3", ArtifactType: models.Avro},
+				},
+				Count: 3,
+			})
+			assert.NoError(t, err)
+		})
+		mux.HandleFunc("POST /groups/{groupId}/artifacts/{artifactId}/rules", func(w http.ResponseWriter, r *http.Request) {
+			var request models.CreateUpdateRuleRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, models.RuleCompatibility, request.RuleType)
+			assert.Equal(t, models.CompatibilityLevelBackward, request.Config)
+
+			switch r.PathValue("artifactId") {
+			case "artifact2":
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(models.APIError{Status: http.StatusConflict, Title: TitleConflict})
+			case "artifact3":
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(models.APIError{
+					Status: http.StatusInternalServerError,
+					Title:  TitleInternalServerError,
+				})
+			default:
+				w.WriteHeader(http.StatusNoContent)
+			}
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		mockClient := client.NewClient(
+			server.URL,
+			client.WithHTTPClient(server.Client()),
+			client.WithMaxConcurrency(2),
+		)
+		groupAPI := apis.NewGroupAPI(mockClient)
+
+		results, err := groupAPI.ApplyRuleToAllArtifacts(
+			context.Background(),
+			stubGroupId,
+			models.RuleCompatibility,
+			string(models.CompatibilityLevelBackward),
+		)
+		assert.NoError(t, err)
+		assert.Len(t, results, 3)
+		assert.NoError(t, results["artifact1"])
+		assert.NoError(t, results["artifact2"])
+		assertAPIError(t, results["artifact3"], http.StatusInternalServerError, TitleInternalServerError)
+	})
+
+	t.Run("List Error", func(t *testing.T) {
+		server := setupMockServer(
+			t,
+			http.StatusNotFound,
+			models.APIError{Status: http.StatusNotFound, Title: TitleNotFound},
+			"/groups/"+stubGroupId+"/artifacts",
+			http.MethodGet,
+		)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		groupAPI := apis.NewGroupAPI(mockClient)
+
+		results, err := groupAPI.ApplyRuleToAllArtifacts(
+			context.Background(),
+			stubGroupId,
+			models.RuleCompatibility,
+			string(models.CompatibilityLevelBackward),
+		)
+		assert.Nil(t, results)
+		assertAPIError(t, err, http.StatusNotFound, TitleNotFound)
+	})
+}
+
+func TestGroupAPI_ListAllVersionsInGroup(t *testing.T) {
+	newServer := func(t *testing.T) *httptest.Server {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /groups/{groupId}/artifacts", func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(models.ListArtifactsResponse{
+				Artifacts: []models.SearchedArtifact{
+					{GroupId: stubGroupId, ArtifactId: "artifact1", ArtifactType: models.Avro},
+					{GroupId: stubGroupId, ArtifactId: "artifact2", ArtifactType: models.Avro},
+				},
+				Count: 2,
+			})
+		})
+		mux.HandleFunc("GET /groups/{groupId}/artifacts/{artifactId}/versions", func(w http.ResponseWriter, r *http.Request) {
+			artifactID := r.PathValue("artifactId")
+			versions := []models.ArtifactVersion{
+				{Version: "1", ArtifactID: artifactID, ArtifactType: models.Avro},
+				{Version: "2", ArtifactID: artifactID, ArtifactType: models.Avro},
+			}
+			_ = json.NewEncoder(w).Encode(models.ArtifactVersionListResponse{Count: len(versions), Versions: versions})
+		})
+		return httptest.NewServer(mux)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		server := newServer(t)
+		defer server.Close()
+
+		mockClient := client.NewClient(
+			server.URL,
+			client.WithHTTPClient(server.Client()),
+			client.WithMaxConcurrency(1),
+		)
+		groupAPI := apis.NewGroupAPI(mockClient)
+
+		results, err := groupAPI.ListAllVersionsInGroup(context.Background(), stubGroupId)
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		for _, artifactID := range []string{"artifact1", "artifact2"} {
+			assert.Len(t, results[artifactID], 2)
+			assert.Equal(t, "1", results[artifactID][0].Version)
+			assert.Equal(t, "2", results[artifactID][1].Version)
+			assert.Equal(t, artifactID, results[artifactID][1].ArtifactID)
+		}
+	})
+
+	t.Run("Max Items Fetched", func(t *testing.T) {
+		server := newServer(t)
+		defer server.Close()
+
+		mockClient := client.NewClient(
+			server.URL,
+			client.WithHTTPClient(server.Client()),
+			client.WithMaxConcurrency(1),
+			client.WithMaxItemsFetched(3),
+		)
+		groupAPI := apis.NewGroupAPI(mockClient)
+
+		results, err := groupAPI.ListAllVersionsInGroup(context.Background(), stubGroupId)
+		assert.ErrorIs(t, err, models.ErrTruncated)
+		var fetched int
+		for _, versions := range results {
+			fetched += len(versions)
+		}
+		assert.Equal(t, 3, fetched)
+	})
+
+	t.Run("List Error", func(t *testing.T) {
+		server := setupMockServer(
+			t,
+			http.StatusNotFound,
+			models.APIError{Status: http.StatusNotFound, Title: TitleNotFound},
+			"/groups/"+stubGroupId+"/artifacts",
+			http.MethodGet,
+		)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		groupAPI := apis.NewGroupAPI(mockClient)
+
+		results, err := groupAPI.ListAllVersionsInGroup(context.Background(), stubGroupId)
+		assert.Nil(t, results)
+		assertAPIError(t, err, http.StatusNotFound, TitleNotFound)
+	})
+}
+
 func TestGroupAPI_CreateGroup(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockGroup := models.GroupInfo{GroupId: "group1"}
@@ -70,6 +324,32 @@ func TestGroupAPI_CreateGroup(t *testing.T) {
 		assert.Equal(t, "group1", result.GroupId)
 	})
 
+	t.Run("Default Labels", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var request models.CreateGroupRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, map[string]string{
+				"created-by": "governance-bot",
+				"team":       "payments",
+			}, request.Labels)
+			assert.NoError(t, json.NewEncoder(w).Encode(models.GroupInfo{GroupId: request.GroupID, Labels: request.Labels}))
+		}))
+		defer server.Close()
+
+		defaults := map[string]string{"created-by": "governance-bot", "team": "platform"}
+		mockClient := client.NewClient(
+			server.URL,
+			client.WithHTTPClient(server.Client()),
+			client.WithDefaultGroupLabels(defaults),
+		)
+		groupAPI := apis.NewGroupAPI(mockClient)
+
+		result, err := groupAPI.CreateGroup(context.Background(), "group1", "description", map[string]string{"team": "payments"})
+		assert.NoError(t, err)
+		assert.Equal(t, "payments", result.Labels["team"])
+		assert.Equal(t, "platform", defaults["team"], "the default labels must not be modified")
+	})
+
 	t.Run("Validation: Empty Group ID", func(t *testing.T) {
 		mockClient := &client.Client{BaseURL: "http://example.com", HTTPClient: http.DefaultClient}
 		groupAPI := apis.NewGroupAPI(mockClient)
@@ -136,6 +416,40 @@ func TestGroupAPI_GetGroupById(t *testing.T) {
 		assert.Equal(t, "group1", result.GroupId)
 	})
 
+	t.Run("Body Drained For Connection Reuse", func(t *testing.T) {
+		// Decoding stops at the end of the JSON document, leaving the trailing padding unread. The padding is
+		// larger than what the transport drains by itself on close.
+		padding := strings.Repeat(" ", 512<<10)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"groupId": "group1"}` + padding))
+		}))
+		defer server.Close()
+
+		var observed []client.RequestMetrics
+		mockClient := client.NewClient(
+			server.URL,
+			client.WithHTTPClient(server.Client()),
+			client.WithMetricsObserver(func(m client.RequestMetrics) { observed = append(observed, m) }),
+			client.WithConnectionReuseMetrics(),
+		)
+		groupAPI := apis.NewGroupAPI(mockClient)
+
+		for range 3 {
+			result, err := groupAPI.GetGroupById(context.Background(), "group1")
+			assert.NoError(t, err)
+			assert.Equal(t, "group1", result.GroupId)
+		}
+
+		if assert.Len(t, observed, 3) {
+			assert.Equal(t, 1, observed[0].NewConnections)
+			for _, m := range observed[1:] {
+				assert.Equal(t, 0, m.NewConnections)
+				assert.Equal(t, 1, m.ReusedConnections)
+			}
+		}
+	})
+
 	t.Run("Validation: Empty Group ID", func(t *testing.T) {
 		mockClient := &client.Client{BaseURL: "http://example.com", HTTPClient: http.DefaultClient}
 		groupAPI := apis.NewGroupAPI(mockClient)
@@ -811,6 +1125,279 @@ func TestGroupsAPI_DeleteGroupRule(t *testing.T) {
 	})
 }
 
+// fakeRegistry is an in-memory registry serving the endpoints GroupAPI.Reconcile uses for a single group.
+type fakeRegistry struct {
+	mu        sync.Mutex
+	artifacts map[string]*fakeArtifact
+	order     []string
+	writes    []string
+	fail      string // Write answered with 500 Internal Server Error, e.g. "POST /groups/g/artifacts/a/versions"
+}
+
+type fakeArtifact struct {
+	versions []string
+	rules    map[models.Rule]models.RuleLevel
+}
+
+func newFakeRegistry(t *testing.T, r *fakeRegistry) *httptest.Server {
+	if r.artifacts == nil {
+		r.artifacts = map[string]*fakeArtifact{}
+	}
+	writeJSON := func(w http.ResponseWriter, v interface{}) {
+		assert.NoError(t, json.NewEncoder(w).Encode(v))
+	}
+	artifact := func(w http.ResponseWriter, req *http.Request) *fakeArtifact {
+		a := r.artifacts[req.PathValue("artifact")]
+		if a == nil {
+			w.WriteHeader(http.StatusNotFound)
+			writeJSON(w, models.APIError{Status: http.StatusNotFound, Title: TitleNotFound})
+		}
+		return a
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /groups/{group}/artifacts", func(w http.ResponseWriter, req *http.Request) {
+		list := models.ListArtifactsResponse{Count: len(r.order)}
+		for _, id := range r.order {
+			list.Artifacts = append(list.Artifacts, models.SearchedArtifact{ArtifactId: id, ArtifactType: models.Avro})
+		}
+		writeJSON(w, list)
+	})
+	mux.HandleFunc("POST /groups/{group}/artifacts", func(w http.ResponseWriter, req *http.Request) {
+		var body struct {
+			ArtifactID   string `json:"artifactId"`
+			FirstVersion struct {
+				Version string `json:"version"`
+			} `json:"firstVersion"`
+		}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		r.artifacts[body.ArtifactID] = &fakeArtifact{versions: []string{body.FirstVersion.Version}, rules: map[models.Rule]models.RuleLevel{}}
+		r.order = append(r.order, body.ArtifactID)
+		writeJSON(w, models.CreateArtifactResponse{Artifact: models.ArtifactDetail{ArtifactID: body.ArtifactID}})
+	})
+	mux.HandleFunc("DELETE /groups/{group}/artifacts/{artifact}", func(w http.ResponseWriter, req *http.Request) {
+		if artifact(w, req) == nil {
+			return
+		}
+		delete(r.artifacts, req.PathValue("artifact"))
+		for i, id := range r.order {
+			if id == req.PathValue("artifact") {
+				r.order = append(r.order[:i], r.order[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /groups/{group}/artifacts/{artifact}/versions", func(w http.ResponseWriter, req *http.Request) {
+		a := artifact(w, req)
+		if a == nil {
+			return
+		}
+		list := models.ArtifactVersionListResponse{Count: len(a.versions)}
+		for _, v := range a.versions {
+			list.Versions = append(list.Versions, models.ArtifactVersion{Version: v, ArtifactType: models.Avro})
+		}
+		writeJSON(w, list)
+	})
+	mux.HandleFunc("POST /groups/{group}/artifacts/{artifact}/versions", func(w http.ResponseWriter, req *http.Request) {
+		a := artifact(w, req)
+		if a == nil {
+			return
+		}
+		var body models.CreateVersionRequest
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		a.versions = append(a.versions, body.Version)
+		writeJSON(w, models.ArtifactVersionDetailed{ArtifactVersion: models.ArtifactVersion{Version: body.Version, ArtifactType: models.Avro}})
+	})
+	mux.HandleFunc("GET /groups/{group}/artifacts/{artifact}/rules", func(w http.ResponseWriter, req *http.Request) {
+		a := artifact(w, req)
+		if a == nil {
+			return
+		}
+		rules := []models.Rule{}
+		for rule := range a.rules {
+			rules = append(rules, rule)
+		}
+		writeJSON(w, rules)
+	})
+	mux.HandleFunc("POST /groups/{group}/artifacts/{artifact}/rules", func(w http.ResponseWriter, req *http.Request) {
+		a := artifact(w, req)
+		if a == nil {
+			return
+		}
+		var body models.CreateUpdateRuleRequest
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		a.rules[body.RuleType] = body.Config
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/groups/{group}/artifacts/{artifact}/rules/{rule}", func(w http.ResponseWriter, req *http.Request) {
+		a := artifact(w, req)
+		if a == nil {
+			return
+		}
+		rule := models.Rule(req.PathValue("rule"))
+		switch req.Method {
+		case http.MethodGet:
+			writeJSON(w, models.RuleResponse{RuleType: rule, Config: a.rules[rule]})
+		case http.MethodPut:
+			var body models.CreateUpdateRuleRequest
+			assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			a.rules[rule] = body.Config
+			writeJSON(w, models.RuleResponse{RuleType: rule, Config: body.Config})
+		case http.MethodDelete:
+			delete(a.rules, rule)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if req.Method != http.MethodGet {
+			r.writes = append(r.writes, req.Method+" "+req.URL.Path)
+		}
+		if req.Method+" "+req.URL.Path == r.fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			writeJSON(w, models.APIError{Status: http.StatusInternalServerError, Title: TitleInternalServerError})
+			return
+		}
+		mux.ServeHTTP(w, req)
+	}))
+}
+
+func TestGroupAPI_Reconcile(t *testing.T) {
+	version := func(v string) models.CreateVersionRequest {
+		return models.CreateVersionRequest{
+			Version: v,
+			Content: models.CreateContentRequest{Content: stubArtifactContent, ContentType: "application/json"},
+		}
+	}
+	desired := []models.DesiredArtifact{
+		{
+			ArtifactID:   "orders",
+			ArtifactType: models.Avro,
+			Versions:     []models.CreateVersionRequest{version("1"), version("2")},
+			Rules:        map[models.Rule]models.RuleLevel{models.RuleCompatibility: models.CompatibilityLevelBackward},
+		},
+		{
+			ArtifactID:   "payments",
+			ArtifactType: models.Avro,
+			Versions:     []models.CreateVersionRequest{version("1")},
+		},
+	}
+
+	t.Run("Empty To Desired State", func(t *testing.T) {
+		registry := &fakeRegistry{}
+		server := newFakeRegistry(t, registry)
+		defer server.Close()
+
+		api := apis.NewGroupAPI(&client.Client{BaseURL: server.URL, HTTPClient: server.Client()})
+		expected := []models.ReconcileAction{
+			{Kind: models.ReconcileCreateArtifact, ArtifactID: "orders", Version: "1"},
+			{Kind: models.ReconcileCreateVersion, ArtifactID: "orders", Version: "2"},
+			{Kind: models.ReconcileCreateRule, ArtifactID: "orders", Rule: models.RuleCompatibility, Level: models.CompatibilityLevelBackward},
+			{Kind: models.ReconcileCreateArtifact, ArtifactID: "payments", Version: "1"},
+		}
+
+		plan, err := api.Reconcile(context.Background(), stubGroupId, desired, &models.ReconcileParams{DryRun: true})
+		assert.NoError(t, err)
+		assert.True(t, plan.DryRun)
+		assert.Equal(t, expected, plan.Actions)
+		assert.Empty(t, registry.writes, "a dry run does not change the registry")
+
+		result, err := api.Reconcile(context.Background(), stubGroupId, desired, nil)
+		assert.NoError(t, err)
+		assert.False(t, result.DryRun)
+		assert.Equal(t, expected, result.Actions)
+		assert.Equal(t, []string{"orders", "payments"}, registry.order)
+		assert.Equal(t, []string{"1", "2"}, registry.artifacts["orders"].versions)
+		assert.Equal(t, map[models.Rule]models.RuleLevel{models.RuleCompatibility: models.CompatibilityLevelBackward}, registry.artifacts["orders"].rules)
+		assert.Equal(t, []string{"1"}, registry.artifacts["payments"].versions)
+
+		again, err := api.Reconcile(context.Background(), stubGroupId, desired, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, again.Actions, "a converged group needs no changes")
+	})
+
+	t.Run("Updates And Deletes", func(t *testing.T) {
+		registry := &fakeRegistry{
+			artifacts: map[string]*fakeArtifact{
+				"orders": {versions: []string{"1"}, rules: map[models.Rule]models.RuleLevel{
+					models.RuleCompatibility: models.CompatibilityLevelFull,
+					models.RuleValidity:      models.RuleLevel("FULL"),
+				}},
+				"stale": {versions: []string{"1"}, rules: map[models.Rule]models.RuleLevel{}},
+			},
+			order: []string{"orders", "stale"},
+		}
+		server := newFakeRegistry(t, registry)
+		defer server.Close()
+
+		api := apis.NewGroupAPI(&client.Client{BaseURL: server.URL, HTTPClient: server.Client()})
+
+		result, err := api.Reconcile(context.Background(), stubGroupId, desired[:1], &models.ReconcileParams{Prune: true})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ReconcileAction{
+			{Kind: models.ReconcileCreateVersion, ArtifactID: "orders", Version: "2"},
+			{Kind: models.ReconcileDeleteRule, ArtifactID: "orders", Rule: models.RuleValidity},
+			{Kind: models.ReconcileUpdateRule, ArtifactID: "orders", Rule: models.RuleCompatibility, Level: models.CompatibilityLevelBackward},
+			{Kind: models.ReconcileDeleteArtifact, ArtifactID: "stale"},
+		}, result.Actions)
+		assert.Equal(t, []string{"orders"}, registry.order)
+		assert.Equal(t, "UPDATE_RULE orders COMPATIBILITY=BACKWARD", result.Actions[2].String())
+	})
+
+	t.Run("Undesired Artifacts Kept Without Prune", func(t *testing.T) {
+		registry := &fakeRegistry{
+			artifacts: map[string]*fakeArtifact{
+				"orders": {versions: []string{"1", "2"}, rules: map[models.Rule]models.RuleLevel{
+					models.RuleCompatibility: models.CompatibilityLevelBackward,
+				}},
+				"stale": {versions: []string{"1"}, rules: map[models.Rule]models.RuleLevel{}},
+			},
+			order: []string{"orders", "stale"},
+		}
+		server := newFakeRegistry(t, registry)
+		defer server.Close()
+
+		api := apis.NewGroupAPI(&client.Client{BaseURL: server.URL, HTTPClient: server.Client()})
+
+		for _, partial := range [][]models.DesiredArtifact{desired[:1], nil} {
+			result, err := api.Reconcile(context.Background(), stubGroupId, partial, nil)
+			assert.NoError(t, err)
+			assert.Empty(t, result.Actions)
+		}
+		assert.Equal(t, []string{"orders", "stale"}, registry.order)
+		assert.Empty(t, registry.writes)
+	})
+
+	t.Run("Failure Returns Actions Taken", func(t *testing.T) {
+		registry := &fakeRegistry{fail: "POST /groups/test-group/artifacts/orders/versions"}
+		server := newFakeRegistry(t, registry)
+		defer server.Close()
+
+		api := apis.NewGroupAPI(&client.Client{BaseURL: server.URL, HTTPClient: server.Client()})
+
+		result, err := api.Reconcile(context.Background(), stubGroupId, desired, nil)
+		assertAPIError(t, err, http.StatusInternalServerError, TitleInternalServerError)
+		assert.ErrorContains(t, err, "failed to reconcile CREATE_VERSION orders@2")
+		assert.Equal(t, []models.ReconcileAction{{Kind: models.ReconcileCreateArtifact, ArtifactID: "orders", Version: "1"}}, result.Actions)
+	})
+
+	t.Run("Invalid Desired State", func(t *testing.T) {
+		api := apis.NewGroupAPI(&client.Client{})
+
+		duplicate := []models.DesiredArtifact{desired[1], desired[1]}
+		result, err := api.Reconcile(context.Background(), stubGroupId, duplicate, nil)
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "artifact payments is listed more than once")
+
+		noVersion := []models.DesiredArtifact{{ArtifactID: "orders", Versions: []models.CreateVersionRequest{version("")}}}
+		_, err = api.Reconcile(context.Background(), stubGroupId, noVersion, nil)
+		assert.ErrorContains(t, err, "every desired version needs a Version")
+	})
+}
+
 /***********************/
 /***** Integration *****/
 /***********************/
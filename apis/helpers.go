@@ -7,30 +7,102 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strings"
+	"sync"
 
 	"github.com/mollie/go-apicurio-registry/client"
+	"github.com/mollie/go-apicurio-registry/internal/plumbing"
 	"github.com/mollie/go-apicurio-registry/models"
 	"github.com/pkg/errors"
 )
 
 const (
-	ContentTypeJSON = "application/json"
-	ContentTypeAll  = "*/*"
+	ContentTypeJSON        = "application/json"
+	ContentTypeAll         = "*/*"
+	ContentTypeZip         = "application/zip"
+	ContentTypeEventStream = "text/event-stream"
 )
 
+// pageSize is the page size used by helpers that page through complete result sets.
+const pageSize = 100
+
+// Iterator iterates over a paginated list page by page. Call Next to advance, Value to read the current
+// item and, once Next returns false, Err to tell the end of the list from a failure.
+type Iterator[T any] struct {
+	fetch  func(ctx context.Context, offset int) (items []T, count int, err error)
+	offset int
+	page   []T
+	index  int
+	done   bool
+	err    error
+}
+
+// newIterator creates an Iterator starting at offset. fetch returns the page at an offset together with
+// the total number of items reported by the registry.
+func newIterator[T any](offset int, fetch func(ctx context.Context, offset int) ([]T, int, error)) *Iterator[T] {
+	return &Iterator[T]{fetch: fetch, offset: offset}
+}
+
+// Next advances to the next item, fetching the next page when the current one is consumed.
+// It returns false when all items have been returned, a request failed or ctx is done.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if it.index+1 < len(it.page) {
+		it.index++
+		return true
+	}
+	if it.done {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	items, count, err := it.fetch(ctx, it.offset)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	// An empty page ends the listing even when the count is larger, e.g. because items were deleted meanwhile.
+	it.page, it.index = items, 0
+	it.offset += len(items)
+	it.done = len(items) == 0 || it.offset >= count
+	return len(it.page) > 0
+}
+
+// Value returns the current item. It is only valid after Next returned true.
+func (it *Iterator[T]) Value() T {
+	return it.page[it.index]
+}
+
+// Err returns the error that stopped the iteration, or nil when all items were returned.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
 var (
 	regexGroupIDArtifactID = regexp.MustCompile(`^.{1,512}$`)
 	regexVersion           = regexp.MustCompile(`[a-zA-Z0-9._\-+]{1,256}`)
 	regexBranchID          = regexp.MustCompile(`[a-zA-Z0-9._\-+]{1,256}`)
 
-	ErrInvalidInput = errors.New("input did not pass validation with regex")
+	// ErrInvalidInput is returned when an input validation fails.
+	ErrInvalidInput = models.ErrInvalidInput
 )
 
-// ErrInvalidInput is returned when an input validation fails.
+// validateInput checks the input against the regex and returns a *models.FieldValidationError on mismatch.
 func validateInput(input string, regex *regexp.Regexp, name string) error {
 	if match := regex.MatchString(input); !match {
-		return errors.Wrapf(ErrInvalidInput, "%s='%s', regex=%s", name, input, regex.String())
+		return &models.FieldValidationError{
+			Field:  name,
+			Value:  input,
+			Reason: fmt.Sprintf("regex=%s", regex.String()),
+		}
 	}
 	return nil
 }
@@ -44,8 +116,16 @@ func parseAPIError(resp *http.Response) (*models.APIError, error) {
 
 	var apiError models.APIError
 	if err := json.Unmarshal(body, &apiError); err != nil {
-		return nil, fmt.Errorf("failed to parse error response: %w", err)
+		if resp.StatusCode != http.StatusTooManyRequests {
+			return nil, fmt.Errorf("failed to parse error response: %w", err)
+		}
+		// Rate limits are often enforced by a gateway that answers without problem details.
+		apiError = models.APIError{Status: resp.StatusCode, Title: http.StatusText(resp.StatusCode)}
 	}
+	// The status of the problem details may be missing or disagree with the response; errors are
+	// matched on the status code of the response.
+	apiError.Status = resp.StatusCode
+	apiError.RetryAfter = models.ParseRetryAfter(resp.Header.Get("Retry-After"))
 
 	return &apiError, nil
 }
@@ -63,20 +143,80 @@ func parseArtifactTypeHeader(resp *http.Response) (models.ArtifactType, error) {
 	return artifactType, nil
 }
 
-// handleResponse reads the response body and checks the status code.
+// reportedArtifactType returns the artifact type reported by the X-Registry-ArtifactType header, which is
+// empty when absent. Types this package does not know, such as custom artifact types, are passed through.
+func reportedArtifactType(resp *http.Response) models.ArtifactType {
+	return models.ArtifactType(resp.Header.Get("X-Registry-ArtifactType"))
+}
+
+// validateRetrievedContent checks content against the artifact type reported by the response, when the
+// client validates retrieved content (see client.WithValidateRetrievedContent).
+func validateRetrievedContent(c *client.Client, resp *http.Response, content string) error {
+	artifactType, _ := models.ParseArtifactType(resp.Header.Get("X-Registry-ArtifactType"))
+	return plumbing.Of(c).ValidateRetrievedContent(artifactType, content)
+}
+
+// contentProvenance returns the group and artifact IDs reported by the X-Registry-GroupId and
+// X-Registry-ArtifactId headers of a content response, which are empty when absent.
+func contentProvenance(resp *http.Response) (groupID, artifactID string) {
+	return resp.Header.Get("X-Registry-GroupId"), resp.Header.Get("X-Registry-ArtifactId")
+}
+
+// mergeLabels returns the defaults overlaid with the explicit labels, which win on conflicting keys.
+// Neither map is modified; nil is returned when both are empty.
+func mergeLabels(defaults, labels map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return labels
+	}
+	merged := make(map[string]string, len(defaults)+len(labels))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range labels {
+		merged[key] = value
+	}
+	return merged
+}
+
+// checkArtifactType verifies the X-Registry-ArtifactType header matches the expected type.
+// An empty expected type disables the check.
+func checkArtifactType(resp *http.Response, expected models.ArtifactType) error {
+	if expected == "" {
+		return nil
+	}
+	actual := resp.Header.Get("X-Registry-ArtifactType")
+	if actual != string(expected) {
+		return errors.Wrapf(
+			models.ErrArtifactTypeMismatch,
+			"expected %s, got '%s'",
+			expected,
+			actual,
+		)
+	}
+	return nil
+}
+
+// handleResponse reads the response body and checks the status code, after the client's status hook, if any.
 func handleResponse(resp *http.Response, expectedStatus int, result interface{}) error {
-	defer resp.Body.Close()
+	defer plumbing.DrainBody(resp)
+
+	if handled, err := plumbing.ApplyStatusHook(resp); handled {
+		return err
+	}
 
 	if resp.StatusCode != expectedStatus {
 		apiError, parseErr := parseAPIError(resp)
 		if parseErr != nil {
+			if resp.StatusCode == http.StatusPreconditionFailed {
+				return errors.Wrapf(models.ErrPreconditionFailed, "unexpected server error: %d", resp.StatusCode)
+			}
 			return errors.Wrapf(parseErr, "unexpected server error: %d", resp.StatusCode)
 		}
 		return apiError
 	}
 
 	if result != nil && resp.StatusCode == expectedStatus {
-		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		if err := decodeResult(resp, result); err != nil {
 			return errors.Wrap(err, "failed to parse response body")
 		}
 	}
@@ -84,9 +224,29 @@ func handleResponse(resp *http.Response, expectedStatus int, result interface{})
 	return nil
 }
 
+// decodeResult decodes the response body into result, using the decoder registered for its type
+// with client.WithModelDecoder when there is one.
+func decodeResult(resp *http.Response, result interface{}) error {
+	if resp.Request != nil {
+		if decode, ok := plumbing.ModelDecoderFor(resp.Request.Context(), result); ok {
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return err
+			}
+			return decode(body, result)
+		}
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
 // handleRawResponse reads the response body and checks the status code.
 func handleRawResponse(resp *http.Response, expectedStatus int) (string, error) {
-	defer resp.Body.Close()
+	defer plumbing.DrainBody(resp)
+
+	if handled, err := plumbing.ApplyStatusHook(resp); handled {
+		return "", err
+	}
+
 	if resp.StatusCode != expectedStatus {
 		apiError, parseErr := parseAPIError(resp)
 		if parseErr != nil {
@@ -104,47 +264,47 @@ func handleRawResponse(resp *http.Response, expectedStatus int) (string, error)
 }
 
 // executeRequest handles the creation and execution of an HTTP request.
+// String and byte slice bodies are sent as-is; any other body is marshaled to JSON.
 func executeRequest(
 	ctx context.Context,
 	client *client.Client,
 	method, url string,
 	body interface{},
 ) (*http.Response, error) {
-	var reqBody io.Reader
-	contentType := ""
-
-	if body != nil {
-		switch v := body.(type) {
-		case string:
-			reqBody = bytes.NewReader([]byte(v))
-			contentType = "*/*"
-		case []byte:
-			reqBody = bytes.NewReader(v)
-			contentType = "*/*"
-		default:
-			contentType = "application/json"
-			jsonData, err := json.Marshal(body)
-			if err != nil {
-				return nil, errors.Wrap(err, "failed to marshal request body as JSON")
-			}
-			reqBody = bytes.NewReader(jsonData)
+	switch v := body.(type) {
+	case nil:
+		return executeRequestRaw(ctx, client, method, url, nil, "")
+	case string:
+		return executeRequestRaw(ctx, client, method, url, strings.NewReader(v), ContentTypeAll)
+	case []byte:
+		return executeRequestRaw(ctx, client, method, url, bytes.NewReader(v), ContentTypeAll)
+	default:
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal request body as JSON")
 		}
-	} else {
-		reqBody = nil // Send request without body
+		return executeRequestRaw(ctx, client, method, url, bytes.NewReader(jsonData), ContentTypeJSON)
 	}
+}
 
-	// Create the HTTP request
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+// executeRequestRaw handles the creation and execution of an HTTP request whose body is sent unmodified
+// with the given content type. A nil body sends the request without a body or Content-Type header.
+func executeRequestRaw(
+	ctx context.Context,
+	client *client.Client,
+	method, url string,
+	body io.Reader,
+	contentType string,
+) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create HTTP request")
 	}
 
-	// Set Content-Type header only if there is a body
-	if contentType != "" {
+	if body != nil && contentType != "" {
 		req.Header.Set("Content-Type", contentType)
 	}
 
-	// Execute the request
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to execute HTTP request")
@@ -152,3 +312,133 @@ func executeRequest(
 
 	return resp, nil
 }
+
+// streamBody ties a response body returned to the caller to the request context, so reads fail with
+// the context's error once it is cancelled or its deadline passes, even while data is still buffered.
+func streamBody(ctx context.Context, body io.ReadCloser) io.ReadCloser {
+	return &contextReader{ctx: ctx, body: body}
+}
+
+// contextReader is a response body that stops reading once its context is done.
+type contextReader struct {
+	ctx  context.Context
+	body io.ReadCloser
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := r.body.Read(p)
+	if err != nil && err != io.EOF {
+		if ctxErr := r.ctx.Err(); ctxErr != nil {
+			return n, ctxErr
+		}
+	}
+	return n, err
+}
+
+func (r *contextReader) Close() error {
+	return r.body.Close()
+}
+
+// contentTypeFor returns the MIME type for raw content of the given artifact type, or */* when unknown.
+func contentTypeFor(c *client.Client, artifactType models.ArtifactType) string {
+	if contentType := c.ContentTypeFor(artifactType); contentType != "" {
+		return contentType
+	}
+	return ContentTypeAll
+}
+
+// executeSearchRequest issues a GET search request for the given path and query.
+// When the client sets MaxURLLength, a longer URL is rejected with models.ErrQueryTooLong instead of being
+// sent. Long queries cannot fall back to a POST search: POST /search/artifacts and /search/versions search
+// by the content in the body, and /search/groups has no POST variant.
+func executeSearchRequest(
+	ctx context.Context,
+	c *client.Client,
+	path string,
+	query url.Values,
+) (*http.Response, error) {
+	urlPath := c.BaseURL + path
+	if encoded := query.Encode(); encoded != "" {
+		urlPath += "?" + encoded
+	}
+	if c.MaxURLLength > 0 && len(urlPath) > c.MaxURLLength {
+		return nil, errors.Wrapf(
+			models.ErrQueryTooLong,
+			"search URL of %d bytes exceeds the limit of %d",
+			len(urlPath),
+			c.MaxURLLength,
+		)
+	}
+	return executeRequest(ctx, c, http.MethodGet, urlPath, nil)
+}
+
+// withBudget derives a context bounded by the client's MaxElapsedTime.
+func withBudget(ctx context.Context, c *client.Client) (context.Context, context.CancelFunc) {
+	if c.MaxElapsedTime <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.MaxElapsedTime)
+}
+
+// budgetError reports models.ErrBudgetExceeded when err was caused by the budget of budgetCtx running out
+// rather than by the caller's ctx.
+func budgetError(ctx, budgetCtx context.Context, err error) error {
+	if ctx.Err() == nil && errors.Is(budgetCtx.Err(), context.DeadlineExceeded) {
+		return errors.Wrapf(models.ErrBudgetExceeded, "stopped after partial result: %v", err)
+	}
+	return err
+}
+
+// maxConcurrency returns the fan-out limit configured on the client.
+func maxConcurrency(c *client.Client) int {
+	if c.MaxConcurrency > 0 {
+		return c.MaxConcurrency
+	}
+	return client.DefaultMaxConcurrency
+}
+
+// forEach calls fn for every index in [0, n) using at most limit concurrent goroutines.
+// The first error cancels the context passed to the remaining calls and is returned.
+func forEach(ctx context.Context, limit, n int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		sem      = make(chan struct{}, limit)
+	)
+
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := fn(ctx, i); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
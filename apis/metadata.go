@@ -8,6 +8,7 @@ import (
 
 	"github.com/mollie/go-apicurio-registry/client"
 	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/pkg/errors"
 )
 
 // MetadataAPI handles metadata-related operations for artifacts.
@@ -23,10 +24,13 @@ func NewMetadataAPI(client *client.Client) *MetadataAPI {
 }
 
 // GetArtifactVersionMetadata retrieves metadata for a single artifact version.
+// The returned ETag can be passed to client.WithIfMatch for a later update.
 func (api *MetadataAPI) GetArtifactVersionMetadata(
 	ctx context.Context,
 	groupId, artifactId, versionExpression string,
 ) (*models.ArtifactVersionMetadata, error) {
+	ctx = client.WithOperationName(ctx, "GetArtifactVersionMetadata")
+
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return nil, err
 	}
@@ -54,16 +58,20 @@ func (api *MetadataAPI) GetArtifactVersionMetadata(
 	if err := handleResponse(resp, http.StatusOK, &metadata); err != nil {
 		return nil, err
 	}
+	metadata.ETag = resp.Header.Get("ETag")
 
 	return &metadata, nil
 }
 
 // UpdateArtifactVersionMetadata updates the user-editable metadata of an artifact version.
+// Use client.WithIfMatch on ctx for optimistic concurrency; a concurrent modification then fails with models.ErrPreconditionFailed.
 func (api *MetadataAPI) UpdateArtifactVersionMetadata(
 	ctx context.Context,
 	groupId, artifactId, versionExpression string,
 	metadata models.UpdateArtifactMetadataRequest,
 ) error {
+	ctx = client.WithOperationName(ctx, "UpdateArtifactVersionMetadata")
+
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
 	}
@@ -91,10 +99,13 @@ func (api *MetadataAPI) UpdateArtifactVersionMetadata(
 }
 
 // GetArtifactMetadata retrieves metadata for an artifact based on the latest version or the next available non-disabled version.
+// The returned ETag can be passed to client.WithIfMatch for a later update.
 func (api *MetadataAPI) GetArtifactMetadata(
 	ctx context.Context,
 	groupId, artifactId string,
 ) (*models.ArtifactMetadata, error) {
+	ctx = client.WithOperationName(ctx, "GetArtifactMetadata")
+
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return nil, err
 	}
@@ -118,16 +129,20 @@ func (api *MetadataAPI) GetArtifactMetadata(
 	if err := handleResponse(resp, http.StatusOK, &metadata); err != nil {
 		return nil, err
 	}
+	metadata.ETag = resp.Header.Get("ETag")
 
 	return &metadata, nil
 }
 
 // UpdateArtifactMetadata updates the editable parts of an artifact's metadata.
+// Use client.WithIfMatch on ctx for optimistic concurrency; a concurrent modification then fails with models.ErrPreconditionFailed.
 func (api *MetadataAPI) UpdateArtifactMetadata(
 	ctx context.Context,
 	groupId, artifactId string,
 	metadata models.UpdateArtifactMetadataRequest,
 ) error {
+	ctx = client.WithOperationName(ctx, "UpdateArtifactMetadata")
+
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
 	}
@@ -151,6 +166,108 @@ func (api *MetadataAPI) UpdateArtifactMetadata(
 	return handleResponse(resp, http.StatusNoContent, nil)
 }
 
+// CheckCompatibilityBatch checks a set of related schema proposals against the rules of their artifacts.
+// Each proposal is evaluated in order as a dry-run version creation, so nothing is published. A proposal
+// for an artifact that does not exist yet is compatible. Rule violations are reported per artifact in the
+// result; any other failure aborts the batch with an error.
+func (api *MetadataAPI) CheckCompatibilityBatch(
+	ctx context.Context,
+	groupID string,
+	proposals []models.SchemaProposal,
+	params *models.CheckCompatibilityBatchParams,
+) (*models.BatchCompatibilityResult, error) {
+	ctx = client.WithOperationName(ctx, "CheckCompatibilityBatch")
+
+	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
+		return nil, err
+	}
+	for _, proposal := range proposals {
+		if err := validateInput(proposal.ArtifactID, regexGroupIDArtifactID, "Artifact ID"); err != nil {
+			return nil, err
+		}
+	}
+
+	versionsAPI := NewVersionsAPI(api.Client)
+	result := &models.BatchCompatibilityResult{
+		Compatible: true,
+		Results:    make(map[string]*models.CompatibilityResult, len(proposals)),
+	}
+	for i, proposal := range proposals {
+		if !result.Compatible && params != nil && params.StopOnFirstIncompatible {
+			for _, skipped := range proposals[i:] {
+				result.Skipped = append(result.Skipped, skipped.ArtifactID)
+			}
+			break
+		}
+
+		check, err := checkProposal(ctx, versionsAPI, groupID, proposal)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to check compatibility of %s", proposal.ArtifactID)
+		}
+		result.Results[proposal.ArtifactID] = check
+		result.Compatible = result.Compatible && check.Compatible
+	}
+
+	return result, nil
+}
+
+// TestCompatibility checks candidate content against the rules configured for the artifact, its group and
+// the registry, without publishing it. The registry has no dedicated endpoint for this, so the content is
+// submitted as a dry-run version creation; rule violations are returned as an incompatible result, with one
+// reason per violation (see models.CompatibilityResult.Reasons). A missing artifact is reported as an error.
+func (api *MetadataAPI) TestCompatibility(
+	ctx context.Context,
+	groupId, artifactId string,
+	content models.CreateContentRequest,
+) (*models.CompatibilityResult, error) {
+	ctx = client.WithOperationName(ctx, "TestCompatibility")
+
+	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
+		return nil, err
+	}
+	if err := validateInput(artifactId, regexGroupIDArtifactID, "Artifact ID"); err != nil {
+		return nil, err
+	}
+
+	request := &models.CreateVersionRequest{Content: content}
+	_, err := NewVersionsAPI(api.Client).CreateArtifactVersion(ctx, groupId, artifactId, request, true)
+	if err == nil {
+		return &models.CompatibilityResult{Compatible: true}, nil
+	}
+
+	var apiErr *models.APIError
+	if errors.As(err, &apiErr) && apiErr.Status == http.StatusConflict && len(apiErr.Causes) > 0 {
+		return apiErr.CompatibilityResult(), nil
+	}
+	return nil, err
+}
+
+// checkProposal evaluates a single schema proposal with a dry-run version creation.
+func checkProposal(
+	ctx context.Context,
+	versionsAPI *VersionsAPI,
+	groupID string,
+	proposal models.SchemaProposal,
+) (*models.CompatibilityResult, error) {
+	request := &models.CreateVersionRequest{Content: proposal.Content}
+	_, err := versionsAPI.CreateArtifactVersion(ctx, groupID, proposal.ArtifactID, request, true)
+	if err == nil {
+		return &models.CompatibilityResult{Compatible: true}, nil
+	}
+
+	var apiErr *models.APIError
+	if !errors.As(err, &apiErr) {
+		return nil, err
+	}
+	switch {
+	case apiErr.Status == http.StatusNotFound:
+		return &models.CompatibilityResult{Compatible: true}, nil
+	case apiErr.Status == http.StatusConflict && len(apiErr.Causes) > 0:
+		return apiErr.CompatibilityResult(), nil
+	}
+	return nil, err
+}
+
 // executeRequest executes an HTTP request with the given method, URL, and body.
 func (api *MetadataAPI) executeRequest(
 	ctx context.Context,
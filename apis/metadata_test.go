@@ -37,6 +37,7 @@ func TestGetArtifactVersionMetadata(t *testing.T) {
 			assert.Contains(t, r.URL.Path, "/groups/test-group/artifacts/artifact-1/versions/1.0")
 			assert.Equal(t, http.MethodGet, r.Method)
 
+			w.Header().Set("ETag", `"etag-1"`)
 			w.WriteHeader(http.StatusOK)
 			err := json.NewEncoder(w).Encode(mockMetadata)
 			assert.NoError(t, err)
@@ -56,6 +57,7 @@ func TestGetArtifactVersionMetadata(t *testing.T) {
 		assert.NotNil(t, result)
 		assert.Equal(t, "Test Artifact", result.Name)
 		assert.Equal(t, "1.0", result.Version)
+		assert.Equal(t, `"etag-1"`, result.ETag)
 	})
 
 	t.Run("InvalidInputs", func(t *testing.T) {
@@ -218,8 +220,11 @@ func TestGetArtifactMetadata(t *testing.T) {
 			ModifiedOn: "2024-12-09",
 		}
 
-		server := setupMockServer(t, http.StatusOK, mockMetadata,
-			"/groups/test-group/artifacts/artifact-1", http.MethodGet)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/groups/test-group/artifacts/artifact-1", r.URL.Path)
+			w.Header().Set("ETag", `"etag-2"`)
+			_ = json.NewEncoder(w).Encode(mockMetadata)
+		}))
 		defer server.Close()
 
 		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
@@ -230,6 +235,7 @@ func TestGetArtifactMetadata(t *testing.T) {
 		assert.NotNil(t, result)
 		assert.Equal(t, "Test Artifact", result.Name)
 		assert.Equal(t, "user-1", result.ModifiedBy)
+		assert.Equal(t, `"etag-2"`, result.ETag)
 	})
 
 	t.Run("Validation: Invalid Inputs", func(t *testing.T) {
@@ -293,6 +299,42 @@ func TestUpdateArtifactMetadata(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Round Trip", func(t *testing.T) {
+		var stored models.ArtifactMetadata
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/groups/test-group/artifacts/artifact-1", r.URL.Path)
+			switch r.Method {
+			case http.MethodPut:
+				var update models.UpdateArtifactMetadataRequest
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+				stored.Name = update.Name
+				stored.Description = update.Description
+				stored.Labels = update.Labels
+				w.WriteHeader(http.StatusNoContent)
+			case http.MethodGet:
+				assert.NoError(t, json.NewEncoder(w).Encode(stored))
+			}
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewMetadataAPI(mockClient)
+
+		update := models.UpdateArtifactMetadataRequest{
+			Name:        "Updated Artifact",
+			Description: "Updated Description",
+			Labels:      map[string]string{"env": "prod", "team": "payments"},
+		}
+		err := api.UpdateArtifactMetadata(context.Background(), "test-group", "artifact-1", update)
+		assert.NoError(t, err)
+
+		metadata, err := api.GetArtifactMetadata(context.Background(), "test-group", "artifact-1")
+		assert.NoError(t, err)
+		assert.Equal(t, update.Name, metadata.Name)
+		assert.Equal(t, update.Description, metadata.Description)
+		assert.Equal(t, update.Labels, metadata.Labels)
+	})
+
 	t.Run("Invalid Inputs", func(t *testing.T) {
 		ctx := context.Background()
 
@@ -342,6 +384,165 @@ func TestUpdateArtifactMetadata(t *testing.T) {
 	})
 }
 
+func TestMetadataAPI_TestCompatibility(t *testing.T) {
+	content := models.CreateContentRequest{Content: stubArtifactContent, ContentType: "application/json"}
+	path := fmt.Sprintf("/groups/%s/artifacts/%s/versions", stubGroupId, stubArtifactId)
+
+	t.Run("Compatible", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, path, r.URL.Path)
+			assert.Equal(t, "true", r.URL.Query().Get("dryRun"))
+
+			var request models.CreateVersionRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, content, request.Content)
+
+			_ = json.NewEncoder(w).Encode(models.ArtifactVersionDetailed{
+				ArtifactVersion: models.ArtifactVersion{Version: "2", ArtifactID: stubArtifactId, ArtifactType: models.Json},
+			})
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewMetadataAPI(mockClient)
+
+		result, err := api.TestCompatibility(context.Background(), stubGroupId, stubArtifactId, content)
+		assert.NoError(t, err)
+		assert.True(t, result.Compatible)
+		assert.Empty(t, result.Reasons())
+	})
+
+	t.Run("Incompatible", func(t *testing.T) {
+		mockResponse := models.APIError{
+			Status: http.StatusConflict,
+			Title:  TitleConflict,
+			Causes: []models.RuleViolationCause{
+				{Description: "FIELD_REMOVED: field amount removed", Context: "/properties/amount"},
+				{Description: "Incompatible type change", Context: "/properties/currency"},
+			},
+		}
+		server := setupMockServer(t, http.StatusConflict, mockResponse, path, http.MethodPost)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewMetadataAPI(mockClient)
+
+		result, err := api.TestCompatibility(context.Background(), stubGroupId, stubArtifactId, content)
+		assert.NoError(t, err)
+		assert.False(t, result.Compatible)
+		assert.Equal(t, []string{
+			"FIELD_REMOVED at /properties/amount: field amount removed",
+			"at /properties/currency: Incompatible type change",
+		}, result.Reasons())
+	})
+
+	t.Run("Artifact Not Found", func(t *testing.T) {
+		mockResponse := models.APIError{Status: http.StatusNotFound, Title: TitleNotFound}
+		server := setupMockServer(t, http.StatusNotFound, mockResponse, path, http.MethodPost)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewMetadataAPI(mockClient)
+
+		result, err := api.TestCompatibility(context.Background(), stubGroupId, stubArtifactId, content)
+		assert.Nil(t, result)
+		assertAPIError(t, err, http.StatusNotFound, TitleNotFound)
+	})
+}
+
+func TestMetadataAPI_CheckCompatibilityBatch(t *testing.T) {
+	proposals := []models.SchemaProposal{
+		{ArtifactID: "orders", Content: models.CreateContentRequest{Content: stubArtifactContent, ContentType: "application/json"}},
+		{ArtifactID: "payments", Content: models.CreateContentRequest{Content: stubArtifactContent, ContentType: "application/json"}},
+		{ArtifactID: "refunds", Content: models.CreateContentRequest{Content: stubArtifactContent, ContentType: "application/json"}},
+	}
+
+	newServer := func(t *testing.T, checked *[]string) *httptest.Server {
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /groups/{groupId}/artifacts/{artifactId}/versions", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "true", r.URL.Query().Get("dryRun"))
+			artifactID := r.PathValue("artifactId")
+			*checked = append(*checked, artifactID)
+
+			w.Header().Set("Content-Type", "application/json")
+			switch artifactID {
+			case "payments":
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(models.APIError{
+					Status: http.StatusConflict,
+					Title:  TitleConflict,
+					Causes: []models.RuleViolationCause{
+						{Description: "FIELD_REMOVED: field amount removed", Context: "/properties/amount"},
+					},
+				})
+			case "refunds":
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(models.APIError{Status: http.StatusNotFound, Title: TitleNotFound})
+			default:
+				_ = json.NewEncoder(w).Encode(models.ArtifactVersionDetailed{
+					ArtifactVersion: models.ArtifactVersion{Version: "2", ArtifactID: artifactID, ArtifactType: models.Json},
+				})
+			}
+		})
+		return httptest.NewServer(mux)
+	}
+
+	t.Run("One Incompatible", func(t *testing.T) {
+		var checked []string
+		server := newServer(t, &checked)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewMetadataAPI(mockClient)
+
+		result, err := api.CheckCompatibilityBatch(context.Background(), stubGroupId, proposals, nil)
+		assert.NoError(t, err)
+		assert.False(t, result.Compatible)
+		assert.Equal(t, []string{"orders", "payments", "refunds"}, checked)
+		assert.Empty(t, result.Skipped)
+
+		assert.True(t, result.Results["orders"].Compatible)
+		assert.True(t, result.Results["refunds"].Compatible, "a new artifact has nothing to be incompatible with")
+		assert.False(t, result.Results["payments"].Compatible)
+		assert.Equal(t, []models.Incompatibility{
+			{Type: "FIELD_REMOVED", Path: "/properties/amount", Message: "field amount removed"},
+		}, result.Results["payments"].Incompatibilities)
+	})
+
+	t.Run("Stop On First Incompatible", func(t *testing.T) {
+		var checked []string
+		server := newServer(t, &checked)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewMetadataAPI(mockClient)
+
+		result, err := api.CheckCompatibilityBatch(
+			context.Background(),
+			stubGroupId,
+			proposals,
+			&models.CheckCompatibilityBatchParams{StopOnFirstIncompatible: true},
+		)
+		assert.NoError(t, err)
+		assert.False(t, result.Compatible)
+		assert.Equal(t, []string{"orders", "payments"}, checked)
+		assert.Equal(t, []string{"refunds"}, result.Skipped)
+		assert.NotContains(t, result.Results, "refunds")
+	})
+
+	t.Run("Validation Errors", func(t *testing.T) {
+		mockClient := &client.Client{BaseURL: "http://mock.server", HTTPClient: http.DefaultClient}
+		api := apis.NewMetadataAPI(mockClient)
+
+		_, err := api.CheckCompatibilityBatch(context.Background(), "", proposals, nil)
+		assert.ErrorContains(t, err, "Group ID")
+
+		_, err = api.CheckCompatibilityBatch(context.Background(), stubGroupId, []models.SchemaProposal{{}}, nil)
+		assert.ErrorContains(t, err, "Artifact ID")
+	})
+}
+
 /***********************/
 /***** Integration *****/
 /***********************/
@@ -0,0 +1,184 @@
+package apis
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync/atomic"
+
+	"github.com/mollie/go-apicurio-registry/internal/lru"
+	"github.com/mollie/go-apicurio-registry/internal/plumbing"
+	"github.com/mollie/go-apicurio-registry/models"
+)
+
+// DefaultSchemaCacheSize is the number of schemas a SchemaResolver caches when no size is given.
+const DefaultSchemaCacheSize = 1000
+
+// SchemaKey identifies a schema lookup of a SchemaResolver: either a global ID or the coordinates of a version.
+type SchemaKey struct {
+	GlobalID   int64  // Set by ResolveByGlobalID
+	GroupID    string // Set by ResolveByCoordinates
+	ArtifactID string // Set by ResolveByCoordinates
+	Version    string // Set by ResolveByCoordinates
+}
+
+// SchemaCacheHook is called with the key of every lookup a SchemaResolver serves from its cache.
+type SchemaCacheHook func(key SchemaKey)
+
+// SchemaCacheStats counts the lookups of a SchemaResolver.
+type SchemaCacheStats struct {
+	Hits   int64 // Lookups served from the cache
+	Misses int64 // Cacheable lookups fetched from the registry, including those of DRAFT versions
+}
+
+// SchemaResolver fetches schema content by global ID or by version coordinates and keeps the most recently
+// used schemas in an LRU cache, for serializers and other code that look up the same schemas over and over.
+// It is safe for concurrent use.
+//
+// The state of a version is looked up before its content, and the content of DRAFT versions, which can still
+// be updated, is never cached. Version expressions such as "branch=latest" may point to another version at
+// any time and are always fetched. A version that is deleted and created again under the same version string,
+// or an artifact that is deleted and created again, leaves stale entries behind: when the client is created
+// with client.WithEventDrivenCacheInvalidation, the entries of an artifact are evicted when the registry
+// reports it updated or deleted; otherwise call Purge. Such a resolver stays registered with the client until
+// Close is called.
+//
+// Looking up the state costs one extra request per cache miss: SearchForArtifactVersions for a global ID and
+// GetArtifactVersionState for coordinates.
+type SchemaResolver struct {
+	Artifacts *ArtifactsAPI
+
+	// OnCacheHit is called for every lookup served from the cache. It must be set before the resolver is used.
+	OnCacheHit SchemaCacheHook
+
+	cache          *lru.Cache[SchemaKey, models.ArtifactContent]
+	hits           atomic.Int64
+	misses         atomic.Int64
+	removeListener func()
+}
+
+// NewSchemaResolver creates a SchemaResolver caching up to size schemas. A size below one means DefaultSchemaCacheSize.
+func NewSchemaResolver(artifacts *ArtifactsAPI, size int) *SchemaResolver {
+	if size < 1 {
+		size = DefaultSchemaCacheSize
+	}
+	r := &SchemaResolver{
+		Artifacts: artifacts,
+		cache:     lru.New[SchemaKey, models.ArtifactContent](size),
+	}
+	r.removeListener = plumbing.Of(artifacts.Client).AddInvalidationListener(r.invalidateForEvent)
+	return r
+}
+
+// ResolveByGlobalID returns the content and artifact type of the version with the given global ID.
+func (r *SchemaResolver) ResolveByGlobalID(ctx context.Context, globalID int64) (*models.ArtifactContent, error) {
+	key := SchemaKey{GlobalID: globalID}
+	if content, ok := r.cached(key); ok {
+		return content, nil
+	}
+
+	// The state is looked up first: content fetched after a version was seen outside DRAFT can no longer change.
+	versions, err := NewVersionsAPI(r.Artifacts.Client).SearchForArtifactVersions(ctx, &models.SearchVersionParams{
+		GlobalID: globalID,
+		Limit:    1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := r.Artifacts.GetArtifactByGlobalID(ctx, globalID, &models.GetArtifactByGlobalIDParams{
+		ReturnArtifactType: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		// The version was created after the search; its state is unknown.
+		return content, nil
+	}
+
+	version := versions[0]
+	content.GroupID = version.GroupID
+	content.ArtifactID = version.ArtifactID
+	content.Version = version.Version
+	if version.State != models.StateDraft {
+		r.cache.Add(key, *content)
+	}
+	return content, nil
+}
+
+// ResolveByCoordinates returns the content and artifact type of a version of an artifact.
+func (r *SchemaResolver) ResolveByCoordinates(
+	ctx context.Context,
+	groupID, artifactID, version string,
+) (*models.ArtifactContent, error) {
+	key := SchemaKey{GroupID: groupID, ArtifactID: artifactID, Version: version}
+	versions := NewVersionsAPI(r.Artifacts.Client)
+	cacheable := version != models.BranchLatest && !strings.Contains(version, "=")
+	if cacheable {
+		if content, ok := r.cached(key); ok {
+			return content, nil
+		}
+
+		// The state is looked up first: content fetched after a version was seen outside DRAFT can no longer change.
+		state, err := versions.GetArtifactVersionState(ctx, groupID, artifactID, version)
+		if err != nil {
+			return nil, err
+		}
+		cacheable = *state != models.StateDraft
+	}
+
+	content, err := versions.GetArtifactVersionContent(ctx, groupID, artifactID, version, nil)
+	if err != nil {
+		return nil, err
+	}
+	content.GroupID = groupID
+	content.ArtifactID = artifactID
+	if cacheable {
+		r.cache.Add(key, *content)
+	}
+	return content, nil
+}
+
+// Stats returns the number of cache hits and misses since the resolver was created.
+func (r *SchemaResolver) Stats() SchemaCacheStats {
+	return SchemaCacheStats{Hits: r.hits.Load(), Misses: r.misses.Load()}
+}
+
+// Purge removes every cached schema.
+func (r *SchemaResolver) Purge() {
+	r.cache.Purge()
+}
+
+// Close stops the eviction of cached schemas for registry events, so that a resolver that is no longer used
+// can be garbage collected while its client lives on. The resolver keeps serving lookups afterwards.
+func (r *SchemaResolver) Close() {
+	r.removeListener()
+}
+
+// cached returns a copy of the cached content for key and counts the lookup.
+func (r *SchemaResolver) cached(key SchemaKey) (*models.ArtifactContent, bool) {
+	content, ok := r.cache.Get(key)
+	if !ok {
+		r.misses.Add(1)
+		return nil, false
+	}
+
+	r.hits.Add(1)
+	if r.OnCacheHit != nil {
+		if err := plumbing.InvokeHook("SchemaCacheHook", func() { r.OnCacheHit(key) }); err != nil {
+			log.Printf("apicurio: %v", err)
+		}
+	}
+	return &content, true
+}
+
+// invalidateForEvent evicts the cached schemas of an artifact the registry reports updated or deleted.
+func (r *SchemaResolver) invalidateForEvent(event models.RegistryEvent) {
+	switch event.Type {
+	case models.EventArtifactUpdated, models.EventArtifactDeleted:
+		r.cache.RemoveFunc(func(_ SchemaKey, content models.ArtifactContent) bool {
+			return content.GroupID == event.GroupID && content.ArtifactID == event.ArtifactID
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package apis_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mollie/go-apicurio-registry/apis"
+	"github.com/mollie/go-apicurio-registry/client"
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaResolver(t *testing.T) {
+	// newServer serves stubArtifactContent for every global ID and version, reporting every version in state,
+	// and counts the content requests.
+	newServer := func(t *testing.T, requests *atomic.Int64, state models.State) *httptest.Server {
+		mux := http.NewServeMux()
+		serve := func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.Header().Set("X-Registry-ArtifactType", string(models.Avro))
+			_, _ = w.Write([]byte(stubArtifactContent))
+		}
+		mux.HandleFunc("GET /ids/globalIds/{id}", serve)
+		mux.HandleFunc("GET /groups/{group}/artifacts/{artifact}/versions/{version}/content", serve)
+		mux.HandleFunc("GET /search/versions", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w,
+				`{"count": 1, "versions": [{"groupId": %q, "artifactId": %q, "version": "1.0.0", "globalId": %s, "state": %q}]}`,
+				stubGroupId, stubArtifactId, r.URL.Query().Get("globalId"), state,
+			)
+		})
+		mux.HandleFunc("GET /groups/{group}/artifacts/{artifact}/versions/{version}/state", func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"state": %q}`, state)
+		})
+		server := httptest.NewServer(mux)
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	t.Run("Resolve By Global ID", func(t *testing.T) {
+		var requests atomic.Int64
+		server := newServer(t, &requests, models.StateEnabled)
+		resolver := apis.NewSchemaResolver(apis.NewArtifactsAPI(client.NewClient(server.URL)), 10)
+
+		var hits []apis.SchemaKey
+		resolver.OnCacheHit = func(key apis.SchemaKey) { hits = append(hits, key) }
+
+		for range 2 {
+			content, err := resolver.ResolveByGlobalID(context.Background(), 42)
+			assert.NoError(t, err)
+			assert.Equal(t, stubArtifactContent, content.Content)
+			assert.Equal(t, models.Avro, content.ArtifactType)
+			assert.Equal(t, stubGroupId, content.GroupID)
+			assert.Equal(t, "1.0.0", content.Version)
+		}
+		assert.Equal(t, int64(1), requests.Load(), "the second resolve must be served from the cache")
+		assert.Equal(t, []apis.SchemaKey{{GlobalID: 42}}, hits)
+		assert.Equal(t, apis.SchemaCacheStats{Hits: 1, Misses: 1}, resolver.Stats())
+	})
+
+	t.Run("Resolve By Coordinates", func(t *testing.T) {
+		var requests atomic.Int64
+		server := newServer(t, &requests, models.StateEnabled)
+		resolver := apis.NewSchemaResolver(apis.NewArtifactsAPI(client.NewClient(server.URL)), 10)
+
+		for range 2 {
+			content, err := resolver.ResolveByCoordinates(context.Background(), stubGroupId, stubArtifactId, "1.0.0")
+			assert.NoError(t, err)
+			assert.Equal(t, stubArtifactContent, content.Content)
+			assert.Equal(t, stubGroupId, content.GroupID)
+			assert.Equal(t, stubArtifactId, content.ArtifactID)
+		}
+		assert.Equal(t, int64(1), requests.Load())
+
+		// A global ID and coordinates are cached independently.
+		_, err := resolver.ResolveByGlobalID(context.Background(), 42)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), requests.Load())
+	})
+
+	t.Run("Version Expressions Are Not Cached", func(t *testing.T) {
+		var requests atomic.Int64
+		server := newServer(t, &requests, models.StateEnabled)
+		resolver := apis.NewSchemaResolver(apis.NewArtifactsAPI(client.NewClient(server.URL)), 10)
+
+		for _, version := range []string{"branch=latest", "branch=latest", "latest"} {
+			_, err := resolver.ResolveByCoordinates(context.Background(), stubGroupId, stubArtifactId, version)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, int64(3), requests.Load())
+		assert.Equal(t, apis.SchemaCacheStats{}, resolver.Stats())
+	})
+
+	t.Run("Draft Versions Are Not Cached", func(t *testing.T) {
+		var requests atomic.Int64
+		server := newServer(t, &requests, models.StateDraft)
+		resolver := apis.NewSchemaResolver(apis.NewArtifactsAPI(client.NewClient(server.URL)), 10)
+
+		for range 2 {
+			_, err := resolver.ResolveByGlobalID(context.Background(), 42)
+			assert.NoError(t, err)
+			_, err = resolver.ResolveByCoordinates(context.Background(), stubGroupId, stubArtifactId, "1.0.0")
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, int64(4), requests.Load(), "the content of a draft can change and must be fetched every time")
+		assert.Equal(t, apis.SchemaCacheStats{Misses: 4}, resolver.Stats())
+	})
+
+	t.Run("Least Recently Used Is Evicted", func(t *testing.T) {
+		var requests atomic.Int64
+		server := newServer(t, &requests, models.StateEnabled)
+		resolver := apis.NewSchemaResolver(apis.NewArtifactsAPI(client.NewClient(server.URL)), 2)
+
+		for _, id := range []int64{1, 2, 1, 3, 1, 2} {
+			_, err := resolver.ResolveByGlobalID(context.Background(), id)
+			assert.NoError(t, err)
+		}
+		// 2 is evicted by 3, while 1 stays cached because it was used more recently.
+		assert.Equal(t, int64(4), requests.Load())
+		assert.Equal(t, apis.SchemaCacheStats{Hits: 2, Misses: 4}, resolver.Stats())
+	})
+
+	t.Run("Errors Are Not Cached", func(t *testing.T) {
+		var requests atomic.Int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status": 404, "title": "No content with global ID 42"}`))
+		}))
+		defer server.Close()
+		resolver := apis.NewSchemaResolver(apis.NewArtifactsAPI(client.NewClient(server.URL)), 10)
+
+		for range 2 {
+			_, err := resolver.ResolveByGlobalID(context.Background(), 42)
+			assertAPIError(t, err, http.StatusNotFound, "No content with global ID 42")
+		}
+		assert.Equal(t, int64(2), requests.Load())
+	})
+
+	t.Run("Concurrent Use", func(t *testing.T) {
+		var requests atomic.Int64
+		server := newServer(t, &requests, models.StateEnabled)
+		resolver := apis.NewSchemaResolver(apis.NewArtifactsAPI(client.NewClient(server.URL)), 10)
+		_, err := resolver.ResolveByGlobalID(context.Background(), 42)
+		assert.NoError(t, err)
+
+		var wg sync.WaitGroup
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := resolver.ResolveByGlobalID(context.Background(), 42)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int64(1), requests.Load())
+		assert.Equal(t, apis.SchemaCacheStats{Hits: 20, Misses: 1}, resolver.Stats())
+	})
+
+	t.Run("Evicted On Events", func(t *testing.T) {
+		var requests atomic.Int64
+		sendEvent := make(chan string)
+		server := newServer(t, &requests, models.StateEnabled)
+		server.Config.Handler.(*http.ServeMux).HandleFunc("GET /events", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.(http.Flusher).Flush()
+			for {
+				select {
+				case event := <-sendEvent:
+					_, _ = w.Write([]byte(event))
+					w.(http.Flusher).Flush()
+				case <-r.Context().Done():
+					return
+				}
+			}
+		})
+		mockClient := client.NewClient(server.URL, client.WithEventDrivenCacheInvalidation())
+		resolver := apis.NewSchemaResolver(apis.NewArtifactsAPI(mockClient), 10)
+		resolve := func() {
+			_, err := resolver.ResolveByGlobalID(context.Background(), 42)
+			assert.NoError(t, err)
+			_, err = resolver.ResolveByCoordinates(context.Background(), stubGroupId, stubArtifactId, "1.0.0")
+			assert.NoError(t, err)
+		}
+		resolve()
+		assert.Equal(t, int64(2), requests.Load())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events, err := apis.NewSystemAPI(mockClient).SubscribeEvents(ctx)
+		assert.NoError(t, err)
+
+		sendEvent <- "event: ARTIFACT_UPDATED\ndata: {\"groupId\":\"test-group\",\"artifactId\":\"other-artifact\"}\n\n"
+		<-events
+		resolve()
+		assert.Equal(t, int64(2), requests.Load(), "events for other artifacts keep the entries cached")
+
+		sendEvent <- "event: ARTIFACT_DELETED\ndata: {\"groupId\":\"test-group\",\"artifactId\":\"test-artifact\"}\n\n"
+		<-events
+		resolve()
+		assert.Equal(t, int64(4), requests.Load(), "the deletion evicted the global ID and the coordinates")
+
+		resolver.Close()
+		sendEvent <- "event: ARTIFACT_DELETED\ndata: {\"groupId\":\"test-group\",\"artifactId\":\"test-artifact\"}\n\n"
+		<-events
+		resolve()
+		assert.Equal(t, int64(4), requests.Load(), "a closed resolver no longer evicts for events")
+	})
+}
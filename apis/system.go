@@ -1,12 +1,18 @@
 package apis
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/mollie/go-apicurio-registry/client"
+	"github.com/mollie/go-apicurio-registry/internal/plumbing"
 	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/pkg/errors"
 )
 
 type SystemAPI struct {
@@ -22,8 +28,11 @@ func NewSystemAPI(client *client.Client) *SystemAPI {
 
 // GetSystemInfo gets the system info
 // This operation retrieves information about the running registry system, such as the version of the software and when it was built.
+// The build timestamp is available as a time.Time through models.SystemInfoResponse.BuildTime.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/System/operation/getSystemInfo
 func (api *SystemAPI) GetSystemInfo(ctx context.Context) (*models.SystemInfoResponse, error) {
+	ctx = client.WithOperationName(ctx, "GetSystemInfo")
+
 	urlPath := fmt.Sprintf("%s/system/info", api.Client.BaseURL)
 	resp, err := api.executeRequest(ctx, http.MethodGet, urlPath, nil)
 	if err != nil {
@@ -42,6 +51,8 @@ func (api *SystemAPI) GetSystemInfo(ctx context.Context) (*models.SystemInfoResp
 // Returns the UI configuration properties for this server. The registry UI can be connected to a backend using just a URL. The rest of the UI configuration can then be fetched from the backend using this operation. This allows UI and backend to both be configured in the same place.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/System/operation/getUIConfig
 func (api *SystemAPI) GetUIConfig(ctx context.Context) (*models.SystemUIConfigResponse, error) {
+	ctx = client.WithOperationName(ctx, "GetUIConfig")
+
 	urlPath := fmt.Sprintf("%s/system/uiConfig", api.Client.BaseURL)
 	resp, err := api.executeRequest(ctx, http.MethodGet, urlPath, nil)
 	if err != nil {
@@ -60,6 +71,8 @@ func (api *SystemAPI) GetUIConfig(ctx context.Context) (*models.SystemUIConfigRe
 // GetCurrentUser Returns information about the currently authenticated user.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Users
 func (api *SystemAPI) GetCurrentUser(ctx context.Context) (*models.UserInfo, error) {
+	ctx = client.WithOperationName(ctx, "GetCurrentUser")
+
 	urlPath := fmt.Sprintf("%s/users/me", api.Client.BaseURL)
 	resp, err := api.executeRequest(ctx, http.MethodGet, urlPath, nil)
 	if err != nil {
@@ -74,6 +87,160 @@ func (api *SystemAPI) GetCurrentUser(ctx context.Context) (*models.UserInfo, err
 	return &userInfo, nil
 }
 
+// IsReady Reports whether the registry is ready to serve requests, for readiness probes.
+// A 2xx response from /health/ready means ready; any other status, such as 503, means not ready and is not an error.
+// Only failures to reach the registry are returned as errors.
+func (api *SystemAPI) IsReady(ctx context.Context) (bool, error) {
+	ctx = client.WithOperationName(ctx, "IsReady")
+
+	return api.checkHealth(ctx, "ready")
+}
+
+// IsLive Reports whether the registry is alive, for liveness probes.
+// A 2xx response from /health/live means alive; any other status, such as 503, means not alive and is not an error.
+// Only failures to reach the registry are returned as errors.
+func (api *SystemAPI) IsLive(ctx context.Context) (bool, error) {
+	ctx = client.WithOperationName(ctx, "IsLive")
+
+	return api.checkHealth(ctx, "live")
+}
+
+// checkHealth calls a health endpoint. Health endpoints are served from the root of the registry rather than
+// from the REST API, so the API path (e.g. /apis/registry/v3) is stripped from the base URL. The call is never
+// retried: a probe must report an unhealthy registry at once rather than outlast its own timeout in backoff.
+func (api *SystemAPI) checkHealth(ctx context.Context, check string) (bool, error) {
+	ctx = client.WithoutRetries(ctx)
+
+	root := api.Client.BaseURL
+	if i := strings.Index(root, "/apis/"); i >= 0 {
+		root = root[:i]
+	}
+
+	urlPath := fmt.Sprintf("%s/health/%s", root, check)
+	resp, err := api.executeRequest(ctx, http.MethodGet, urlPath, nil)
+	if err != nil {
+		return false, err
+	}
+	plumbing.DrainBody(resp)
+
+	return resp.StatusCode >= 200 && resp.StatusCode < 300, nil
+}
+
+// SubscribeEvents Subscribes to the registry's server-sent event stream, e.g. to invalidate caches when
+// artifacts change upstream. Decoded events are delivered on the returned channel, which is closed when ctx
+// is done or the registry ends the stream; resubscribe to resume. Registries that do not expose an event
+// stream fail with models.ErrEventsUnsupported. The HTTP client's timeout also bounds the subscription, so
+// long-lived subscriptions need a client without one.
+// GET /events
+func (api *SystemAPI) SubscribeEvents(ctx context.Context) (<-chan models.RegistryEvent, error) {
+	ctx = client.WithOperationName(ctx, "SubscribeEvents")
+
+	urlPath := fmt.Sprintf("%s/events", api.Client.BaseURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlPath, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create HTTP request")
+	}
+	req.Header.Set("Accept", ContentTypeEventStream)
+	req.Header.Set("Accept-Encoding", "identity")
+	req.Header.Set("Cache-Control", "no-cache")
+
+	resp, err := api.Client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute HTTP request")
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		plumbing.DrainBody(resp)
+		return nil, models.ErrEventsUnsupported
+	default:
+		return nil, handleResponse(resp, http.StatusOK, nil)
+	}
+	if mediaType := resp.Header.Get("Content-Type"); !strings.HasPrefix(mediaType, ContentTypeEventStream) {
+		resp.Body.Close()
+		return nil, errors.Wrapf(models.ErrEventsUnsupported, "unexpected content type %q", mediaType)
+	}
+
+	events := make(chan models.RegistryEvent)
+	go readEvents(ctx, api.Client, resp.Body, events)
+	return events, nil
+}
+
+// RunCacheInvalidation Subscribes to the registry's event stream and applies every event to the client's
+// content cache and to the SchemaResolvers built on the client (see client.WithEventDrivenCacheInvalidation)
+// until ctx is done, for callers that do not consume the events themselves. It returns ctx's error once ctx is
+// done, or nil when the registry ends the stream, in which case the caller decides whether to run it again.
+func (api *SystemAPI) RunCacheInvalidation(ctx context.Context) error {
+	events, err := api.SubscribeEvents(ctx)
+	if err != nil {
+		return err
+	}
+	for range events {
+		// Events are applied to the cache before they are delivered.
+	}
+	return ctx.Err()
+}
+
+// readEvents decodes server-sent events from body onto events until the stream ends or ctx is done.
+// Each event is applied to the client's content cache before it is delivered.
+func readEvents(ctx context.Context, c *client.Client, body io.ReadCloser, events chan<- models.RegistryEvent) {
+	defer close(events)
+	defer body.Close()
+	// Closing the body unblocks a scanner waiting for the next line once ctx is done.
+	stop := context.AfterFunc(ctx, func() { body.Close() })
+	defer stop()
+
+	var eventType string
+	var data strings.Builder
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if data.Len() > 0 {
+				event := decodeEvent(eventType, data.String())
+				plumbing.Of(c).InvalidateForEvent(event)
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			eventType = ""
+			data.Reset()
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue // Comment, used by servers as a keep-alive
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		}
+	}
+}
+
+// decodeEvent builds an event from the SSE event name and data. The event name takes precedence over a
+// type field in the data; data that is not a JSON object only fills Data.
+func decodeEvent(eventType, data string) models.RegistryEvent {
+	var event models.RegistryEvent
+	_ = json.Unmarshal([]byte(data), &event)
+	event.Data = json.RawMessage(data)
+	if eventType != "" {
+		event.Type = models.RegistryEventType(eventType)
+	}
+	return event
+}
+
 // executeRequest handles the creation and execution of an HTTP request.
 func (api *SystemAPI) executeRequest(
 	ctx context.Context,
@@ -2,9 +2,13 @@ package apis_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/mollie/go-apicurio-registry/apis"
 	"github.com/mollie/go-apicurio-registry/client"
@@ -30,7 +34,13 @@ func TestSystemAPI_GetSystemInfo(t *testing.T) {
 		result, err := api.GetSystemInfo(context.Background())
 
 		assert.NoError(t, err)
+		assert.Equal(t, mockResponse.Name, result.Name)
+		assert.Equal(t, mockResponse.Description, result.Description)
 		assert.Equal(t, mockResponse.Version, result.Version)
+
+		builtOn, err := result.BuildTime()
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2021, time.March, 19, 12, 55, 0, 0, time.UTC), builtOn)
 	})
 
 	t.Run("InternalServerError", func(t *testing.T) {
@@ -49,6 +59,145 @@ func TestSystemAPI_GetSystemInfo(t *testing.T) {
 	})
 }
 
+func TestSystemAPI_ModelDecoder(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /system/info", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name": "Apicurio Registry", "version": "3.0.5", "vendor": "Red Hat"}`))
+	})
+	mux.HandleFunc("GET /users/me", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"username": "jane", "admin": true}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	calls := 0
+	mockClient := client.NewClient(
+		server.URL,
+		client.WithHTTPClient(server.Client()),
+		client.WithModelDecoder(models.SystemInfoResponse{}, func(data []byte, v interface{}) error {
+			calls++
+			var raw struct {
+				Name   string `json:"name"`
+				Vendor string `json:"vendor"`
+			}
+			if err := json.Unmarshal(data, &raw); err != nil {
+				return err
+			}
+			info := v.(*models.SystemInfoResponse)
+			info.Name = raw.Vendor + " " + raw.Name
+			return nil
+		}),
+	)
+	api := apis.NewSystemAPI(mockClient)
+
+	info, err := api.GetSystemInfo(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "Red Hat Apicurio Registry", info.Name)
+	assert.Empty(t, info.Version, "the custom decoder replaces the standard decoding")
+
+	user, err := api.GetCurrentUser(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "jane", user.Username)
+	assert.True(t, user.Admin)
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestSystemAPI_StatusHook(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /system/info", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"error": "registry is in maintenance mode"}`))
+	})
+	mux.HandleFunc("GET /users/me", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"username": "jane", "admin": true}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mockClient := client.NewClient(
+		server.URL,
+		client.WithHTTPClient(server.Client()),
+		client.WithStatusHook(func(resp *http.Response) (bool, error) {
+			var body struct {
+				Error string `json:"error"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.Error == "" {
+				return false, nil
+			}
+			return true, errors.New(body.Error)
+		}),
+	)
+	api := apis.NewSystemAPI(mockClient)
+
+	info, err := api.GetSystemInfo(context.Background())
+	assert.Nil(t, info)
+	assert.EqualError(t, err, "registry is in maintenance mode")
+
+	user, err := api.GetCurrentUser(context.Background())
+	assert.NoError(t, err, "an unhandled response keeps the default handling")
+	assert.Equal(t, "jane", user.Username)
+}
+
+func TestSystemAPI_Health(t *testing.T) {
+	checks := map[string]func(*apis.SystemAPI, context.Context) (bool, error){
+		"ready": (*apis.SystemAPI).IsReady,
+		"live":  (*apis.SystemAPI).IsLive,
+	}
+
+	for check, call := range checks {
+		t.Run(check, func(t *testing.T) {
+			for _, tt := range []struct {
+				name    string
+				status  int
+				healthy bool
+			}{
+				{"Healthy", http.StatusOK, true},
+				{"Unhealthy", http.StatusServiceUnavailable, false},
+			} {
+				t.Run(tt.name, func(t *testing.T) {
+					server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/health/"+check, r.URL.Path, "health endpoints are served from the registry root")
+						w.WriteHeader(tt.status)
+						_, _ = w.Write([]byte(`{"status": "UP", "checks": []}`))
+					}))
+					defer server.Close()
+
+					mockClient := &client.Client{BaseURL: server.URL + "/apis/registry/v3", HTTPClient: server.Client()}
+					healthy, err := call(apis.NewSystemAPI(mockClient), context.Background())
+					assert.NoError(t, err)
+					assert.Equal(t, tt.healthy, healthy)
+				})
+			}
+
+			t.Run("Connection Refused", func(t *testing.T) {
+				server := httptest.NewServer(http.NotFoundHandler())
+				baseURL := server.URL
+				server.Close()
+
+				mockClient := &client.Client{BaseURL: baseURL, HTTPClient: http.DefaultClient}
+				healthy, err := call(apis.NewSystemAPI(mockClient), context.Background())
+				assert.Error(t, err)
+				assert.False(t, healthy)
+			})
+
+			t.Run("Not Retried", func(t *testing.T) {
+				attempts := 0
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					attempts++
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}))
+				defer server.Close()
+
+				mockClient := client.NewClient(server.URL, client.WithMaxRetries(3))
+				healthy, err := call(apis.NewSystemAPI(mockClient), context.Background())
+				assert.NoError(t, err)
+				assert.False(t, healthy)
+				assert.Equal(t, 1, attempts)
+			})
+		})
+	}
+}
+
 func TestSystemAPI_GetUIConfig(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockResponse := models.SystemUIConfigResponse{Ui: models.UIConfig{ContextPath: "/"}}
@@ -134,6 +283,239 @@ func TestSystemAPI_GetCurrentUser(t *testing.T) {
 	})
 }
 
+func TestSystemAPI_RateLimited(t *testing.T) {
+	t.Run("Problem Details", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"status": 429, "title": "Too many requests"}`))
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewSystemAPI(mockClient)
+
+		_, err := api.GetSystemInfo(context.Background())
+		var apiErr *models.APIError
+		if assert.ErrorAs(t, err, &apiErr) {
+			assert.Equal(t, http.StatusTooManyRequests, apiErr.Status)
+			assert.Equal(t, 120*time.Second, apiErr.RetryAfter)
+		}
+	})
+
+	t.Run("Gateway Without Problem Details", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte("rate limit exceeded"))
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewSystemAPI(mockClient)
+
+		_, err := api.GetSystemInfo(context.Background())
+		var apiErr *models.APIError
+		if assert.ErrorAs(t, err, &apiErr) {
+			assert.Equal(t, http.StatusTooManyRequests, apiErr.Status)
+			assert.InDelta(t, float64(time.Minute), float64(apiErr.RetryAfter), float64(2*time.Second))
+		}
+	})
+
+	t.Run("Retry-After Beyond Deadline", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"status": 429, "title": "Too many requests"}`))
+		}))
+		defer server.Close()
+
+		api := apis.NewSystemAPI(client.NewClient(server.URL, client.WithMaxRetries(3)))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err := api.GetSystemInfo(ctx)
+		var apiErr *models.APIError
+		if assert.ErrorAs(t, err, &apiErr) {
+			assert.Equal(t, http.StatusTooManyRequests, apiErr.Status)
+			assert.Equal(t, 120*time.Second, apiErr.RetryAfter)
+		}
+		assert.Equal(t, 1, attempts, "a wait longer than the caller's deadline is not started")
+	})
+}
+
+func TestSystemAPI_SLACallback(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /system/info", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"name": "Apicurio Registry"}`))
+	})
+	mux.HandleFunc("GET /users/me", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"username": "jane"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	type breach struct {
+		op  string
+		dur time.Duration
+	}
+	var breaches []breach
+	mockClient := client.NewClient(
+		server.URL,
+		client.WithHTTPClient(server.Client()),
+		client.WithSLACallback(50*time.Millisecond, func(op string, dur time.Duration) {
+			breaches = append(breaches, breach{op: op, dur: dur})
+		}),
+	)
+	api := apis.NewSystemAPI(mockClient)
+
+	info, err := api.GetSystemInfo(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "Apicurio Registry", info.Name)
+
+	_, err = api.GetCurrentUser(context.Background())
+	assert.NoError(t, err)
+
+	if assert.Len(t, breaches, 1) {
+		assert.Equal(t, "GetSystemInfo", breaches[0].op)
+		assert.GreaterOrEqual(t, breaches[0].dur, 100*time.Millisecond)
+	}
+}
+
+func TestSystemAPI_SubscribeEvents(t *testing.T) {
+	t.Run("Two Events", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/events", r.URL.Path)
+			assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(": keep-alive\n\n" +
+				"event: ARTIFACT_CREATED\n" +
+				"data: {\"groupId\":\"test-group\",\"artifactId\":\"orders\",\"version\":\"1\"}\n\n" +
+				"data: {\"type\":\"ARTIFACT_DELETED\",\n" +
+				"data: \"groupId\":\"test-group\",\"artifactId\":\"payments\"}\n\n"))
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewSystemAPI(mockClient)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events, err := api.SubscribeEvents(ctx)
+		assert.NoError(t, err)
+
+		first := <-events
+		assert.Equal(t, models.EventArtifactCreated, first.Type)
+		assert.Equal(t, "orders", first.ArtifactID)
+		assert.Equal(t, "1", first.Version)
+
+		second := <-events
+		assert.Equal(t, models.EventArtifactDeleted, second.Type)
+		assert.Equal(t, "test-group", second.GroupID)
+		assert.Equal(t, "payments", second.ArtifactID)
+
+		cancel()
+		select {
+		case _, ok := <-events:
+			assert.False(t, ok, "the channel is closed once the context is done")
+		case <-time.After(time.Second):
+			t.Fatal("the channel was not closed after cancelling the context")
+		}
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		server := setupMockServer(t, http.StatusNotFound, models.APIError{Status: http.StatusNotFound, Title: TitleNotFound}, "/events", http.MethodGet)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewSystemAPI(mockClient)
+
+		events, err := api.SubscribeEvents(context.Background())
+		assert.Nil(t, events)
+		assert.ErrorIs(t, err, models.ErrEventsUnsupported)
+	})
+
+	t.Run("Not An Event Stream", func(t *testing.T) {
+		server := setupMockServer(t, http.StatusOK, map[string]string{}, "/events", http.MethodGet)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewSystemAPI(mockClient)
+
+		events, err := api.SubscribeEvents(context.Background())
+		assert.Nil(t, events)
+		assert.ErrorIs(t, err, models.ErrEventsUnsupported)
+	})
+}
+
+func TestSystemAPI_EventDrivenCacheInvalidation(t *testing.T) {
+	var fetches atomic.Int32
+	sendEvent := make(chan string)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /ids/globalIds/7", func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		w.Header().Set("X-Registry-ArtifactType", string(models.Avro))
+		w.Header().Set("X-Registry-GroupId", stubGroupId)
+		w.Header().Set("X-Registry-ArtifactId", stubArtifactId)
+		_, _ = w.Write([]byte(stubArtifactContent))
+	})
+	mux.HandleFunc("GET /events", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case event := <-sendEvent:
+				_, _ = w.Write([]byte(event))
+				w.(http.Flusher).Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mockClient := client.NewClient(
+		server.URL,
+		client.WithHTTPClient(server.Client()),
+		client.WithContentCache(10),
+		client.WithEventDrivenCacheInvalidation(),
+	)
+	artifactsAPI := apis.NewArtifactsAPI(mockClient)
+	systemAPI := apis.NewSystemAPI(mockClient)
+
+	for i := 0; i < 2; i++ {
+		_, err := artifactsAPI.GetArtifactByGlobalID(context.Background(), 7, nil)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), fetches.Load(), "the second fetch is served from the cache")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := systemAPI.SubscribeEvents(ctx)
+	assert.NoError(t, err)
+
+	sendEvent <- "event: ARTIFACT_UPDATED\ndata: {\"groupId\":\"test-group\",\"artifactId\":\"other-artifact\"}\n\n"
+	<-events
+	_, err = artifactsAPI.GetArtifactByGlobalID(context.Background(), 7, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), fetches.Load(), "events for other artifacts keep the entry cached")
+
+	sendEvent <- "event: ARTIFACT_UPDATED\ndata: {\"groupId\":\"test-group\",\"artifactId\":\"test-artifact\"}\n\n"
+	<-events
+	_, err = artifactsAPI.GetArtifactByGlobalID(context.Background(), 7, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), fetches.Load(), "the update evicted the cached global ID")
+}
+
 /***********************/
 /***** Integration *****/
 /***********************/
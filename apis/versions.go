@@ -3,14 +3,28 @@ package apis
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/mollie/go-apicurio-registry/client"
+	"github.com/mollie/go-apicurio-registry/internal/plumbing"
 	"github.com/mollie/go-apicurio-registry/models"
 	"github.com/pkg/errors"
 )
 
+// latestVersionExpression resolves to the latest version of an artifact.
+const latestVersionExpression = "branch=latest"
+
+// defaultGroupID is the group of references and versions that do not name one.
+const defaultGroupID = "default"
+
+// versionTimestampLayout formats the labels of versions created with models.VersionTimestamp.
+const versionTimestampLayout = "20060102150405"
+
 type VersionsAPI struct {
 	Client *client.Client
 }
@@ -29,6 +43,8 @@ func (api *VersionsAPI) DeleteArtifactVersion(
 	ctx context.Context,
 	groupID, artifactID, versionExpression string,
 ) error {
+	ctx = client.WithOperationName(ctx, "DeleteArtifactVersion")
+
 	// Validate inputs
 	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
@@ -54,9 +70,6 @@ func (api *VersionsAPI) DeleteArtifactVersion(
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
 
 	return handleResponse(resp, http.StatusNoContent, nil)
 }
@@ -69,6 +82,8 @@ func (api *VersionsAPI) GetArtifactVersionReferences(ctx context.Context,
 	groupId, artifactId, versionExpression string,
 	params *models.ArtifactVersionReferencesParams,
 ) ([]models.ArtifactReference, error) {
+	ctx = client.WithOperationName(ctx, "GetArtifactVersionReferences")
+
 	// Validate inputs
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return nil, err
@@ -112,6 +127,99 @@ func (api *VersionsAPI) GetArtifactVersionReferences(ctx context.Context,
 	return references, nil
 }
 
+// ResolveReferencesRecursive Retrieves the content of a version together with the content of every version it
+// references, following the references of referenced versions as well. A version reached along several paths
+// is fetched once. References that lead back to a version being resolved fail with models.ErrCyclicReference,
+// and a reference name used for two different versions is an error as well.
+func (api *VersionsAPI) ResolveReferencesRecursive(
+	ctx context.Context,
+	groupId, artifactId, versionExpression string,
+) (*models.ResolvedSchema, error) {
+	ctx = client.WithOperationName(ctx, "ResolveReferencesRecursive")
+
+	root, err := api.GetArtifactVersionContent(ctx, groupId, artifactId, versionExpression, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	start := models.ArtifactReference{GroupID: groupId, ArtifactID: artifactId, Version: versionExpression}
+	r := referenceResolver{
+		api:      api,
+		resolved: &models.ResolvedSchema{Content: root.Content, ArtifactType: root.ArtifactType, References: map[string]string{}},
+		names:    make(map[string]models.ArtifactReference),
+		contents: map[models.ArtifactReference]string{start: root.Content},
+		walking:  make(map[models.ArtifactReference]bool),
+	}
+	if err := r.walk(ctx, start, []string{referenceLabel(start)}); err != nil {
+		return nil, err
+	}
+	return r.resolved, nil
+}
+
+// referenceResolver walks the reference graph of ResolveReferencesRecursive depth first.
+type referenceResolver struct {
+	api      *VersionsAPI
+	resolved *models.ResolvedSchema
+	names    map[string]models.ArtifactReference // Version each reference name resolved to
+	contents map[models.ArtifactReference]string // Content of the versions fetched so far
+	walking  map[models.ArtifactReference]bool   // Versions whose references are being walked
+}
+
+// walk resolves the references of version and, recursively, of the versions they point to. path lists the
+// versions from the root to version, to report cycles.
+func (r *referenceResolver) walk(ctx context.Context, version models.ArtifactReference, path []string) error {
+	r.walking[version] = true
+	defer delete(r.walking, version)
+
+	references, err := r.api.GetArtifactVersionReferences(ctx, version.GroupID, version.ArtifactID, version.Version,
+		&models.ArtifactVersionReferencesParams{RefType: models.OutBound})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list references of %s", referenceLabel(version))
+	}
+
+	for _, ref := range references {
+		target := models.ArtifactReference{GroupID: ref.GroupID, ArtifactID: ref.ArtifactID, Version: ref.Version}
+		if target.GroupID == "" {
+			target.GroupID = defaultGroupID
+		}
+		if target.Version == "" {
+			target.Version = latestVersionExpression
+		}
+
+		if previous, found := r.names[ref.Name]; found && previous != target {
+			return errors.Errorf("reference name %q refers to both %s and %s",
+				ref.Name, referenceLabel(previous), referenceLabel(target))
+		}
+		r.names[ref.Name] = target
+
+		if r.walking[target] {
+			return errors.Wrapf(models.ErrCyclicReference, "%s -> %s",
+				strings.Join(path, " -> "), referenceLabel(target))
+		}
+		if content, fetched := r.contents[target]; fetched {
+			r.resolved.References[ref.Name] = content
+			continue
+		}
+
+		content, err := r.api.GetArtifactVersionContent(ctx, target.GroupID, target.ArtifactID, target.Version, nil)
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch reference %q to %s", ref.Name, referenceLabel(target))
+		}
+		r.contents[target] = content.Content
+		r.resolved.References[ref.Name] = content.Content
+
+		if err := r.walk(ctx, target, append(path, referenceLabel(target))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// referenceLabel formats the coordinates of a version as group/artifact@version.
+func referenceLabel(ref models.ArtifactReference) string {
+	return ref.GroupID + "/" + ref.ArtifactID + "@" + ref.Version
+}
+
 // GetArtifactVersionComments Retrieves all comments for a version of an artifact.
 // Both the artifactId and the unique version number must be provided.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Versions/operation/getArtifactVersionComments
@@ -119,6 +227,8 @@ func (api *VersionsAPI) GetArtifactVersionComments(
 	ctx context.Context,
 	groupId, artifactId, versionExpression string,
 ) (*[]models.ArtifactComment, error) {
+	ctx = client.WithOperationName(ctx, "GetArtifactVersionComments")
+
 	// Validate inputs
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return nil, err
@@ -162,6 +272,8 @@ func (api *VersionsAPI) AddArtifactVersionComment(
 	groupId, artifactId, versionExpression string,
 	commentValue string,
 ) (*models.ArtifactComment, error) {
+	ctx = client.WithOperationName(ctx, "AddArtifactVersionComment")
+
 	// Validate inputs
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return nil, err
@@ -211,6 +323,8 @@ func (api *VersionsAPI) UpdateArtifactVersionComment(
 	groupId, artifactId, versionExpression, commentId string,
 	updatedComment string,
 ) error {
+	ctx = client.WithOperationName(ctx, "UpdateArtifactVersionComment")
+
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
 	}
@@ -257,6 +371,8 @@ func (api *VersionsAPI) DeleteArtifactVersionComment(
 	ctx context.Context,
 	groupId, artifactId, versionExpression, commentId string,
 ) error {
+	ctx = client.WithOperationName(ctx, "DeleteArtifactVersionComment")
+
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
 	}
@@ -297,6 +413,37 @@ func (api *VersionsAPI) ListArtifactVersions(
 	groupId, artifactId string,
 	params *models.ListArtifactsVersionsParams,
 ) ([]models.ArtifactVersion, error) {
+	result, err := api.listArtifactVersions(ctx, groupId, artifactId, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Versions, nil
+}
+
+// ListArtifactVersionsPage Returns a page of versions of the artifact like ListArtifactVersions, together
+// with the total number of versions.
+func (api *VersionsAPI) ListArtifactVersionsPage(
+	ctx context.Context,
+	groupId, artifactId string,
+	params *models.ListArtifactsVersionsParams,
+) (*models.Page[models.ArtifactVersion], error) {
+	result, err := api.listArtifactVersions(ctx, groupId, artifactId, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Page[models.ArtifactVersion]{Items: result.Versions, Count: result.Count}, nil
+}
+
+// listArtifactVersions returns a page of versions together with the total number of versions.
+func (api *VersionsAPI) listArtifactVersions(
+	ctx context.Context,
+	groupId, artifactId string,
+	params *models.ListArtifactsVersionsParams,
+) (*models.ArtifactVersionListResponse, error) {
+	ctx = client.WithOperationName(ctx, "ListArtifactVersions")
+
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return nil, err
 	}
@@ -329,13 +476,177 @@ func (api *VersionsAPI) ListArtifactVersions(
 		return nil, err
 	}
 
-	return versionsResponse.Versions, nil
+	return &versionsResponse, nil
+}
+
+// AllArtifactVersions Pages through ListArtifactVersions and returns every version of the artifact.
+// When the client's MaxElapsedTime budget runs out, the versions fetched so far are returned with models.ErrBudgetExceeded.
+// When the artifact has more versions than the client's MaxItemsFetched, the first MaxItemsFetched versions are
+// returned with models.ErrTruncated.
+func (api *VersionsAPI) AllArtifactVersions(
+	ctx context.Context,
+	groupId, artifactId string,
+) ([]models.ArtifactVersion, error) {
+	ctx = client.WithOperationName(ctx, "AllArtifactVersions")
+
+	budgetCtx, cancel := withBudget(ctx, api.Client)
+	defer cancel()
+
+	maxItems := api.Client.MaxItemsFetched
+	var versions []models.ArtifactVersion
+	for {
+		limit := pageSize
+		if maxItems > 0 {
+			// Fetch one item past the cap so a history of exactly maxItems versions is not reported as truncated
+			limit = min(limit, maxItems-len(versions)+1)
+		}
+
+		page, err := api.ListArtifactVersions(budgetCtx, groupId, artifactId, &models.ListArtifactsVersionsParams{
+			Offset: len(versions),
+			Limit:  limit,
+		})
+		if err != nil {
+			return versions, budgetError(ctx, budgetCtx, err)
+		}
+
+		versions = append(versions, page...)
+		if maxItems > 0 && len(versions) > maxItems {
+			return versions[:maxItems], errors.Wrapf(models.ErrTruncated, "stopped after %d versions", maxItems)
+		}
+		if len(page) < limit {
+			return versions, nil
+		}
+	}
+}
+
+// ListArtifactVersionsAll Returns an iterator over every version of the artifact, fetching pages of
+// ListArtifactVersions as it advances. Iteration starts at params.Offset, params.Limit sets the page size
+// (100 when zero) and versions are returned in the order set by params.OrderBy and params.Order. Pages are
+// fetched with the context passed to Next.
+func (api *VersionsAPI) ListArtifactVersionsAll(
+	ctx context.Context,
+	groupId, artifactId string,
+	params *models.ListArtifactsVersionsParams,
+) *Iterator[models.ArtifactVersion] {
+	var p models.ListArtifactsVersionsParams
+	if params != nil {
+		p = *params
+	}
+	if p.Limit == 0 {
+		p.Limit = pageSize
+	}
+	it := newIterator(p.Offset, func(ctx context.Context, offset int) ([]models.ArtifactVersion, int, error) {
+		p.Offset = offset
+		result, err := api.listArtifactVersions(ctx, groupId, artifactId, &p)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Versions, result.Count, nil
+	})
+	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
+		it.err = err
+	} else if err := validateInput(artifactId, regexGroupIDArtifactID, "Artifact ID"); err != nil {
+		it.err = err
+	} else if err := p.Validate(); err != nil {
+		it.err = errors.Wrap(err, "invalid parameters provided")
+	}
+	return it
+}
+
+// ListAllComments Retrieves the comments of every version of the artifact, keyed by version.
+// Versions without comments map to an empty slice. Comments are fetched at most Client.MaxConcurrency versions
+// at a time and the first failure stops the listing; when the client's MaxElapsedTime budget runs out, the
+// comments fetched so far are returned with models.ErrBudgetExceeded.
+func (api *VersionsAPI) ListAllComments(
+	ctx context.Context,
+	groupID, artifactID string,
+) (map[string][]models.ArtifactComment, error) {
+	ctx = client.WithOperationName(ctx, "ListAllComments")
+
+	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
+		return nil, err
+	}
+	if err := validateInput(artifactID, regexGroupIDArtifactID, "Artifact ID"); err != nil {
+		return nil, err
+	}
+
+	budgetCtx, cancel := withBudget(ctx, api.Client)
+	defer cancel()
+
+	versions, err := api.AllArtifactVersions(budgetCtx, groupID, artifactID)
+	if err != nil {
+		return nil, errors.Wrapf(budgetError(ctx, budgetCtx, err), "failed to list versions of %s", artifactID)
+	}
+
+	var mu sync.Mutex
+	results := make(map[string][]models.ArtifactComment, len(versions))
+	err = forEach(budgetCtx, maxConcurrency(api.Client), len(versions), func(ctx context.Context, i int) error {
+		version := versions[i].Version
+		comments, err := api.GetArtifactVersionComments(ctx, groupID, artifactID, version)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get comments of version %s", version)
+		}
+
+		mu.Lock()
+		results[version] = *comments
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return results, budgetError(ctx, budgetCtx, err)
+	}
+
+	return results, nil
+}
+
+// EffectiveLatestState Returns the state and version of the newest version a consumer sees by default.
+// Versions are walked from newest to oldest, skipping DISABLED and DRAFT ones, so the result is ENABLED
+// or DEPRECATED. When no version qualifies, models.ErrNoEnabledVersion is returned.
+func (api *VersionsAPI) EffectiveLatestState(
+	ctx context.Context,
+	groupID, artifactID string,
+) (models.State, string, error) {
+	ctx = client.WithOperationName(ctx, "EffectiveLatestState")
+
+	budgetCtx, cancel := withBudget(ctx, api.Client)
+	defer cancel()
+
+	for offset := 0; ; offset += pageSize {
+		page, err := api.ListArtifactVersions(budgetCtx, groupID, artifactID, &models.ListArtifactsVersionsParams{
+			Offset:  offset,
+			Limit:   pageSize,
+			Order:   models.OrderDesc,
+			OrderBy: models.VersionSortByCreatedOn,
+		})
+		if err != nil {
+			return "", "", budgetError(ctx, budgetCtx, err)
+		}
+
+		for _, version := range page {
+			switch version.State {
+			case models.StateDisabled, models.StateDraft:
+				continue
+			case "":
+				return models.StateEnabled, version.Version, nil
+			default:
+				return version.State, version.Version, nil
+			}
+		}
 
+		if len(page) < pageSize {
+			return "", "", errors.Wrapf(models.ErrNoEnabledVersion, "%s/%s", groupID, artifactID)
+		}
+	}
 }
 
 // CreateArtifactVersion Creates a new version of the artifact by uploading new content.
 // The configured rules for the artifact are applied, and if they all pass, the new content is added as the most recent version of the artifact.
 // If any of the rules fail, an error is returned.
+// With client.WithSerializeWritesPerArtifact, concurrent creations for the same artifact are sent one at a time.
+// With request.ValidateLocally, the content is checked according to request.ArtifactType before it is sent;
+// as in ArtifactsAPI.CreateArtifact, content without an artifact type is left to the registry.
+// A request without a version is labeled according to client.WithVersionStrategy, except in a dry run, which
+// leaves the version to the registry rather than looking up the versions of the artifact.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Versions/operation/createArtifactVersion
 func (api *VersionsAPI) CreateArtifactVersion(
 	ctx context.Context,
@@ -343,12 +654,30 @@ func (api *VersionsAPI) CreateArtifactVersion(
 	request *models.CreateVersionRequest,
 	dryRun bool,
 ) (*models.ArtifactVersionDetailed, error) {
+	ctx = client.WithOperationName(ctx, "CreateArtifactVersion")
+
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return nil, err
 	}
 	if err := validateInput(artifactId, regexGroupIDArtifactID, "Artifact ID"); err != nil {
 		return nil, err
 	}
+	if request != nil {
+		if err := request.ResolveDraft(); err != nil {
+			return nil, errors.Wrap(err, "invalid version provided")
+		}
+		if request.ValidateLocally {
+			if err := models.ValidateContent(request.ArtifactType, []byte(request.Content.Content)); err != nil {
+				return nil, errors.Wrap(err, "invalid version provided")
+			}
+		}
+		minified := plumbing.Of(api.Client).MinifyForWrite(request.Content.ContentType, request.Content.Content)
+		if minified != request.Content.Content {
+			sent := *request
+			sent.Content.Content = minified
+			request = &sent
+		}
+	}
 
 	urlPath := fmt.Sprintf(
 		"%s/groups/%s/artifacts/%s/versions",
@@ -358,6 +687,26 @@ func (api *VersionsAPI) CreateArtifactVersion(
 	)
 	if dryRun {
 		urlPath = fmt.Sprintf("%s?dryRun=true", urlPath)
+		ctx = client.WithRetrySafe(ctx)
+	} else {
+		unlock, err := plumbing.Of(api.Client).LockArtifact(ctx, groupId, artifactId)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+
+	if request != nil && request.Version == "" && !dryRun {
+		// Labeled after taking the artifact lock, so serialized writes bump from the version created last.
+		label, err := api.nextVersion(ctx, groupId, artifactId)
+		if err != nil {
+			return nil, err
+		}
+		if label != "" {
+			labeled := *request
+			labeled.Version = label
+			request = &labeled
+		}
 	}
 
 	resp, err := api.executeRequest(ctx, http.MethodPost, urlPath, request)
@@ -374,16 +723,33 @@ func (api *VersionsAPI) CreateArtifactVersion(
 
 }
 
+// nextVersion returns the label of a version created without an explicit version, following the client's
+// VersionStrategy. It is empty when the registry assigns the version.
+func (api *VersionsAPI) nextVersion(ctx context.Context, groupId, artifactId string) (string, error) {
+	switch api.Client.VersionStrategy {
+	case "", models.VersionAuto:
+		return "", nil
+	case models.VersionTimestamp:
+		return time.Now().UTC().Format(versionTimestampLayout), nil
+	case models.VersionSemverBump:
+		return api.NextSemver(ctx, groupId, artifactId, models.SemverPatch)
+	}
+	return "", errors.Errorf("unknown version strategy %q", api.Client.VersionStrategy)
+}
+
 // GetArtifactVersionContent Retrieves a single version of the artifact content.
 // Both the artifactId and the unique version number must be provided.
 // The Content-Type of the response depends on the artifact type.
 // In most cases, this is application/json, but for some types it may be different (for example, PROTOBUF).
+// The returned ETag can be passed to client.WithIfMatch for a later update.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Versions/operation/getArtifactVersionContent
 func (api *VersionsAPI) GetArtifactVersionContent(
 	ctx context.Context,
 	groupId, artifactId, versionExpression string,
 	params *models.ArtifactReferenceParams,
 ) (*models.ArtifactContent, error) {
+	ctx = client.WithOperationName(ctx, "GetArtifactVersionContent")
+
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return nil, err
 	}
@@ -394,11 +760,13 @@ func (api *VersionsAPI) GetArtifactVersionContent(
 		return nil, err
 	}
 
+	var expectedType models.ArtifactType
 	query := ""
 	if params != nil {
 		if err := params.Validate(); err != nil {
 			return nil, errors.Wrap(err, "invalid parameters provided")
 		}
+		expectedType = params.ExpectedArtifactType
 		query = "?" + params.ToQuery().Encode()
 	}
 	urlPath := fmt.Sprintf(
@@ -420,18 +788,329 @@ func (api *VersionsAPI) GetArtifactVersionContent(
 		return nil, err
 	}
 
+	if err := checkArtifactType(resp, expectedType); err != nil {
+		return nil, err
+	}
+
+	artifactType := reportedArtifactType(resp)
+	if err := plumbing.Of(api.Client).ValidateRetrievedContent(artifactType, content); err != nil {
+		return nil, err
+	}
+
 	return &models.ArtifactContent{
-		Content: content,
+		Content:      content,
+		ArtifactType: artifactType,
+		Version:      resolvedVersion(resp, versionExpression),
+		ETag:         resp.Header.Get("ETag"),
 	}, nil
 }
 
+// GetArtifactContent Retrieves the content of the artifact version chosen by the selector.
+// A selector with a Branch fetches the version currently at the tip of that branch in a single request,
+// without resolving the branch first. An empty selector fetches the tip of the client's default branch
+// (see client.WithDefaultBranch), or of "latest" when none is set.
+func (api *VersionsAPI) GetArtifactContent(
+	ctx context.Context,
+	groupId, artifactId string,
+	selector models.ContentSelector,
+	params *models.ArtifactReferenceParams,
+) (*models.ArtifactContent, error) {
+	ctx = client.WithOperationName(ctx, "GetArtifactContent")
+
+	if err := selector.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid content selector provided")
+	}
+	if selector.Version == "" && selector.Branch == "" {
+		selector.Branch = api.Client.DefaultBranch
+	}
+	if selector.Branch != "" {
+		if err := validateInput(selector.Branch, regexBranchID, "Branch ID"); err != nil {
+			return nil, err
+		}
+	}
+
+	return api.GetArtifactVersionContent(ctx, groupId, artifactId, selector.Expression(), params)
+}
+
+// GetArtifactVersionContentRange Retrieves the bytes start through end (inclusive) of a version's content
+// with an HTTP Range request, for reading part of a very large artifact. When the registry ignores the
+// range and answers with the full content, models.ErrRangeNotSupported is returned.
+func (api *VersionsAPI) GetArtifactVersionContentRange(
+	ctx context.Context,
+	groupID, artifactID, version string,
+	start, end int64,
+) ([]byte, error) {
+	ctx = client.WithOperationName(ctx, "GetArtifactVersionContentRange")
+
+	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
+		return nil, err
+	}
+	if err := validateInput(artifactID, regexGroupIDArtifactID, "Artifact ID"); err != nil {
+		return nil, err
+	}
+	if err := validateInput(version, regexVersion, "Version Expression"); err != nil {
+		return nil, err
+	}
+	byteRange := fmt.Sprintf("bytes=%d-%d", start, end)
+	if start < 0 || end < start {
+		return nil, &models.FieldValidationError{
+			Field:  "Range",
+			Value:  byteRange,
+			Reason: "start must be non-negative and not after end",
+		}
+	}
+
+	urlPath := fmt.Sprintf(
+		"%s/groups/%s/artifacts/%s/versions/%s/content",
+		api.Client.BaseURL,
+		url.PathEscape(groupID),
+		url.PathEscape(artifactID),
+		url.PathEscape(version),
+	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlPath, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create HTTP request")
+	}
+	req.Header.Set("Range", byteRange)
+	// Ranges of a compressed response would address the compressed bytes.
+	req.Header.Set("Accept-Encoding", "identity")
+
+	resp, err := api.Client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute HTTP request")
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		plumbing.DrainBody(resp)
+		return nil, models.ErrRangeNotSupported
+	}
+	content, err := handleRawResponse(resp, http.StatusPartialContent)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(content), nil
+}
+
+// GetArtifactVersionContentStream Retrieves a single version of the artifact content without buffering it.
+// It is the streaming counterpart of GetArtifactVersionContent for multi-megabyte content, e.g. to copy it to disk.
+// The artifact type is taken from the X-Registry-ArtifactType header and is empty when the registry omits it.
+// The caller must close the returned reader.
+// See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Versions/operation/getArtifactVersionContent
+func (api *VersionsAPI) GetArtifactVersionContentStream(
+	ctx context.Context,
+	groupId, artifactId, versionExpression string,
+	params *models.ArtifactReferenceParams,
+) (io.ReadCloser, models.ArtifactType, error) {
+	ctx = client.WithOperationName(ctx, "GetArtifactVersionContentStream")
+
+	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
+		return nil, "", err
+	}
+	if err := validateInput(artifactId, regexGroupIDArtifactID, "Artifact ID"); err != nil {
+		return nil, "", err
+	}
+	if err := validateInput(versionExpression, regexVersion, "Version Expression"); err != nil {
+		return nil, "", err
+	}
+
+	var expectedType models.ArtifactType
+	query := ""
+	if params != nil {
+		if err := params.Validate(); err != nil {
+			return nil, "", errors.Wrap(err, "invalid parameters provided")
+		}
+		expectedType = params.ExpectedArtifactType
+		query = "?" + params.ToQuery().Encode()
+	}
+	urlPath := fmt.Sprintf(
+		"%s/groups/%s/artifacts/%s/versions/%s/content%s",
+		api.Client.BaseURL,
+		url.PathEscape(groupId),
+		url.PathEscape(artifactId),
+		url.PathEscape(versionExpression),
+		query,
+	)
+
+	resp, err := api.executeRequest(ctx, http.MethodGet, urlPath, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", handleResponse(resp, http.StatusOK, nil)
+	}
+
+	if err := checkArtifactType(resp, expectedType); err != nil {
+		plumbing.DrainBody(resp)
+		return nil, "", err
+	}
+
+	return streamBody(ctx, resp.Body), reportedArtifactType(resp), nil
+}
+
+// GetLatestContent Retrieves the content of the latest version of the artifact.
+// The returned ArtifactContent carries the concrete version that "latest" resolved to,
+// so callers can pin subsequent fetches to exactly that version.
+func (api *VersionsAPI) GetLatestContent(
+	ctx context.Context,
+	groupId, artifactId string,
+	params *models.ArtifactReferenceParams,
+) (*models.ArtifactContent, error) {
+	ctx = client.WithOperationName(ctx, "GetLatestContent")
+
+	return api.GetArtifactVersionContent(ctx, groupId, artifactId, latestVersionExpression, params)
+}
+
+// GetVersion Retrieves the content and the metadata of a single artifact version.
+// Both are fetched concurrently, so the call costs a single round trip. For a version expression
+// such as "branch=latest", a version created between the two requests can make them resolve to
+// different versions; pass a concrete version when that matters.
+func (api *VersionsAPI) GetVersion(
+	ctx context.Context,
+	groupId, artifactId, versionExpression string,
+	params *models.ArtifactReferenceParams,
+) (*models.ArtifactVersionFull, error) {
+	ctx = client.WithOperationName(ctx, "GetVersion")
+
+	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
+		return nil, err
+	}
+	if err := validateInput(artifactId, regexGroupIDArtifactID, "Artifact ID"); err != nil {
+		return nil, err
+	}
+	if err := validateInput(versionExpression, regexVersion, "Version Expression"); err != nil {
+		return nil, err
+	}
+
+	var (
+		content  *models.ArtifactContent
+		metadata *models.ArtifactVersionMetadata
+	)
+	err := forEach(ctx, 2, 2, func(ctx context.Context, i int) error {
+		var err error
+		if i == 0 {
+			content, err = api.GetArtifactVersionContent(ctx, groupId, artifactId, versionExpression, params)
+		} else {
+			metadata, err = NewMetadataAPI(api.Client).GetArtifactVersionMetadata(ctx, groupId, artifactId, versionExpression)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	artifactType := content.ArtifactType
+	if artifactType == "" {
+		artifactType = models.ArtifactType(metadata.ArtifactType)
+	}
+
+	return &models.ArtifactVersionFull{
+		ArtifactVersionMetadata: *metadata,
+		ArtifactType:            artifactType,
+		Content:                 content.Content,
+	}, nil
+}
+
+// ResolveVersion Returns the concrete version a version expression such as "branch=staging" currently points to.
+// The bare expression "latest" is accepted as a shorthand for "branch=latest".
+// Useful to pin subsequent calls to a version or to record exactly which version was used.
+func (api *VersionsAPI) ResolveVersion(
+	ctx context.Context,
+	groupID, artifactID, versionExpression string,
+) (string, error) {
+	ctx = client.WithOperationName(ctx, "ResolveVersion")
+
+	if versionExpression == models.BranchLatest {
+		versionExpression = latestVersionExpression
+	}
+
+	metadata, err := NewMetadataAPI(api.Client).GetArtifactVersionMetadata(ctx, groupID, artifactID, versionExpression)
+	if err != nil {
+		return "", err
+	}
+	return metadata.Version, nil
+}
+
+// NextSemver Returns the version that follows the latest version of the artifact for the given bump, e.g.
+// "1.5.0" for a minor bump of "1.4.2". It fails with models.ErrNotSemver when the latest version is not a
+// semantic version. The result is only a proposal: a version created concurrently can take it first.
+func (api *VersionsAPI) NextSemver(
+	ctx context.Context,
+	groupID, artifactID string,
+	bump models.SemverBump,
+) (string, error) {
+	ctx = client.WithOperationName(ctx, "NextSemver")
+
+	latest, err := api.ResolveVersion(ctx, groupID, artifactID, latestVersionExpression)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to look up the latest version")
+	}
+	semver, err := models.ParseSemver(latest)
+	if err != nil {
+		return "", errors.Wrapf(err, "latest version of artifact %s/%s cannot be bumped", groupID, artifactID)
+	}
+	next, err := semver.Bump(bump)
+	if err != nil {
+		return "", err
+	}
+	return next.String(), nil
+}
+
+// CompareWithLatest reports whether content is identical to the content of the latest version of the artifact.
+// The comparison uses the registry's content hashing; with canonical set, content is canonicalized
+// before hashing so formatting-only differences are ignored. Useful to avoid publishing no-op versions.
+func (api *VersionsAPI) CompareWithLatest(
+	ctx context.Context,
+	groupID, artifactID string,
+	content []byte,
+	canonical bool,
+) (bool, error) {
+	ctx = client.WithOperationName(ctx, "CompareWithLatest")
+
+	if err := validateInput(groupID, regexGroupIDArtifactID, "Group ID"); err != nil {
+		return false, err
+	}
+	if err := validateInput(artifactID, regexGroupIDArtifactID, "Artifact ID"); err != nil {
+		return false, err
+	}
+
+	latest, err := NewMetadataAPI(api.Client).GetArtifactVersionMetadata(ctx, groupID, artifactID, latestVersionExpression)
+	if err != nil {
+		return false, err
+	}
+
+	params := &models.SearchVersionByContentParams{
+		Canonical:  &canonical,
+		GroupID:    groupID,
+		ArtifactID: artifactID,
+		Order:      models.OrderDesc,
+		OrderBy:    models.OrderByCreatedOn,
+	}
+	matches, err := api.SearchForArtifactVersionByContent(ctx, string(content), params)
+	if err != nil {
+		return false, err
+	}
+
+	for _, version := range matches {
+		if version.GlobalID == latest.GlobalID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // UpdateArtifactVersionContent Updates the content of a single version of an artifact.
+// Use client.WithIfMatch on ctx for optimistic concurrency; a concurrent modification then fails with models.ErrPreconditionFailed.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Versions/operation/updateArtifactVersionContent
 func (api *VersionsAPI) UpdateArtifactVersionContent(
 	ctx context.Context,
 	groupId, artifactId, versionExpression string,
 	content *models.CreateContentRequest,
 ) error {
+	ctx = client.WithOperationName(ctx, "UpdateArtifactVersionContent")
+
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
 	}
@@ -445,6 +1124,8 @@ func (api *VersionsAPI) UpdateArtifactVersionContent(
 	if err := content.Validate(); err != nil {
 		return errors.Wrap(err, "invalid content provided")
 	}
+	sent := *content
+	sent.Content = plumbing.Of(api.Client).MinifyForWrite(content.ContentType, content.Content)
 
 	urlPath := fmt.Sprintf(
 		"%s/groups/%s/artifacts/%s/versions/%s/content",
@@ -454,7 +1135,7 @@ func (api *VersionsAPI) UpdateArtifactVersionContent(
 		url.PathEscape(versionExpression),
 	)
 
-	resp, err := api.executeRequest(ctx, http.MethodPut, urlPath, content)
+	resp, err := api.executeRequest(ctx, http.MethodPut, urlPath, &sent)
 	if err != nil {
 		return err
 	}
@@ -468,18 +1149,17 @@ func (api *VersionsAPI) SearchForArtifactVersions(
 	ctx context.Context,
 	params *models.SearchVersionParams,
 ) ([]models.ArtifactVersion, error) {
+	ctx = client.WithOperationName(ctx, "SearchForArtifactVersions")
 
-	query := ""
+	query := url.Values{}
 	if params != nil {
 		if err := params.Validate(); err != nil {
 			return nil, errors.Wrap(err, "invalid parameters provided")
 		}
-		query = params.ToQuery().Encode()
+		query = params.ToQuery()
 	}
 
-	urlPath := fmt.Sprintf("%s/search/versions?%s", api.Client.BaseURL, query)
-
-	resp, err := api.executeRequest(ctx, http.MethodGet, urlPath, nil)
+	resp, err := executeSearchRequest(ctx, api.Client, "/search/versions", query)
 	if err != nil {
 		return nil, err
 	}
@@ -493,23 +1173,37 @@ func (api *VersionsAPI) SearchForArtifactVersions(
 }
 
 // SearchForArtifactVersionByContent Returns a paginated list of all versions that match the posted content.
+// The content is posted unmodified, with the content type of the artifact type filter when one is set.
+// Setting GroupID and ArtifactID in params answers whether the exact content already exists as a version of
+// that artifact; versions outside the scope are dropped even if the registry returns them.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Versions/operation/searchVersionsByContent
 func (api *VersionsAPI) SearchForArtifactVersionByContent(
 	ctx context.Context,
 	content string,
 	params *models.SearchVersionByContentParams,
 ) ([]models.ArtifactVersion, error) {
+	ctx = client.WithRetrySafe(client.WithOperationName(ctx, "SearchForArtifactVersionByContent"))
+
 	query := ""
+	var artifactType models.ArtifactType
 	if params != nil {
 		if err := params.Validate(); err != nil {
 			return nil, errors.Wrap(err, "invalid parameters provided")
 		}
+		artifactType = params.ArtifactType
 		query = params.ToQuery().Encode()
 	}
 
 	urlPath := fmt.Sprintf("%s/search/versions?%s", api.Client.BaseURL, query)
 
-	resp, err := api.executeRequest(ctx, http.MethodPost, urlPath, content)
+	resp, err := executeRequestRaw(
+		ctx,
+		api.Client,
+		http.MethodPost,
+		urlPath,
+		strings.NewReader(content),
+		contentTypeFor(api.Client, artifactType),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -519,15 +1213,41 @@ func (api *VersionsAPI) SearchForArtifactVersionByContent(
 		return nil, err
 	}
 
+	if params != nil && (params.GroupID != "" || params.ArtifactID != "") {
+		return inScope(searchVersionsResponse.Versions, params.GroupID, params.ArtifactID), nil
+	}
 	return searchVersionsResponse.Versions, nil
 }
 
+// inScope drops the versions that report a group or artifact other than the given scope. An empty scope
+// matches any value. A version that omits its group, as the registry does for the default group, only
+// matches the default group; a version that omits its artifact matches no artifact scope.
+func inScope(versions []models.ArtifactVersion, groupID, artifactID string) []models.ArtifactVersion {
+	scoped := make([]models.ArtifactVersion, 0, len(versions))
+	for _, version := range versions {
+		versionGroupID := version.GroupID
+		if versionGroupID == "" {
+			versionGroupID = defaultGroupID
+		}
+		if groupID != "" && versionGroupID != groupID {
+			continue
+		}
+		if artifactID != "" && version.ArtifactID != artifactID {
+			continue
+		}
+		scoped = append(scoped, version)
+	}
+	return scoped
+}
+
 // GetArtifactVersionState Gets the current state of an artifact version.
 // See https://www.apicur.io/registry/docs/apicurio-registry/3.0.x/assets-attachments/registry-rest-api.htm#tag/Versions/operation/getArtifactVersionState
 func (api *VersionsAPI) GetArtifactVersionState(
 	ctx context.Context,
 	groupId, artifactId, versionExpression string,
 ) (*models.State, error) {
+	ctx = client.WithOperationName(ctx, "GetArtifactVersionState")
+
 	// Validate inputs
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return nil, err
@@ -577,6 +1297,8 @@ func (api *VersionsAPI) UpdateArtifactVersionState(
 	state models.State,
 	dryRun bool,
 ) error {
+	ctx = client.WithOperationName(ctx, "UpdateArtifactVersionState")
+
 	// Validate inputs
 	if err := validateInput(groupId, regexGroupIDArtifactID, "Group ID"); err != nil {
 		return err
@@ -619,6 +1341,88 @@ func (api *VersionsAPI) UpdateArtifactVersionState(
 	return nil
 }
 
+// CreateDraft Creates a new version of the artifact in DRAFT state, the first step of the draft workflow.
+// Draft versions can be edited with UpdateDraftContent and commented on with AddDraftComment until PublishDraft
+// enables them. A request State other than DRAFT fails with models.ErrDraftStateConflict.
+func (api *VersionsAPI) CreateDraft(
+	ctx context.Context,
+	groupId, artifactId string,
+	request *models.CreateVersionRequest,
+) (*models.ArtifactVersionDetailed, error) {
+	ctx = client.WithOperationName(ctx, "CreateDraft")
+
+	if request == nil {
+		return nil, errors.New("invalid version provided: request is nil")
+	}
+	draft := *request
+	if draft.State == "" {
+		draft.State = models.StateDraft
+	}
+	if draft.State != models.StateDraft {
+		return nil, errors.Wrapf(models.ErrDraftStateConflict, "draft created with state %s", draft.State)
+	}
+
+	return api.CreateArtifactVersion(ctx, groupId, artifactId, &draft, false)
+}
+
+// UpdateDraftContent Replaces the content of a version in DRAFT state.
+// A version in any other state is left unchanged and models.ErrNotDraft is returned.
+func (api *VersionsAPI) UpdateDraftContent(
+	ctx context.Context,
+	groupId, artifactId, version string,
+	content *models.CreateContentRequest,
+) error {
+	ctx = client.WithOperationName(ctx, "UpdateDraftContent")
+
+	if err := api.requireDraft(ctx, groupId, artifactId, version); err != nil {
+		return err
+	}
+	return api.UpdateArtifactVersionContent(ctx, groupId, artifactId, version, content)
+}
+
+// AddDraftComment Adds a review comment to a version in DRAFT state.
+// A version in any other state is left unchanged and models.ErrNotDraft is returned.
+func (api *VersionsAPI) AddDraftComment(
+	ctx context.Context,
+	groupId, artifactId, version string,
+	comment string,
+) (*models.ArtifactComment, error) {
+	ctx = client.WithOperationName(ctx, "AddDraftComment")
+
+	if err := api.requireDraft(ctx, groupId, artifactId, version); err != nil {
+		return nil, err
+	}
+	return api.AddArtifactVersionComment(ctx, groupId, artifactId, version, comment)
+}
+
+// PublishDraft Finalizes a version in DRAFT state by transitioning it to ENABLED. The configured content rules
+// are applied at this point and may reject the transition. With dryRun the transition is only checked.
+// A version in any other state is left unchanged and models.ErrNotDraft is returned.
+func (api *VersionsAPI) PublishDraft(
+	ctx context.Context,
+	groupId, artifactId, version string,
+	dryRun bool,
+) error {
+	ctx = client.WithOperationName(ctx, "PublishDraft")
+
+	if err := api.requireDraft(ctx, groupId, artifactId, version); err != nil {
+		return err
+	}
+	return api.UpdateArtifactVersionState(ctx, groupId, artifactId, version, models.StateEnabled, dryRun)
+}
+
+// requireDraft returns models.ErrNotDraft unless the version is in DRAFT state.
+func (api *VersionsAPI) requireDraft(ctx context.Context, groupId, artifactId, version string) error {
+	state, err := api.GetArtifactVersionState(ctx, groupId, artifactId, version)
+	if err != nil {
+		return err
+	}
+	if *state != models.StateDraft {
+		return errors.Wrapf(models.ErrNotDraft, "version %s of %s is %s", version, artifactId, *state)
+	}
+	return nil
+}
+
 // executeRequest handles the creation and execution of an HTTP request.
 func (api *VersionsAPI) executeRequest(
 	ctx context.Context,
@@ -627,3 +1431,15 @@ func (api *VersionsAPI) executeRequest(
 ) (*http.Response, error) {
 	return executeRequest(ctx, api.Client, method, url, body)
 }
+
+// resolvedVersion returns the concrete version reported by the registry in the X-Registry-Version header.
+// When the header is absent, the version expression is returned if it already names a concrete version.
+func resolvedVersion(resp *http.Response, versionExpression string) string {
+	if version := resp.Header.Get("X-Registry-Version"); version != "" {
+		return version
+	}
+	if strings.HasPrefix(versionExpression, "branch=") {
+		return ""
+	}
+	return versionExpression
+}
@@ -2,10 +2,15 @@ package apis_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -331,6 +336,102 @@ func TestVersionsAPI_GetArtifactVersionReferences(t *testing.T) {
 	})
 }
 
+func TestVersionsAPI_ResolveReferencesRecursive(t *testing.T) {
+	// newAPI serves versions keyed by "artifact/version" in the default group, each with its content and
+	// outbound references, and counts the content requests per version.
+	type stubVersion struct {
+		content    string
+		references []models.ArtifactReference
+	}
+	newAPI := func(t *testing.T, versions map[string]stubVersion, fetched map[string]int) *apis.VersionsAPI {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /groups/default/artifacts/{artifact}/versions/{version}/content", func(w http.ResponseWriter, r *http.Request) {
+			key := r.PathValue("artifact") + "/" + r.PathValue("version")
+			version, found := versions[key]
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(models.APIError{Status: http.StatusNotFound, Title: TitleNotFound})
+				return
+			}
+			fetched[key]++
+			w.Header().Set("X-Registry-ArtifactType", string(models.Avro))
+			_, _ = w.Write([]byte(version.content))
+		})
+		mux.HandleFunc("GET /groups/default/artifacts/{artifact}/versions/{version}/references", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "OUTBOUND", r.URL.Query().Get("refType"))
+			w.Header().Set("Content-Type", "application/json")
+			references := versions[r.PathValue("artifact")+"/"+r.PathValue("version")].references
+			if references == nil {
+				references = []models.ArtifactReference{}
+			}
+			_ = json.NewEncoder(w).Encode(references)
+		})
+		server := httptest.NewServer(mux)
+		t.Cleanup(server.Close)
+		return apis.NewVersionsAPI(&client.Client{BaseURL: server.URL, HTTPClient: server.Client()})
+	}
+	ref := func(name, artifactID, version string) models.ArtifactReference {
+		return models.ArtifactReference{GroupID: "default", ArtifactID: artifactID, Version: version, Name: name}
+	}
+
+	t.Run("Two Level Chain", func(t *testing.T) {
+		fetched := map[string]int{}
+		api := newAPI(t, map[string]stubVersion{
+			"order/1": {content: "order", references: []models.ArtifactReference{
+				ref("com.example.Customer", "customer", "2"),
+				ref("com.example.Address", "address", "1"),
+			}},
+			"customer/2": {content: "customer", references: []models.ArtifactReference{ref("com.example.Address", "address", "1")}},
+			"address/1":  {content: "address"},
+		}, fetched)
+
+		resolved, err := api.ResolveReferencesRecursive(context.Background(), "default", "order", "1")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ResolvedSchema{
+			Content:      "order",
+			ArtifactType: models.Avro,
+			References:   map[string]string{"com.example.Customer": "customer", "com.example.Address": "address"},
+		}, resolved)
+		assert.Equal(t, map[string]int{"order/1": 1, "customer/2": 1, "address/1": 1}, fetched,
+			"a version referenced twice must be fetched once")
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		api := newAPI(t, map[string]stubVersion{
+			"a/1": {content: "a", references: []models.ArtifactReference{ref("B", "b", "1")}},
+			"b/1": {content: "b", references: []models.ArtifactReference{ref("C", "c", "1")}},
+			"c/1": {content: "c", references: []models.ArtifactReference{ref("B", "b", "1")}},
+		}, map[string]int{})
+
+		resolved, err := api.ResolveReferencesRecursive(context.Background(), "default", "a", "1")
+		assert.Nil(t, resolved)
+		assert.ErrorIs(t, err, models.ErrCyclicReference)
+		assert.Contains(t, err.Error(), "default/a@1 -> default/b@1 -> default/c@1 -> default/b@1")
+	})
+
+	t.Run("Conflicting Reference Names", func(t *testing.T) {
+		api := newAPI(t, map[string]stubVersion{
+			"a/1": {content: "a", references: []models.ArtifactReference{ref("Common", "b", "1"), ref("Other", "c", "1")}},
+			"b/1": {content: "b"},
+			"c/1": {content: "c", references: []models.ArtifactReference{ref("Common", "b", "2")}},
+			"b/2": {content: "b2"},
+		}, map[string]int{})
+
+		_, err := api.ResolveReferencesRecursive(context.Background(), "default", "a", "1")
+		assert.EqualError(t, err, `reference name "Common" refers to both default/b@1 and default/b@2`)
+	})
+
+	t.Run("Missing Reference", func(t *testing.T) {
+		api := newAPI(t, map[string]stubVersion{
+			"a/1": {content: "a", references: []models.ArtifactReference{ref("B", "b", "1")}},
+		}, map[string]int{})
+
+		_, err := api.ResolveReferencesRecursive(context.Background(), "default", "a", "1")
+		assertAPIError(t, err, http.StatusNotFound, TitleNotFound)
+		assert.Contains(t, err.Error(), `failed to fetch reference "B" to default/b@1`)
+	})
+}
+
 func TestVersionsAPI_GetArtifactVersionComments(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockResponse := []models.ArtifactComment{
@@ -1091,6 +1192,346 @@ func TestVersionsAPI_ListArtifactVersions(t *testing.T) {
 	})
 }
 
+func TestVersionsAPI_ListArtifactVersionsPage(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := models.ArtifactVersionListResponse{
+			Count: 345,
+			Versions: []models.ArtifactVersion{
+				{Version: "2.0.0", ArtifactType: models.Json},
+				{Version: "1.0.0", ArtifactType: models.Json},
+			},
+		}
+		server := setupMockServer(t, http.StatusOK, mockResponse,
+			"/groups/my-group/artifacts/example-artifact/versions", http.MethodGet)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		page, err := api.ListArtifactVersionsPage(context.Background(), "my-group", "example-artifact", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 345, page.Count)
+		assert.Len(t, page.Items, 2)
+		assert.Equal(t, "2.0.0", page.Items[0].Version)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		errorResponse := models.APIError{Status: http.StatusNotFound, Title: TitleNotFound}
+		server := setupMockServer(t, http.StatusNotFound, errorResponse,
+			"/groups/my-group/artifacts/example-artifact/versions", http.MethodGet)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		page, err := api.ListArtifactVersionsPage(context.Background(), "my-group", "example-artifact", nil)
+		assert.Nil(t, page)
+		assertAPIError(t, err, http.StatusNotFound, TitleNotFound)
+	})
+}
+
+func TestVersionsAPI_AllArtifactVersions(t *testing.T) {
+	newPage := func(offset, size int) models.ArtifactVersionListResponse {
+		page := models.ArtifactVersionListResponse{Count: offset + size}
+		for i := offset; i < offset+size; i++ {
+			page.Versions = append(page.Versions, models.ArtifactVersion{
+				Version:      strconv.Itoa(i + 1),
+				ArtifactType: models.Avro,
+			})
+		}
+		return page
+	}
+
+	t.Run("All Pages", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			size := 100
+			if offset > 0 {
+				size = 20
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(newPage(offset, size)))
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		versions, err := api.AllArtifactVersions(context.Background(), stubGroupId, stubArtifactId)
+		assert.NoError(t, err)
+		assert.Len(t, versions, 120)
+		assert.Equal(t, "120", versions[119].Version)
+	})
+
+	t.Run("Budget Exceeded Returns Partial Result", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			if offset > 0 {
+				select {
+				case <-r.Context().Done():
+					return
+				case <-time.After(2 * time.Second):
+				}
+			}
+			_ = json.NewEncoder(w).Encode(newPage(offset, 100))
+		}))
+		defer server.Close()
+
+		mockClient := client.NewClient(
+			server.URL,
+			client.WithHTTPClient(server.Client()),
+			client.WithMaxElapsedTime(100*time.Millisecond),
+		)
+		api := apis.NewVersionsAPI(mockClient)
+
+		start := time.Now()
+		versions, err := api.AllArtifactVersions(context.Background(), stubGroupId, stubArtifactId)
+		assert.ErrorIs(t, err, models.ErrBudgetExceeded)
+		assert.Len(t, versions, 100)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("Max Items Fetched", func(t *testing.T) {
+		const total = 1000
+		var requests int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			page := newPage(offset, min(limit, total-offset))
+			page.Count = total
+			_ = json.NewEncoder(w).Encode(page)
+		}))
+		defer server.Close()
+
+		mockClient := client.NewClient(
+			server.URL,
+			client.WithHTTPClient(server.Client()),
+			client.WithMaxItemsFetched(100),
+		)
+		api := apis.NewVersionsAPI(mockClient)
+
+		versions, err := api.AllArtifactVersions(context.Background(), stubGroupId, stubArtifactId)
+		assert.ErrorIs(t, err, models.ErrTruncated)
+		assert.Len(t, versions, 100)
+		assert.Equal(t, "100", versions[99].Version)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("History At Cap Is Not Truncated", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			_ = json.NewEncoder(w).Encode(newPage(offset, max(0, 100-offset)))
+		}))
+		defer server.Close()
+
+		mockClient := client.NewClient(
+			server.URL,
+			client.WithHTTPClient(server.Client()),
+			client.WithMaxItemsFetched(100),
+		)
+		api := apis.NewVersionsAPI(mockClient)
+
+		versions, err := api.AllArtifactVersions(context.Background(), stubGroupId, stubArtifactId)
+		assert.NoError(t, err)
+		assert.Len(t, versions, 100)
+	})
+}
+
+func TestVersionsAPI_ListArtifactVersionsAll(t *testing.T) {
+	// Five versions served newest first in pages of three: a full page followed by a partial one.
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/groups/test-group/artifacts/test-artifact/versions", r.URL.Path)
+		assert.Equal(t, "3", r.URL.Query().Get("limit"))
+		assert.Equal(t, "desc", r.URL.Query().Get("order"))
+		assert.Equal(t, "createdOn", r.URL.Query().Get("orderby"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		requested = append(requested, strconv.Itoa(offset))
+
+		page := models.ArtifactVersionListResponse{Count: 5}
+		for i := offset; i < offset+3 && i < 5; i++ {
+			page.Versions = append(page.Versions, models.ArtifactVersion{
+				Version:      strconv.Itoa(5 - i),
+				ArtifactType: models.Avro,
+			})
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(page))
+	}))
+	defer server.Close()
+
+	mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+	api := apis.NewVersionsAPI(mockClient)
+
+	t.Run("Two Pages", func(t *testing.T) {
+		requested = nil
+		params := &models.ListArtifactsVersionsParams{Limit: 3, Order: models.OrderDesc, OrderBy: models.VersionSortByCreatedOn}
+
+		var versions []string
+		it := api.ListArtifactVersionsAll(context.Background(), stubGroupId, stubArtifactId, params)
+		for it.Next(context.Background()) {
+			versions = append(versions, it.Value().Version)
+		}
+		assert.NoError(t, it.Err())
+		assert.Equal(t, []string{"5", "4", "3", "2", "1"}, versions)
+		assert.Equal(t, []string{"0", "3"}, requested, "the last page ends the iteration")
+		assert.False(t, it.Next(context.Background()))
+	})
+
+	t.Run("Invalid Parameters", func(t *testing.T) {
+		requested = nil
+		params := &models.ListArtifactsVersionsParams{Limit: -1}
+
+		it := api.ListArtifactVersionsAll(context.Background(), stubGroupId, stubArtifactId, params)
+		assert.False(t, it.Next(context.Background()))
+		assert.ErrorContains(t, it.Err(), "invalid parameters provided")
+		assert.Empty(t, requested)
+	})
+}
+
+func TestVersionsAPI_ListAllComments(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var (
+			mu          sync.Mutex
+			inFlight    int
+			maxInFlight int
+		)
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /groups/{groupId}/artifacts/{artifactId}/versions", func(w http.ResponseWriter, r *http.Request) {
+			versions := []models.ArtifactVersion{
+				{Version: "1", ArtifactType: models.Avro},
+				{Version: "2", ArtifactType: models.Avro},
+				{Version: "3", ArtifactType: models.Avro},
+			}
+			_ = json.NewEncoder(w).Encode(models.ArtifactVersionListResponse{Count: len(versions), Versions: versions})
+		})
+		mux.HandleFunc("GET /groups/{groupId}/artifacts/{artifactId}/versions/{version}/comments", func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+			}()
+			time.Sleep(10 * time.Millisecond)
+
+			comments := []models.ArtifactComment{}
+			switch r.PathValue("version") {
+			case "1":
+				comments = append(comments, models.ArtifactComment{CommentID: "c1", Value: "Looks good"})
+			case "3":
+				comments = append(comments,
+					models.ArtifactComment{CommentID: "c2", Value: "Breaking change"},
+					models.ArtifactComment{CommentID: "c3", Value: "Approved"},
+				)
+			}
+			_ = json.NewEncoder(w).Encode(comments)
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		mockClient := client.NewClient(
+			server.URL,
+			client.WithHTTPClient(server.Client()),
+			client.WithMaxConcurrency(2),
+		)
+		api := apis.NewVersionsAPI(mockClient)
+
+		results, err := api.ListAllComments(context.Background(), stubGroupId, stubArtifactId)
+		assert.NoError(t, err)
+		assert.Len(t, results, 3)
+		assert.Equal(t, []models.ArtifactComment{{CommentID: "c1", Value: "Looks good"}}, results["1"])
+		assert.Empty(t, results["2"])
+		if assert.Len(t, results["3"], 2) {
+			assert.Equal(t, "c2", results["3"][0].CommentID)
+			assert.Equal(t, "c3", results["3"][1].CommentID)
+		}
+		assert.LessOrEqual(t, maxInFlight, 2)
+	})
+
+	t.Run("Comments Error", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /groups/{groupId}/artifacts/{artifactId}/versions", func(w http.ResponseWriter, r *http.Request) {
+			versions := []models.ArtifactVersion{{Version: "1", ArtifactType: models.Avro}}
+			_ = json.NewEncoder(w).Encode(models.ArtifactVersionListResponse{Count: len(versions), Versions: versions})
+		})
+		mux.HandleFunc("GET /groups/{groupId}/artifacts/{artifactId}/versions/{version}/comments", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(models.APIError{Status: http.StatusInternalServerError, Title: TitleInternalServerError})
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		_, err := api.ListAllComments(context.Background(), stubGroupId, stubArtifactId)
+		assertAPIError(t, err, http.StatusInternalServerError, TitleInternalServerError)
+	})
+}
+
+func TestVersionsAPI_EffectiveLatestState(t *testing.T) {
+	serve := func(t *testing.T, versions []models.ArtifactVersion) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, fmt.Sprintf("/groups/%s/artifacts/%s/versions", stubGroupId, stubArtifactId), r.URL.Path)
+			assert.Equal(t, "desc", r.URL.Query().Get("order"))
+			assert.Equal(t, "createdOn", r.URL.Query().Get("orderby"))
+			assert.NoError(t, json.NewEncoder(w).Encode(models.ArtifactVersionListResponse{
+				Count:    len(versions),
+				Versions: versions,
+			}))
+		}))
+	}
+
+	t.Run("Skips Draft And Disabled Versions", func(t *testing.T) {
+		server := serve(t, []models.ArtifactVersion{
+			{Version: "4", State: models.StateDraft, ArtifactType: models.Avro},
+			{Version: "3", State: models.StateDisabled, ArtifactType: models.Avro},
+			{Version: "2", State: models.StateEnabled, ArtifactType: models.Avro},
+			{Version: "1", State: models.StateEnabled, ArtifactType: models.Avro},
+		})
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		state, version, err := api.EffectiveLatestState(context.Background(), stubGroupId, stubArtifactId)
+		assert.NoError(t, err)
+		assert.Equal(t, models.StateEnabled, state)
+		assert.Equal(t, "2", version)
+	})
+
+	t.Run("Deprecated Version", func(t *testing.T) {
+		server := serve(t, []models.ArtifactVersion{
+			{Version: "2", State: models.StateDeprecated, ArtifactType: models.Avro},
+			{Version: "1", State: models.StateEnabled, ArtifactType: models.Avro},
+		})
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		state, version, err := api.EffectiveLatestState(context.Background(), stubGroupId, stubArtifactId)
+		assert.NoError(t, err)
+		assert.Equal(t, models.StateDeprecated, state)
+		assert.Equal(t, "2", version)
+	})
+
+	t.Run("No Enabled Version", func(t *testing.T) {
+		server := serve(t, []models.ArtifactVersion{
+			{Version: "1", State: models.StateDraft, ArtifactType: models.Avro},
+		})
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		_, _, err := api.EffectiveLatestState(context.Background(), stubGroupId, stubArtifactId)
+		assert.ErrorIs(t, err, models.ErrNoEnabledVersion)
+	})
+}
+
 func TestVersionsAPI_CreateArtifactVersion(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockResponse := models.ArtifactVersionDetailed{
@@ -1147,6 +1588,61 @@ func TestVersionsAPI_CreateArtifactVersion(t *testing.T) {
 		assert.Equal(t, 2, len(result.Labels))
 	})
 
+	t.Run("Validate Locally", func(t *testing.T) {
+		api := apis.NewVersionsAPI(&client.Client{})
+
+		createRequest := &models.CreateVersionRequest{
+			Version: "2.0.0",
+			Content: models.CreateContentRequest{
+				Content:     `{"type": "object", "properties": {"id": {"type": "uuid"}}}`,
+				ContentType: "application/json",
+			},
+			ValidateLocally: true,
+			ArtifactType:    models.Json,
+		}
+
+		result, err := api.CreateArtifactVersion(context.Background(), "my-group", "example-artifact", createRequest, false)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, models.ErrInvalidContent)
+		assert.ErrorContains(t, err, "invalid JSON Schema at /properties/id/type: unknown type uuid")
+
+		createRequest.ArtifactType = models.Avro
+		createRequest.Content.Content = `{"type": "record", "name": "Test", "fields": [{"name": "field1"}]}`
+		result, err = api.CreateArtifactVersion(context.Background(), "my-group", "example-artifact", createRequest, false)
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "invalid Avro schema at /fields/0: field field1 without type")
+
+		createRequest.ArtifactType = models.Protobuf
+		createRequest.Content = models.CreateContentRequest{
+			Content:     "syntax = \"proto3\";\nmessage Test {\n  Missing field1 = 1;\n}\n",
+			ContentType: "application/x-protobuf",
+		}
+		result, err = api.CreateArtifactVersion(context.Background(), "my-group", "example-artifact", createRequest, false)
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, `invalid Protobuf schema at line 3, column 3: undefined type "Missing"`)
+	})
+
+	t.Run("Validate Locally Without Artifact Type", func(t *testing.T) {
+		server := setupMockServer(t, http.StatusOK, models.ArtifactVersionDetailed{
+			ArtifactVersion: models.ArtifactVersion{Version: "2.0.0", ArtifactType: models.Json},
+		}, "/groups/my-group/artifacts/example-artifact/versions", http.MethodPost)
+		defer server.Close()
+
+		api := apis.NewVersionsAPI(&client.Client{BaseURL: server.URL, HTTPClient: server.Client()})
+
+		createRequest := &models.CreateVersionRequest{
+			Version: "2.0.0",
+			Content: models.CreateContentRequest{
+				Content:     `{"type": "object", "properties": {"id": {"type": "uuid"}}}`,
+				ContentType: "application/json",
+			},
+			ValidateLocally: true,
+		}
+		result, err := api.CreateArtifactVersion(context.Background(), "my-group", "example-artifact", createRequest, false)
+		assert.NoError(t, err, "content of an unknown type is left to the registry, as by CreateArtifact")
+		assert.NotNil(t, result)
+	})
+
 	t.Run("BadRequest", func(t *testing.T) {
 		apiError := models.APIError{Status: http.StatusBadRequest, Title: "Invalid input"}
 
@@ -1290,6 +1786,286 @@ func TestVersionsAPI_CreateArtifactVersion(t *testing.T) {
 		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "Artifact ID")
 	})
+
+	t.Run("Draft State Sets IsDraft", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, true, body["isDraft"])
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(models.ArtifactVersionDetailed{
+				ArtifactVersion: models.ArtifactVersion{Version: "1.0.0", ArtifactType: models.Json, State: models.StateDraft},
+			})
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		createRequest := &models.CreateVersionRequest{
+			Version: "1.0.0",
+			Content: models.CreateContentRequest{Content: `{"a": "1"}`, ContentType: "application/json"},
+			State:   models.StateDraft,
+		}
+
+		result, err := api.CreateArtifactVersion(context.Background(), "my-group", "example-artifact", createRequest, false)
+		assert.NoError(t, err)
+		assert.Equal(t, models.StateDraft, result.State)
+		assert.True(t, createRequest.IsDraft)
+	})
+
+	t.Run("Validation Error: Draft Contradicts State", func(t *testing.T) {
+		mockClient := &client.Client{}
+		api := apis.NewVersionsAPI(mockClient)
+
+		createRequest := &models.CreateVersionRequest{
+			Content: models.CreateContentRequest{Content: `{"a": "1"}`, ContentType: "application/json"},
+			IsDraft: true,
+			State:   models.StateEnabled,
+		}
+
+		result, err := api.CreateArtifactVersion(context.Background(), "my-group", "example-artifact", createRequest, false)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, models.ErrDraftStateConflict))
+	})
+}
+
+func TestVersionsAPI_CreateArtifactVersion_VersionStrategy(t *testing.T) {
+	// newServer serves latestVersion as the latest version and records the version of created versions.
+	newServer := func(t *testing.T, latestVersion string, created *[]string) *httptest.Server {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /groups/{group}/artifacts/{artifact}/versions/{version}", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "branch=latest", r.PathValue("version"))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(models.ArtifactVersionMetadata{Version: latestVersion})
+		})
+		mux.HandleFunc("POST /groups/{group}/artifacts/{artifact}/versions", func(w http.ResponseWriter, r *http.Request) {
+			var request models.CreateVersionRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			*created = append(*created, request.Version)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(models.ArtifactVersionDetailed{ArtifactVersion: models.ArtifactVersion{Version: request.Version, ArtifactType: models.Avro}})
+		})
+		server := httptest.NewServer(mux)
+		t.Cleanup(server.Close)
+		return server
+	}
+	newRequest := func() *models.CreateVersionRequest {
+		return &models.CreateVersionRequest{Content: models.CreateContentRequest{Content: stubArtifactContent, ContentType: "application/json"}}
+	}
+
+	t.Run("Timestamp", func(t *testing.T) {
+		var created []string
+		server := newServer(t, "1.0.0", &created)
+		api := apis.NewVersionsAPI(client.NewClient(server.URL, client.WithVersionStrategy(models.VersionTimestamp)))
+
+		before := time.Now().UTC().Truncate(time.Second)
+		result, err := api.CreateArtifactVersion(context.Background(), stubGroupId, stubArtifactId, newRequest(), false)
+		assert.NoError(t, err)
+
+		labeled, err := time.Parse("20060102150405", result.Version)
+		assert.NoError(t, err)
+		assert.False(t, labeled.Before(before))
+		assert.False(t, labeled.After(time.Now().UTC()))
+		assert.Equal(t, []string{result.Version}, created)
+	})
+
+	t.Run("Semver Bump", func(t *testing.T) {
+		for latest, next := range map[string]string{"1.4.1": "1.4.2", "v2.0.0-rc.1": "2.0.0"} {
+			var created []string
+			server := newServer(t, latest, &created)
+			api := apis.NewVersionsAPI(client.NewClient(server.URL, client.WithVersionStrategy(models.VersionSemverBump)))
+
+			request := newRequest()
+			result, err := api.CreateArtifactVersion(context.Background(), stubGroupId, stubArtifactId, request, false)
+			assert.NoError(t, err)
+			assert.Equal(t, next, result.Version)
+			assert.Equal(t, []string{next}, created)
+			assert.Empty(t, request.Version, "the caller's request must not be modified")
+		}
+	})
+
+	t.Run("Semver Bump Of Non-Semver Latest", func(t *testing.T) {
+		var created []string
+		server := newServer(t, "20261016142530", &created)
+		api := apis.NewVersionsAPI(client.NewClient(server.URL, client.WithVersionStrategy(models.VersionSemverBump)))
+
+		_, err := api.CreateArtifactVersion(context.Background(), stubGroupId, stubArtifactId, newRequest(), false)
+		assert.ErrorIs(t, err, models.ErrNotSemver)
+		assert.Empty(t, created)
+	})
+
+	t.Run("Explicit Version Wins", func(t *testing.T) {
+		var created []string
+		server := newServer(t, "1.4.1", &created)
+		api := apis.NewVersionsAPI(client.NewClient(server.URL, client.WithVersionStrategy(models.VersionSemverBump)))
+
+		request := newRequest()
+		request.Version = "3.0.0"
+		_, err := api.CreateArtifactVersion(context.Background(), stubGroupId, stubArtifactId, request, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"3.0.0"}, created)
+	})
+
+	t.Run("Dry Run Is Not Labeled", func(t *testing.T) {
+		var created []string
+		server := newServer(t, "20261016142530", &created)
+		api := apis.NewVersionsAPI(client.NewClient(server.URL, client.WithVersionStrategy(models.VersionSemverBump)))
+
+		_, err := api.CreateArtifactVersion(context.Background(), stubGroupId, stubArtifactId, newRequest(), true)
+		assert.NoError(t, err, "the latest version is not looked up, so it cannot fail the dry run")
+		assert.Equal(t, []string{""}, created)
+	})
+
+	t.Run("Auto", func(t *testing.T) {
+		var created []string
+		server := newServer(t, "1.4.1", &created)
+		api := apis.NewVersionsAPI(client.NewClient(server.URL, client.WithVersionStrategy(models.VersionAuto)))
+
+		_, err := api.CreateArtifactVersion(context.Background(), stubGroupId, stubArtifactId, newRequest(), false)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{""}, created)
+	})
+}
+
+func TestVersionsAPI_CreateArtifactVersion_SerializeWrites(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		inFlight int
+		received []string
+	)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request models.CreateVersionRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		mu.Lock()
+		inFlight++
+		assert.Equal(t, 1, inFlight, "version creates must not interleave")
+		received = append(received, request.Version)
+		first := len(received) == 1
+		mu.Unlock()
+
+		if first {
+			<-release
+		}
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+		assert.NoError(t, json.NewEncoder(w).Encode(models.ArtifactVersionDetailed{
+			ArtifactVersion: models.ArtifactVersion{Version: request.Version, ArtifactType: models.Avro},
+		}))
+	}))
+	defer server.Close()
+
+	mockClient := client.NewClient(
+		server.URL,
+		client.WithHTTPClient(server.Client()),
+		client.WithSerializeWritesPerArtifact(),
+	)
+	api := apis.NewVersionsAPI(mockClient)
+
+	const writers = 5
+	var wg sync.WaitGroup
+	versions := make([]string, writers)
+	for i := 0; i < writers; i++ {
+		versions[i] = strconv.Itoa(i + 1)
+		wg.Add(1)
+		go func(v string) {
+			defer wg.Done()
+			request := &models.CreateVersionRequest{
+				Version: v,
+				Content: models.CreateContentRequest{Content: stubArtifactContent, ContentType: "application/json"},
+			}
+			result, err := api.CreateArtifactVersion(context.Background(), stubGroupId, stubArtifactId, request, false)
+			if assert.NoError(t, err) {
+				assert.Equal(t, v, result.Version)
+			}
+		}(versions[i])
+		// Give each writer time to queue up behind the previous one.
+		time.Sleep(20 * time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, versions, received)
+}
+
+func TestVersionsAPI_GetArtifactContent(t *testing.T) {
+	tests := []struct {
+		name          string
+		selector      models.ContentSelector
+		defaultBranch string
+		path          string
+	}{
+		{"Branch", models.ContentSelector{Branch: "drafts"}, "main", "/groups/my-group/artifacts/example-artifact/versions/branch=drafts/content"},
+		{"Version", models.ContentSelector{Version: "1.0.0"}, "main", "/groups/my-group/artifacts/example-artifact/versions/1.0.0/content"},
+		{"Empty", models.ContentSelector{}, "", "/groups/my-group/artifacts/example-artifact/versions/branch=latest/content"},
+		{"Empty With Default Branch", models.ContentSelector{}, "main", "/groups/my-group/artifacts/example-artifact/versions/branch=main/content"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.path, r.URL.Path)
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.Header().Set("X-Registry-ArtifactType", string(models.Json))
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"a": "1"}`))
+			}))
+			defer server.Close()
+
+			mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client(), DefaultBranch: tt.defaultBranch}
+			api := apis.NewVersionsAPI(mockClient)
+
+			content, err := api.GetArtifactContent(context.Background(), "my-group", "example-artifact", tt.selector, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"a": "1"}`, content.Content)
+		})
+	}
+
+	t.Run("Version And Branch", func(t *testing.T) {
+		api := apis.NewVersionsAPI(&client.Client{})
+
+		selector := models.ContentSelector{Version: "1.0.0", Branch: "drafts"}
+		content, err := api.GetArtifactContent(context.Background(), "my-group", "example-artifact", selector, nil)
+		assert.Nil(t, content)
+		assert.ErrorContains(t, err, "invalid content selector provided")
+	})
+}
+
+func TestVersionsAPI_GetArtifactVersionContent_RequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+			_, _ = w.Write([]byte(stubArtifactContent))
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	httpClient := server.Client()
+	mockClient := client.NewClient(
+		server.URL,
+		client.WithHTTPClient(httpClient),
+		client.WithTimeout(10*time.Second),
+	)
+	api := apis.NewVersionsAPI(mockClient)
+
+	ctx, cancel := client.WithRequestTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	content, err := api.GetArtifactVersionContent(ctx, stubGroupId, stubArtifactId, "1", nil)
+	assert.Nil(t, content)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.Equal(t, 10*time.Second, mockClient.HTTPClient.Timeout)
+	assert.Zero(t, httpClient.Timeout, "the supplied HTTP client must not be modified")
 }
 
 func TestVersionsAPI_GetArtifactVersionContent(t *testing.T) {
@@ -1305,6 +2081,7 @@ func TestVersionsAPI_GetArtifactVersionContent(t *testing.T) {
 			assert.Equal(t, http.MethodGet, r.Method)
 			// Write the response
 			w.Header().Set("X-Registry-ArtifactType", string(models.Json))
+			w.Header().Set("ETag", `"etag-3"`)
 			w.WriteHeader(http.StatusOK)
 			_, err := w.Write([]byte(mockResponse))
 			assert.NoError(t, err)
@@ -1325,18 +2102,107 @@ func TestVersionsAPI_GetArtifactVersionContent(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotEmpty(t, content)
 		assert.Equal(t, `{"a": "1"}`, content.Content)
+		assert.Equal(t, "1.0.0", content.Version)
+		assert.Equal(t, `"etag-3"`, content.ETag)
 	})
 
-	t.Run("BadRequest", func(t *testing.T) {
-		apiError := models.APIError{Status: http.StatusBadRequest, Title: "Invalid request"}
-		expectedURL := "/groups/my-group/artifacts/example-artifact/versions/1.0.0/content"
-
-		server := setupMockServer(t, http.StatusBadRequest, apiError, expectedURL, http.MethodGet)
-		defer server.Close()
-
-		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
-		api := apis.NewVersionsAPI(mockClient)
-
+	t.Run("Latest Resolves Version", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(
+				t,
+				"/groups/my-group/artifacts/example-artifact/versions/branch=latest/content",
+				r.URL.Path,
+			)
+			w.Header().Set("X-Registry-Version", "3.2.1")
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"a": "1"}`))
+			assert.NoError(t, err)
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		content, err := api.GetLatestContent(context.Background(), "my-group", "example-artifact", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"a": "1"}`, content.Content)
+		assert.Equal(t, "3.2.1", content.Version)
+	})
+
+	t.Run("Artifact Type Mismatch", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Registry-ArtifactType", string(models.Json))
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"a": "1"}`))
+			assert.NoError(t, err)
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		// An Avro deserializer must not silently accept a JSON Schema artifact.
+		params := &models.ArtifactReferenceParams{ExpectedArtifactType: models.Avro}
+		result, err := api.GetArtifactVersionContent(
+			context.Background(),
+			"my-group",
+			"example-artifact",
+			"1.0.0",
+			params,
+		)
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, models.ErrArtifactTypeMismatch))
+	})
+
+	t.Run("Artifact Type Match", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Registry-ArtifactType", string(models.Avro))
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(stubContent))
+			assert.NoError(t, err)
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		params := &models.ArtifactReferenceParams{ExpectedArtifactType: models.Avro}
+		result, err := api.GetArtifactVersionContent(
+			context.Background(),
+			"my-group",
+			"example-artifact",
+			"1.0.0",
+			params,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, stubContent, result.Content)
+	})
+
+	t.Run("Custom Artifact Type", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Registry-ArtifactType", "RAML")
+			_, _ = w.Write([]byte(stubContent))
+		}))
+		defer server.Close()
+
+		api := apis.NewVersionsAPI(&client.Client{BaseURL: server.URL, HTTPClient: server.Client()})
+
+		result, err := api.GetArtifactVersionContent(context.Background(), "my-group", "example-artifact", "1.0.0", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, stubContent, result.Content)
+		assert.Equal(t, models.ArtifactType("RAML"), result.ArtifactType, "unknown types are passed through")
+	})
+
+	t.Run("BadRequest", func(t *testing.T) {
+		apiError := models.APIError{Status: http.StatusBadRequest, Title: "Invalid request"}
+		expectedURL := "/groups/my-group/artifacts/example-artifact/versions/1.0.0/content"
+
+		server := setupMockServer(t, http.StatusBadRequest, apiError, expectedURL, http.MethodGet)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
 		result, err := api.GetArtifactVersionContent(
 			context.Background(),
 			"my-group",
@@ -1458,6 +2324,165 @@ func TestVersionsAPI_GetArtifactVersionContent(t *testing.T) {
 	})
 }
 
+func TestVersionsAPI_GetArtifactVersionContent_ValidateContent(t *testing.T) {
+	var stored string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Registry-ArtifactType", string(models.Avro))
+		_, _ = io.WriteString(w, stored)
+	}))
+	defer server.Close()
+
+	mockClient := client.NewClient(
+		server.URL,
+		client.WithHTTPClient(server.Client()),
+		client.WithValidateRetrievedContent(),
+	)
+	api := apis.NewVersionsAPI(mockClient)
+
+	stored = `{"type": "record", "name": "User", "fields": [{"name": "id", "type": "string"}]}`
+	content, err := api.GetArtifactVersionContent(context.Background(), stubGroupId, stubArtifactId, version, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, stored, content.Content)
+
+	// A record without a name, as stored while the validity rule was disabled.
+	stored = `{"type": "record", "fields": [{"name": "id", "type": "string"}]}`
+	content, err = api.GetArtifactVersionContent(context.Background(), stubGroupId, stubArtifactId, version, nil)
+	assert.Nil(t, content)
+	assert.ErrorIs(t, err, models.ErrInvalidContent)
+
+	unvalidated := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+	content, err = apis.NewVersionsAPI(unvalidated).GetArtifactVersionContent(context.Background(), stubGroupId, stubArtifactId, version, nil)
+	assert.NoError(t, err, "content is only validated when enabled")
+	assert.Equal(t, stored, content.Content)
+}
+
+func TestVersionsAPI_GetArtifactVersionContentRange(t *testing.T) {
+	t.Run("Partial Content", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/groups/test-group/artifacts/test-artifact/versions/1.0.0/content", r.URL.Path)
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "bytes=2-5", r.Header.Get("Range"))
+			assert.Equal(t, "identity", r.Header.Get("Accept-Encoding"))
+
+			w.Header().Set("Content-Range", "bytes 2-5/"+strconv.Itoa(len(stubArtifactContent)))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write([]byte(stubArtifactContent[2:6]))
+		}))
+		defer server.Close()
+
+		mockClient := client.NewClient(server.URL, client.WithHTTPClient(server.Client()))
+		api := apis.NewVersionsAPI(mockClient)
+
+		content, err := api.GetArtifactVersionContentRange(context.Background(), stubGroupId, stubArtifactId, "1.0.0", 2, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(stubArtifactContent[2:6]), content)
+	})
+
+	t.Run("Range Not Supported", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(stubArtifactContent))
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		content, err := api.GetArtifactVersionContentRange(context.Background(), stubGroupId, stubArtifactId, "1.0.0", 0, 3)
+		assert.Nil(t, content)
+		assert.ErrorIs(t, err, models.ErrRangeNotSupported)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		server := setupMockServer(t, http.StatusNotFound, models.APIError{Status: http.StatusNotFound, Title: TitleNotFound}, "/groups/test-group/artifacts/test-artifact/versions/1.0.0/content", http.MethodGet)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		content, err := api.GetArtifactVersionContentRange(context.Background(), stubGroupId, stubArtifactId, "1.0.0", 0, 3)
+		assert.Nil(t, content)
+		assertAPIError(t, err, http.StatusNotFound, TitleNotFound)
+	})
+
+	t.Run("Invalid Range", func(t *testing.T) {
+		api := apis.NewVersionsAPI(&client.Client{})
+
+		content, err := api.GetArtifactVersionContentRange(context.Background(), stubGroupId, stubArtifactId, "1.0.0", 5, 2)
+		assert.Nil(t, content)
+		assert.ErrorIs(t, err, models.ErrInvalidInput)
+	})
+}
+
+func TestVersionsAPI_GetArtifactVersionContentStream(t *testing.T) {
+	t.Run("Reads Chunk By Chunk", func(t *testing.T) {
+		chunk := strings.Repeat("x", 64*1024)
+		const chunks = 64 // 4 MiB in total
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, fmt.Sprintf("/groups/%s/artifacts/%s/versions/%s/content", stubGroupId, stubArtifactId, version), r.URL.Path)
+			w.Header().Set("X-Registry-ArtifactType", string(models.Protobuf))
+			for i := 0; i < chunks; i++ {
+				_, _ = io.WriteString(w, chunk)
+			}
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		body, artifactType, err := api.GetArtifactVersionContentStream(context.Background(), stubGroupId, stubArtifactId, version, nil)
+		assert.NoError(t, err)
+		defer body.Close()
+		assert.Equal(t, models.Protobuf, artifactType)
+
+		buf := make([]byte, len(chunk))
+		for i := 0; i < chunks; i++ {
+			n, err := io.ReadFull(body, buf)
+			assert.NoError(t, err)
+			assert.Equal(t, chunk, string(buf[:n]))
+		}
+		n, err := body.Read(buf)
+		assert.Zero(t, n)
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("Artifact Type Mismatch", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Registry-ArtifactType", string(models.Protobuf))
+			_, _ = io.WriteString(w, stubArtifactContent)
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		params := &models.ArtifactReferenceParams{ExpectedArtifactType: models.Avro}
+		body, _, err := api.GetArtifactVersionContentStream(context.Background(), stubGroupId, stubArtifactId, version, params)
+		assert.Nil(t, body)
+		assert.ErrorIs(t, err, models.ErrArtifactTypeMismatch)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockErrorResponse := models.APIError{Status: http.StatusNotFound, Title: TitleNotFound}
+		server := setupMockServer(
+			t,
+			http.StatusNotFound,
+			mockErrorResponse,
+			fmt.Sprintf("/groups/%s/artifacts/%s/versions/%s/content", stubGroupId, stubArtifactId, version),
+			http.MethodGet,
+		)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		body, _, err := api.GetArtifactVersionContentStream(context.Background(), stubGroupId, stubArtifactId, version, nil)
+		assert.Nil(t, body)
+		assertAPIError(t, err, http.StatusNotFound, TitleNotFound)
+	})
+}
+
 func TestVersionsAPI_UpdateArtifactVersionContent(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		expectedURL := "/groups/my-group/artifacts/example-artifact/versions/1.0.0/content"
@@ -1484,6 +2509,38 @@ func TestVersionsAPI_UpdateArtifactVersionContent(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Minify JSON On Write", func(t *testing.T) {
+		var uploaded []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var content models.CreateContentRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&content))
+			uploaded = append(uploaded, content.Content)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		api := apis.NewVersionsAPI(client.NewClient(server.URL, client.WithMinifyJSONOnWrite()))
+		update := func(contentType, content string) *models.CreateContentRequest {
+			request := &models.CreateContentRequest{Content: content, ContentType: contentType}
+			assert.NoError(t, api.UpdateArtifactVersionContent(context.Background(), stubGroupId, stubArtifactId, version, request))
+			return request
+		}
+
+		openAPI := "{\n  \"openapi\": \"3.0.0\",\n  \"info\": {\"title\": \"Orders API\", \"version\": \"1.0\"}\n}"
+		request := update("application/json", openAPI)
+		update("application/vnd.oai.openapi+json; charset=utf-8", openAPI)
+		update("application/x-yaml", "openapi: 3.0.0\ninfo:\n  title: Orders API\n")
+		update("application/json", `{"openapi": "3.0.0",`)
+
+		if assert.Len(t, uploaded, 4) {
+			assert.Equal(t, `{"openapi":"3.0.0","info":{"title":"Orders API","version":"1.0"}}`, uploaded[0])
+			assert.Equal(t, uploaded[0], uploaded[1])
+			assert.Equal(t, "openapi: 3.0.0\ninfo:\n  title: Orders API\n", uploaded[2], "non-JSON content must be left untouched")
+			assert.Equal(t, `{"openapi": "3.0.0",`, uploaded[3], "invalid JSON must be sent as-is")
+		}
+		assert.Equal(t, openAPI, request.Content, "the caller's request must not be modified")
+	})
+
 	t.Run("BadRequest", func(t *testing.T) {
 		apiError := models.APIError{Status: http.StatusBadRequest, Title: "Invalid input"}
 		expectedURL := "/groups/my-group/artifacts/example-artifact/versions/1.0.0/content"
@@ -1511,6 +2568,60 @@ func TestVersionsAPI_UpdateArtifactVersionContent(t *testing.T) {
 		assertAPIError(t, err, http.StatusBadRequest, "Invalid input")
 	})
 
+	t.Run("PreconditionFailed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, `"etag-1"`, r.Header.Get("If-Match"))
+			w.WriteHeader(http.StatusPreconditionFailed)
+			err := json.NewEncoder(w).Encode(models.APIError{
+				Status: http.StatusPreconditionFailed,
+				Title:  "Precondition Failed",
+			})
+			assert.NoError(t, err)
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		content := &models.CreateContentRequest{
+			Content:     `{"key": "value"}`,
+			ContentType: "application/json",
+		}
+
+		err := api.UpdateArtifactVersionContent(
+			client.WithIfMatch(context.Background(), `"etag-1"`),
+			"my-group",
+			"example-artifact",
+			"1.0.0",
+			content,
+		)
+
+		assert.True(t, errors.Is(err, models.ErrPreconditionFailed))
+		assertAPIError(t, err, http.StatusPreconditionFailed, "Precondition Failed")
+	})
+
+	t.Run("PreconditionFailed Matched On Response Status", func(t *testing.T) {
+		// A gateway may answer with problem details that omit the status.
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte(`{"title": "Precondition Failed"}`))
+		}))
+		defer server.Close()
+
+		api := apis.NewVersionsAPI(&client.Client{BaseURL: server.URL, HTTPClient: server.Client()})
+
+		content := &models.CreateContentRequest{Content: `{"key": "value"}`, ContentType: "application/json"}
+		err := api.UpdateArtifactVersionContent(
+			client.WithIfMatch(context.Background(), `"etag-1"`),
+			"my-group",
+			"example-artifact",
+			"1.0.0",
+			content,
+		)
+		assert.ErrorIs(t, err, models.ErrPreconditionFailed)
+		assertAPIError(t, err, http.StatusPreconditionFailed, "Precondition Failed")
+	})
+
 	t.Run("NotFound", func(t *testing.T) {
 		apiError := models.APIError{Status: http.StatusNotFound, Title: "Artifact not found"}
 		expectedURL := "/groups/my-group/artifacts/example-artifact/versions/1.0.0/content"
@@ -1850,6 +2961,73 @@ func TestVersionsAPI_SearchForArtifactVersionByContent(t *testing.T) {
 		assert.Equal(t, "1.0.0", versions[1].Version)
 	})
 
+	t.Run("Scoped To Artifact", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/search/versions", r.URL.Path)
+			assert.Equal(t, "my-group", r.URL.Query().Get("groupId"))
+			assert.Equal(t, "example-artifact", r.URL.Query().Get("artifactId"))
+
+			// The response includes a match from another artifact, as an older registry ignoring the scope would.
+			_ = json.NewEncoder(w).Encode(models.ArtifactVersionListResponse{
+				Count: 4,
+				Versions: []models.ArtifactVersion{
+					{Version: "1.0.0", GroupID: "my-group", ArtifactID: "example-artifact", ArtifactType: models.Json},
+					{Version: "3.0.0", GroupID: "my-group", ArtifactID: "other-artifact", ArtifactType: models.Json},
+					// A version without a group belongs to the default group.
+					{Version: "4.0.0", ArtifactID: "example-artifact", ArtifactType: models.Json},
+					// A version without an artifact cannot be shown to belong to the scoped artifact.
+					{Version: "5.0.0", GroupID: "my-group", ArtifactType: models.Json},
+				},
+			})
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		params := &models.SearchVersionByContentParams{GroupID: "my-group", ArtifactID: "example-artifact"}
+		versions, err := api.SearchForArtifactVersionByContent(context.Background(), `{"key": "value"}`, params)
+		assert.NoError(t, err)
+		if assert.Len(t, versions, 1) {
+			assert.Equal(t, "1.0.0", versions[0].Version)
+			assert.Equal(t, "example-artifact", versions[0].ArtifactID)
+		}
+	})
+
+	t.Run("Scoped To Artifact Without Group", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.URL.Query().Get("groupId"))
+			assert.Equal(t, "example-artifact", r.URL.Query().Get("artifactId"))
+
+			_ = json.NewEncoder(w).Encode(models.ArtifactVersionListResponse{
+				Count: 2,
+				Versions: []models.ArtifactVersion{
+					{Version: "1.0.0", GroupID: "my-group", ArtifactID: "example-artifact", ArtifactType: models.Json},
+					{Version: "2.0.0", ArtifactID: "example-artifact", ArtifactType: models.Json},
+				},
+			})
+		}))
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		params := &models.SearchVersionByContentParams{ArtifactID: "example-artifact"}
+		versions, err := api.SearchForArtifactVersionByContent(context.Background(), `{"key": "value"}`, params)
+		assert.NoError(t, err)
+		assert.Len(t, versions, 2, "without a group scope, versions of every group are kept")
+	})
+
+	t.Run("Scope Validation", func(t *testing.T) {
+		mockClient := &client.Client{BaseURL: "http://example.com", HTTPClient: &http.Client{}}
+		api := apis.NewVersionsAPI(mockClient)
+
+		params := &models.SearchVersionByContentParams{GroupID: "my group!", ArtifactID: "example-artifact"}
+		versions, err := api.SearchForArtifactVersionByContent(context.Background(), `{"key": "value"}`, params)
+		assert.Nil(t, versions)
+		assert.Error(t, err)
+	})
+
 	t.Run("BadRequest - Empty Content", func(t *testing.T) {
 		mockError := models.APIError{
 			Status: 400,
@@ -1947,6 +3125,252 @@ func TestVersionsAPI_SearchForArtifactVersionByContent(t *testing.T) {
 	})
 }
 
+func TestVersionsAPI_OperationName(t *testing.T) {
+	var operations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Registry-ArtifactType", string(models.Avro))
+		if r.Method == http.MethodPost {
+			_ = json.NewEncoder(w).Encode(models.ArtifactVersionDetailed{
+				ArtifactVersion: models.ArtifactVersion{Version: "2", ArtifactType: models.Avro},
+			})
+			return
+		}
+		_, _ = w.Write([]byte(stubContent))
+	}))
+	defer server.Close()
+
+	mockClient := client.NewClient(
+		server.URL,
+		client.WithHTTPClient(server.Client()),
+		client.WithRequestEditor(func(ctx context.Context, req *http.Request) error {
+			operations = append(operations, client.OperationName(ctx))
+			return nil
+		}),
+	)
+	api := apis.NewVersionsAPI(mockClient)
+
+	request := &models.CreateVersionRequest{
+		Content: models.CreateContentRequest{Content: stubContent, ContentType: "application/json"},
+	}
+	_, err := api.CreateArtifactVersion(context.Background(), stubGroupId, stubArtifactId, request, false)
+	assert.NoError(t, err)
+
+	_, err = api.GetLatestContent(context.Background(), stubGroupId, stubArtifactId, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"CreateArtifactVersion", "GetArtifactVersionContent"}, operations)
+	assert.Empty(t, client.OperationName(context.Background()))
+}
+
+func TestVersionsAPI_GetVersion(t *testing.T) {
+	basePath := "/groups/" + stubGroupId + "/artifacts/" + stubArtifactId + "/versions/1"
+
+	t.Run("Success", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET "+basePath+"/content", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Registry-ArtifactType", string(models.Avro))
+			_, _ = w.Write([]byte(stubContent))
+		})
+		mux.HandleFunc("GET "+basePath, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(models.ArtifactVersionMetadata{
+				BaseMetadata: models.BaseMetadata{
+					GroupID:      stubGroupId,
+					ArtifactID:   stubArtifactId,
+					ArtifactType: string(models.Avro),
+				},
+				Version:   "1",
+				GlobalID:  42,
+				ContentID: 7,
+				State:     models.StateEnabled,
+			})
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		version, err := api.GetVersion(context.Background(), stubGroupId, stubArtifactId, "1", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, stubContent, version.Content)
+		assert.Equal(t, models.Avro, version.ArtifactType)
+		assert.Equal(t, "1", version.Version)
+		assert.Equal(t, int64(42), version.GlobalID)
+		assert.Equal(t, int64(7), version.ContentID)
+		assert.Equal(t, models.StateEnabled, version.State)
+	})
+
+	t.Run("Metadata Not Found", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET "+basePath+"/content", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(stubContent))
+		})
+		mux.HandleFunc("GET "+basePath, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(models.APIError{Status: http.StatusNotFound, Title: TitleNotFound})
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		version, err := api.GetVersion(context.Background(), stubGroupId, stubArtifactId, "1", nil)
+		assert.Nil(t, version)
+		assertAPIError(t, err, http.StatusNotFound, TitleNotFound)
+	})
+}
+
+func TestVersionsAPI_ResolveVersion(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		expression string
+		requested  string
+	}{
+		{name: "Latest Shorthand", expression: "latest", requested: "branch=latest"},
+		{name: "Branch Expression", expression: "branch=staging", requested: "branch=staging"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /groups/{groupId}/artifacts/{artifactId}/versions/{version}", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tc.requested, r.PathValue("version"))
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(models.ArtifactVersionMetadata{Version: "3.1.0", GlobalID: 42})
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+			api := apis.NewVersionsAPI(mockClient)
+
+			version, err := api.ResolveVersion(context.Background(), stubGroupId, stubArtifactId, tc.expression)
+			assert.NoError(t, err)
+			assert.Equal(t, "3.1.0", version)
+		})
+	}
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockErrorResponse := models.APIError{Status: http.StatusNotFound, Title: TitleNotFound}
+		server := setupMockServer(
+			t,
+			http.StatusNotFound,
+			mockErrorResponse,
+			"/groups/"+stubGroupId+"/artifacts/"+stubArtifactId+"/versions/branch=latest",
+			http.MethodGet,
+		)
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		version, err := api.ResolveVersion(context.Background(), stubGroupId, stubArtifactId, "latest")
+		assert.Empty(t, version)
+		assertAPIError(t, err, http.StatusNotFound, TitleNotFound)
+	})
+}
+
+func TestVersionsAPI_NextSemver(t *testing.T) {
+	newAPI := func(t *testing.T, latest string) *apis.VersionsAPI {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /groups/{groupId}/artifacts/{artifactId}/versions/{version}", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "branch=latest", r.PathValue("version"))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(models.ArtifactVersionMetadata{Version: latest, GlobalID: 42})
+		})
+		server := httptest.NewServer(mux)
+		t.Cleanup(server.Close)
+		return apis.NewVersionsAPI(&client.Client{BaseURL: server.URL, HTTPClient: server.Client()})
+	}
+
+	for _, tc := range []struct {
+		name string
+		bump models.SemverBump
+		next string
+	}{
+		{name: "Patch", bump: models.SemverPatch, next: "1.4.3"},
+		{name: "Minor", bump: models.SemverMinor, next: "1.5.0"},
+		{name: "Major", bump: models.SemverMajor, next: "2.0.0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			next, err := newAPI(t, "1.4.2").NextSemver(context.Background(), stubGroupId, stubArtifactId, tc.bump)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.next, next)
+		})
+	}
+
+	t.Run("Latest Is Not Semver", func(t *testing.T) {
+		next, err := newAPI(t, "2").NextSemver(context.Background(), stubGroupId, stubArtifactId, models.SemverPatch)
+		assert.Empty(t, next)
+		assert.ErrorIs(t, err, models.ErrNotSemver)
+		assert.Contains(t, err.Error(), `latest version of artifact test-group/test-artifact cannot be bumped: version "2"`)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockErrorResponse := models.APIError{Status: http.StatusNotFound, Title: TitleNotFound}
+		server := setupMockServer(t, http.StatusNotFound, mockErrorResponse, "", http.MethodGet)
+		defer server.Close()
+		api := apis.NewVersionsAPI(&client.Client{BaseURL: server.URL, HTTPClient: server.Client()})
+
+		next, err := api.NextSemver(context.Background(), stubGroupId, stubArtifactId, models.SemverPatch)
+		assert.Empty(t, next)
+		assertAPIError(t, err, http.StatusNotFound, TitleNotFound)
+	})
+}
+
+func TestVersionsAPI_CompareWithLatest(t *testing.T) {
+	newServer := func(t *testing.T, matches []models.ArtifactVersion) *httptest.Server {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /groups/{groupId}/artifacts/{artifactId}/versions/{version}", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "branch=latest", r.PathValue("version"))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(models.ArtifactVersionMetadata{Version: "2", GlobalID: 42})
+		})
+		mux.HandleFunc("POST /search/versions", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "true", r.URL.Query().Get("canonical"))
+			assert.Equal(t, stubGroupId, r.URL.Query().Get("groupId"))
+			assert.Equal(t, stubArtifactId, r.URL.Query().Get("artifactId"))
+
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, stubContent, string(body))
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(models.ArtifactVersionListResponse{Count: len(matches), Versions: matches})
+		})
+		return httptest.NewServer(mux)
+	}
+
+	t.Run("Identical Content", func(t *testing.T) {
+		server := newServer(t, []models.ArtifactVersion{
+			{Version: "2", GlobalID: 42, GroupID: stubGroupId, ArtifactID: stubArtifactId, ArtifactType: models.Avro},
+			{Version: "1", GlobalID: 41, GroupID: stubGroupId, ArtifactID: stubArtifactId, ArtifactType: models.Avro},
+		})
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		identical, err := api.CompareWithLatest(context.Background(), stubGroupId, stubArtifactId, []byte(stubContent), true)
+		assert.NoError(t, err)
+		assert.True(t, identical)
+	})
+
+	t.Run("Matches Older Version Only", func(t *testing.T) {
+		server := newServer(t, []models.ArtifactVersion{
+			{Version: "1", GlobalID: 41, GroupID: stubGroupId, ArtifactID: stubArtifactId, ArtifactType: models.Avro},
+		})
+		defer server.Close()
+
+		mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		api := apis.NewVersionsAPI(mockClient)
+
+		identical, err := api.CompareWithLatest(context.Background(), stubGroupId, stubArtifactId, []byte(stubContent), true)
+		assert.NoError(t, err)
+		assert.False(t, identical)
+	})
+}
+
 func TestVersionsAPI_GetArtifactVersionState(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockResponse := models.StateResponse{
@@ -2291,6 +3715,19 @@ func TestVersionsAPI_InputValidation(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "Artifact ID")
 	})
+
+	t.Run("Typed Field Error", func(t *testing.T) {
+		mockClient := &client.Client{}
+		api := apis.NewVersionsAPI(mockClient)
+
+		err := api.DeleteArtifactVersion(context.Background(), "test-group", "", "1.0.0")
+
+		var fieldErr *models.FieldValidationError
+		assert.True(t, errors.As(err, &fieldErr))
+		assert.Equal(t, "Artifact ID", fieldErr.Field)
+		assert.Equal(t, "", fieldErr.Value)
+		assert.True(t, errors.Is(err, apis.ErrInvalidInput))
+	})
 }
 
 func TestVersionsAPI_HTTPRequestErrors(t *testing.T) {
@@ -2334,6 +3771,148 @@ func TestVersionsAPI_HTTPRequestErrors(t *testing.T) {
 	})
 }
 
+func TestVersionsAPI_ErrorResponsesReuseConnections(t *testing.T) {
+	// Each body is larger than what the transport drains by itself on close, so a connection is only reused
+	// when the error path reads the body to the end.
+	padding := strings.Repeat(" ", 512<<10)
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests % 3 {
+		case 0:
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status": 404, "title": "Version not found"}` + padding))
+		case 1:
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte("conflict" + padding))
+		default:
+			w.Header().Set("X-Registry-ArtifactType", string(models.Json))
+			_, _ = w.Write([]byte(stubArtifactContent + padding))
+		}
+	}))
+	defer server.Close()
+
+	var observed []client.RequestMetrics
+	mockClient := client.NewClient(
+		server.URL,
+		client.WithHTTPClient(server.Client()),
+		client.WithMetricsObserver(func(m client.RequestMetrics) { observed = append(observed, m) }),
+		client.WithConnectionReuseMetrics(),
+	)
+	api := apis.NewVersionsAPI(mockClient)
+
+	params := &models.ArtifactReferenceParams{ExpectedArtifactType: models.Avro}
+	for range 12 {
+		_, _, err := api.GetArtifactVersionContentStream(context.Background(), stubGroupId, stubArtifactId, version, params)
+		assert.Error(t, err)
+		_, err = api.GetArtifactVersionContent(context.Background(), stubGroupId, stubArtifactId, version, params)
+		assert.Error(t, err)
+	}
+
+	newConnections := 0
+	for _, m := range observed {
+		newConnections += m.NewConnections
+	}
+	assert.Len(t, observed, 24)
+	assert.Equal(t, 1, newConnections)
+}
+
+func TestVersionsAPI_DraftWorkflow(t *testing.T) {
+	// A registry holding a single version that follows the DRAFT -> ENABLED lifecycle.
+	var (
+		state    models.State
+		content  string
+		comments []string
+	)
+	versionPath := "/groups/" + stubGroupId + "/artifacts/" + stubArtifactId + "/versions/" + version
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /groups/{group}/artifacts/{artifact}/versions", func(w http.ResponseWriter, r *http.Request) {
+		var request models.CreateVersionRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.True(t, request.IsDraft)
+		state, content = models.StateDraft, request.Content.Content
+		_ = json.NewEncoder(w).Encode(models.ArtifactVersionDetailed{ArtifactVersion: models.ArtifactVersion{
+			Version:      request.Version,
+			ArtifactType: models.Avro,
+			State:        state,
+		}})
+	})
+	mux.HandleFunc("GET "+versionPath+"/state", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(models.StateResponse{State: state})
+	})
+	mux.HandleFunc("PUT "+versionPath+"/content", func(w http.ResponseWriter, r *http.Request) {
+		var request models.CreateContentRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		content = request.Content
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST "+versionPath+"/comments", func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		comments = append(comments, request["value"])
+		_ = json.NewEncoder(w).Encode(models.ArtifactComment{CommentID: strconv.Itoa(len(comments)), Value: request["value"]})
+	})
+	mux.HandleFunc("PUT "+versionPath+"/state", func(w http.ResponseWriter, r *http.Request) {
+		var request models.StateRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		state = request.State
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mockClient := &client.Client{BaseURL: server.URL, HTTPClient: server.Client()}
+	api := apis.NewVersionsAPI(mockClient)
+	ctx := context.Background()
+
+	draft, err := api.CreateDraft(ctx, stubGroupId, stubArtifactId, &models.CreateVersionRequest{
+		Version: version,
+		Content: models.CreateContentRequest{Content: stubArtifactContent, ContentType: "application/json"},
+	})
+	assert.NoError(t, err)
+	if assert.NotNil(t, draft) {
+		assert.Equal(t, models.StateDraft, draft.State)
+	}
+
+	updated := `{"type": "record", "name": "Test", "fields": [{"name": "field1", "type": "long"}]}`
+	err = api.UpdateDraftContent(ctx, stubGroupId, stubArtifactId, version, &models.CreateContentRequest{
+		Content:     updated,
+		ContentType: "application/json",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, updated, content)
+
+	comment, err := api.AddDraftComment(ctx, stubGroupId, stubArtifactId, version, "field1 should be a long")
+	assert.NoError(t, err)
+	if assert.NotNil(t, comment) {
+		assert.Equal(t, "1", comment.CommentID)
+	}
+
+	assert.NoError(t, api.PublishDraft(ctx, stubGroupId, stubArtifactId, version, false))
+	assert.Equal(t, models.StateEnabled, state)
+
+	// Once published the version is no longer a draft.
+	err = api.UpdateDraftContent(ctx, stubGroupId, stubArtifactId, version, &models.CreateContentRequest{
+		Content:     stubArtifactContent,
+		ContentType: "application/json",
+	})
+	assert.ErrorIs(t, err, models.ErrNotDraft)
+	assert.Equal(t, updated, content)
+	assert.ErrorIs(t, api.PublishDraft(ctx, stubGroupId, stubArtifactId, version, false), models.ErrNotDraft)
+	_, err = api.AddDraftComment(ctx, stubGroupId, stubArtifactId, version, "too late")
+	assert.ErrorIs(t, err, models.ErrNotDraft)
+	assert.Len(t, comments, 1)
+
+	_, err = api.CreateDraft(ctx, stubGroupId, stubArtifactId, &models.CreateVersionRequest{
+		Content: models.CreateContentRequest{Content: stubArtifactContent, ContentType: "application/json"},
+		State:   models.StateEnabled,
+	})
+	assert.ErrorIs(t, err, models.ErrDraftStateConflict)
+}
+
 /***********************/
 /***** Integration *****/
 /***********************/
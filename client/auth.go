@@ -0,0 +1,257 @@
+package client
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mollie/go-apicurio-registry/internal/plumbing"
+	"github.com/pkg/errors"
+)
+
+// defaultTokenRefreshSkew is how long before its expiry a cached token is refreshed by default.
+const defaultTokenRefreshSkew = 30 * time.Second
+
+// defaultTokenRefreshTimeout bounds a token refresh by default.
+const defaultTokenRefreshTimeout = 30 * time.Second
+
+// TokenSource supplies bearer tokens for authenticating requests, e.g. from Vault, AWS STS, Azure AD
+// or an OAuth2 client. Token returns the token and its expiry; a zero expiry means the token is not
+// cached and Token is called for every request.
+type TokenSource interface {
+	Token(ctx context.Context) (string, time.Time, error)
+}
+
+// TokenSourceFunc adapts a function to a TokenSource.
+type TokenSourceFunc func(ctx context.Context) (string, time.Time, error)
+
+// Token calls f(ctx).
+func (f TokenSourceFunc) Token(ctx context.Context) (string, time.Time, error) {
+	return f(ctx)
+}
+
+// StaticTokenSource returns a TokenSource that always returns token.
+func StaticTokenSource(token string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, time.Time, error) {
+		return token, time.Time{}, nil
+	})
+}
+
+// WithTokenSource authenticates every request with a bearer token obtained from ts. Tokens are cached
+// until shortly before their expiry, and the cache is cleared when the registry answers 401 so the next
+// request fetches a fresh token. It takes precedence over WithAuthHeader, WithBasicAuth and WithAuthToken.
+func WithTokenSource(ts TokenSource) Option {
+	return func(c *Client) {
+		c.tokenSource = &cachedTokenSource{source: ts}
+	}
+}
+
+// WithTokenRefreshSkew sets how long before its expiry a cached token is refreshed (default 30s).
+// Refreshing early keeps requests from being sent with a token that expires in flight.
+func WithTokenRefreshSkew(skew time.Duration) Option {
+	return func(c *Client) {
+		c.tokenRefreshSkew = skew
+	}
+}
+
+// WithTokenRefreshTimeout bounds how long a refresh of the token source may take (default 30s). A refresh is
+// shared by every request waiting for the token and is not cancelled with the request that started it, so
+// without a bound a hanging token endpoint would block every request.
+func WithTokenRefreshTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.tokenRefreshTimeout = timeout
+	}
+}
+
+// TokenRefreshCallback is called after every refresh of the token source with the expiry of the new token,
+// or with the error of a failed refresh.
+type TokenRefreshCallback func(expiry time.Time, err error)
+
+// WithOnTokenRefresh calls fn whenever the token source is asked for a new token, successful or not, so
+// operators can audit authentication and alert when refreshes start failing. It has no effect without a
+// token source. The callback runs before the requests waiting for the token proceed; a panicking callback
+// is recovered and logged.
+func WithOnTokenRefresh(fn TokenRefreshCallback) Option {
+	return func(c *Client) {
+		c.onTokenRefresh = fn
+	}
+}
+
+// TokenProvider supplies a bearer token for a request, e.g. from Vault, IMDS or an OIDC library.
+type TokenProvider func(ctx context.Context) (string, error)
+
+// WithTokenProvider authenticates every request with a bearer token returned by provider, which is called
+// immediately before each request. A provider error fails the request before it is sent. Use WithTokenSource
+// instead for tokens with a known expiry, so they are cached until shortly before they expire.
+// Like WithTokenSource, it takes precedence over WithAuthHeader, WithBasicAuth and WithAuthToken.
+func WithTokenProvider(provider TokenProvider) Option {
+	return WithTokenSource(TokenSourceFunc(func(ctx context.Context) (string, time.Time, error) {
+		token, err := provider(ctx)
+		if err != nil {
+			return "", time.Time{}, errors.Wrap(err, "token provider")
+		}
+		return token, time.Time{}, nil
+	}))
+}
+
+// cachedTokenSource caches the token of a TokenSource until it expires. Concurrent callers that find
+// the cache empty or stale share a single in-flight refresh instead of each calling the source.
+type cachedTokenSource struct {
+	source TokenSource
+
+	mu      sync.Mutex
+	token   string
+	expiry  time.Time
+	refresh *tokenRefresh
+}
+
+// tokenRefresh is a call to the token source shared by every caller waiting for it.
+type tokenRefresh struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// get returns the cached token when it is valid for longer than skew, or waits for a refresh, which is given
+// at most timeout. onRefresh, when set, is called with the outcome of a refresh started by this call.
+func (s *cachedTokenSource) get(
+	ctx context.Context,
+	skew, timeout time.Duration,
+	onRefresh TokenRefreshCallback,
+) (string, error) {
+	s.mu.Lock()
+	if s.token != "" && time.Now().Add(skew).Before(s.expiry) {
+		token := s.token
+		s.mu.Unlock()
+		return token, nil
+	}
+	r := s.refresh
+	if r == nil {
+		r = &tokenRefresh{done: make(chan struct{})}
+		s.refresh = r
+		// The refresh outlives the caller that started it so that cancelling one request does not
+		// fail every other request waiting for the same token; its own timeout ends a hanging refresh.
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		go func() {
+			defer cancel()
+			s.fetch(fetchCtx, r, onRefresh)
+		}()
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	if r.err != nil {
+		return "", errors.Wrap(r.err, "failed to obtain token")
+	}
+	return r.token, nil
+}
+
+// fetch calls the source, caches a successful result and releases the callers waiting for r.
+func (s *cachedTokenSource) fetch(ctx context.Context, r *tokenRefresh, onRefresh TokenRefreshCallback) {
+	token, expiry, err := s.source.Token(ctx)
+
+	s.mu.Lock()
+	if err == nil {
+		s.token, s.expiry = token, expiry
+	}
+	s.refresh = nil
+	s.mu.Unlock()
+
+	if onRefresh != nil {
+		if hookErr := plumbing.InvokeHook("TokenRefreshCallback", func() { onRefresh(expiry, err) }); hookErr != nil {
+			log.Printf("apicurio: %v", hookErr)
+		}
+	}
+
+	r.token, r.err = token, err
+	close(r.done)
+}
+
+// invalidate drops the cached token.
+func (s *cachedTokenSource) invalidate() {
+	s.mu.Lock()
+	s.token, s.expiry = "", time.Time{}
+	s.mu.Unlock()
+}
+
+// authorize sets the Authorization header from the token source or the static auth header.
+func (c *Client) authorize(req *http.Request) error {
+	if c.tokenSource != nil {
+		skew := c.tokenRefreshSkew
+		if skew <= 0 {
+			skew = defaultTokenRefreshSkew
+		}
+		timeout := c.tokenRefreshTimeout
+		if timeout <= 0 {
+			timeout = defaultTokenRefreshTimeout
+		}
+		token, err := c.tokenSource.get(req.Context(), skew, timeout, c.onTokenRefresh)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+	if c.AuthHeader != "" {
+		req.Header.Set("Authorization", c.AuthHeader)
+	}
+	return nil
+}
+
+// FileTokenSource returns a TokenSource that reads the token from the file at path, such as a
+// Kubernetes projected service account token. The file is stat-ed on every request and re-read
+// whenever its modification time or size changes, so rotated tokens are picked up without a restart.
+func FileTokenSource(path string) TokenSource {
+	return &fileTokenSource{path: path}
+}
+
+// WithTokenFile authenticates every request with the bearer token stored in the file at path.
+// See FileTokenSource.
+func WithTokenFile(path string) Option {
+	return WithTokenSource(FileTokenSource(path))
+}
+
+// fileTokenSource reads a token from a file that may be rotated on disk.
+type fileTokenSource struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+// Token returns the token in the file, re-reading it when the file changed since the last read.
+func (s *fileTokenSource) Token(context.Context) (string, time.Time, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "failed to stat token file")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return s.token, time.Time{}, nil
+	}
+
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "failed to read token file")
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", time.Time{}, errors.Errorf("token file %s is empty", s.path)
+	}
+
+	s.token, s.modTime, s.size = token, info.ModTime(), info.Size()
+	return token, time.Time{}, nil
+}
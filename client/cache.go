@@ -0,0 +1,158 @@
+package client
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/mollie/go-apicurio-registry/internal/lru"
+	"github.com/mollie/go-apicurio-registry/internal/plumbing"
+	"github.com/mollie/go-apicurio-registry/models"
+)
+
+// artifactTypesCache holds the artifact types supported by the registry the client talks to.
+type artifactTypesCache struct {
+	mu    sync.RWMutex
+	types map[models.ArtifactType]bool
+}
+
+// cachedArtifactTypes returns a copy of the cached set of supported artifact types and whether the cache is populated.
+func (c *Client) cachedArtifactTypes() (map[models.ArtifactType]bool, bool) {
+	c.artifactTypes.mu.RLock()
+	defer c.artifactTypes.mu.RUnlock()
+
+	if c.artifactTypes.types == nil {
+		return nil, false
+	}
+	types := make(map[models.ArtifactType]bool, len(c.artifactTypes.types))
+	for t := range c.artifactTypes.types {
+		types[t] = true
+	}
+	return types, true
+}
+
+// cacheArtifactTypes stores the set of artifact types supported by the registry.
+func (c *Client) cacheArtifactTypes(types []models.ArtifactType) {
+	set := make(map[models.ArtifactType]bool, len(types))
+	for _, t := range types {
+		set[t] = true
+	}
+
+	c.artifactTypes.mu.Lock()
+	c.artifactTypes.types = set
+	c.artifactTypes.mu.Unlock()
+}
+
+// invalidateArtifactTypes clears the cached set of supported artifact types.
+func (c *Client) invalidateArtifactTypes() {
+	c.artifactTypes.mu.Lock()
+	c.artifactTypes.types = nil
+	c.artifactTypes.mu.Unlock()
+}
+
+// WithContentCache enables an LRU cache of up to size artifact contents keyed by global ID.
+// The content behind a global ID can still change while its version is a DRAFT, and a deleted version's entry
+// lingers until it is evicted; WithEventDrivenCacheInvalidation evicts the entries of artifacts reported updated
+// or deleted.
+func WithContentCache(size int) Option {
+	return func(c *Client) {
+		c.contentCache = lru.New[int64, plumbing.CachedContent](size)
+	}
+}
+
+// contentCacheEnabled reports whether the client caches content by global ID.
+func (c *Client) contentCacheEnabled() bool {
+	return c.contentCache != nil
+}
+
+// cachedContent returns the cached content for a global ID.
+func (c *Client) cachedContent(globalID int64) (plumbing.CachedContent, bool) {
+	if c.contentCache == nil {
+		return plumbing.CachedContent{}, false
+	}
+	return c.contentCache.Get(globalID)
+}
+
+// cacheContent stores content for a global ID when the content cache is enabled.
+func (c *Client) cacheContent(globalID int64, content plumbing.CachedContent) {
+	if c.contentCache != nil {
+		c.contentCache.Add(globalID, content)
+	}
+}
+
+// evictContent removes the cached content for a global ID.
+func (c *Client) evictContent(globalID int64) {
+	if c.contentCache != nil {
+		c.contentCache.Remove(globalID)
+	}
+}
+
+// evictArtifact removes the cached content of every version of an artifact and returns the number of
+// entries removed. Entries whose group or artifact the registry did not report cannot be matched and stay cached.
+func (c *Client) evictArtifact(groupID, artifactID string) int {
+	if c.contentCache == nil {
+		return 0
+	}
+	return c.contentCache.RemoveFunc(func(_ int64, content plumbing.CachedContent) bool {
+		return content.GroupID == groupID && content.ArtifactID == artifactID
+	})
+}
+
+// WithEventDrivenCacheInvalidation evicts cached content, including that of the apis.SchemaResolvers built on
+// the client, when the registry's event stream reports that an artifact was updated or deleted, so caches stay
+// fresh without guessing a TTL. Events are applied while a subscription from SystemAPI.SubscribeEvents is open,
+// before they are delivered to the subscriber; SystemAPI.RunCacheInvalidation keeps such a subscription open for
+// callers that do not need the events.
+func WithEventDrivenCacheInvalidation() Option {
+	return func(c *Client) {
+		c.eventInvalidation = true
+	}
+}
+
+// invalidateForEvent evicts the cached content an event makes stale. It does nothing unless
+// WithEventDrivenCacheInvalidation is set.
+func (c *Client) invalidateForEvent(event models.RegistryEvent) {
+	if !c.eventInvalidation {
+		return
+	}
+	switch event.Type {
+	case models.EventArtifactUpdated, models.EventArtifactDeleted:
+		c.evictArtifact(event.GroupID, event.ArtifactID)
+	}
+
+	c.eventListeners.mu.Lock()
+	listeners := c.eventListeners.fns
+	c.eventListeners.mu.Unlock()
+	for _, listener := range listeners {
+		(*listener)(event)
+	}
+}
+
+// eventListeners holds the functions that evict other caches built on the client, such as those of
+// apis.SchemaResolver, when event-driven invalidation applies an event.
+type eventListeners struct {
+	mu  sync.Mutex
+	fns []*func(event models.RegistryEvent)
+}
+
+// addInvalidationListener registers listener to be called with every event invalidateForEvent applies and
+// returns a function that unregisters it. Without WithEventDrivenCacheInvalidation no event is ever applied,
+// so nothing is registered.
+func (c *Client) addInvalidationListener(listener func(event models.RegistryEvent)) (remove func()) {
+	if !c.eventInvalidation {
+		return func() {}
+	}
+
+	fn := &listener
+	c.eventListeners.mu.Lock()
+	// Appending to a clipped slice copies it, so invalidateForEvent can range over its snapshot without the lock.
+	c.eventListeners.fns = append(slices.Clip(c.eventListeners.fns), fn)
+	c.eventListeners.mu.Unlock()
+
+	return func() {
+		c.eventListeners.mu.Lock()
+		// Deleting from a copy leaves the snapshots invalidateForEvent may be ranging over untouched.
+		fns := slices.Clone(c.eventListeners.fns)
+		c.eventListeners.fns = slices.DeleteFunc(fns, func(f *func(event models.RegistryEvent)) bool { return f == fn })
+		c.eventListeners.mu.Unlock()
+	}
+}
@@ -1,13 +1,23 @@
 package client
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"io"
 	"log"
 	"net"
 	"net/http"
 	"os"
+	"reflect"
 	"time"
 
 	retryablehttp "github.com/hashicorp/go-retryablehttp"
+	"github.com/mollie/go-apicurio-registry/internal/lru"
+	"github.com/mollie/go-apicurio-registry/internal/plumbing"
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/pkg/errors"
 )
 
 // Client is a reusable HTTP client for the SDK.
@@ -15,8 +25,67 @@ type Client struct {
 	BaseURL    string
 	HTTPClient *http.Client
 	AuthHeader string
+
+	// MaxConcurrency caps the number of concurrent requests issued by fan-out helpers.
+	// Zero means DefaultMaxConcurrency.
+	MaxConcurrency int
+
+	// MaxURLLength is the longest search URL the client sends; longer searches fail with models.ErrQueryTooLong.
+	// Zero means no limit.
+	MaxURLLength int
+
+	// MaxElapsedTime bounds the total duration of helpers that page through results or fan out
+	// over many resources, and of the retries of a single request. Zero means no budget.
+	MaxElapsedTime time.Duration
+
+	// MaxItemsFetched caps the number of items collected by helpers that gather whole histories, such as
+	// AllArtifactVersions. Zero means no cap.
+	MaxItemsFetched int
+
+	// DefaultIfExists is the IfExists policy used by CreateArtifact when the call does not set one.
+	DefaultIfExists models.IfExistsType
+
+	// VersionStrategy labels the versions created by CreateArtifactVersion without an explicit version.
+	// Empty means models.VersionAuto.
+	VersionStrategy models.VersionStrategy
+
+	// DefaultBranch is the branch used by branch operations and branch content fetches called without a branch ID.
+	DefaultBranch string
+
+	// DefaultGroupLabels are merged into the labels of every group created with CreateGroup.
+	DefaultGroupLabels map[string]string
+
+	timeout              time.Duration
+	disableCompression   bool
+	compressionThreshold int
+	metricsObserver      MetricsObserver
+	connReuseMetrics     bool
+	slaThreshold         time.Duration
+	slaCallback          SLACallback
+	transportMiddlewares []TransportMiddleware
+	requestEditor        RequestEditor
+	statusHook           StatusHook
+	retry                retryPolicy
+	artifactTypes        artifactTypesCache
+	contentCache         *lru.Cache[int64, plumbing.CachedContent]
+	eventInvalidation    bool
+	eventListeners       eventListeners
+	jsonUseNumber        bool
+	contentTypes         map[models.ArtifactType]string
+	canonicalizeOnCreate bool
+	minifyJSONOnWrite    bool
+	validateContent      bool
+	artifactLocks        *artifactLocks
+	tokenSource          *cachedTokenSource
+	tokenRefreshSkew     time.Duration
+	tokenRefreshTimeout  time.Duration
+	onTokenRefresh       TokenRefreshCallback
+	modelDecoders        map[reflect.Type]plumbing.ModelDecoder
 }
 
+// DefaultMaxConcurrency is the fan-out limit used when Client.MaxConcurrency is not set.
+const DefaultMaxConcurrency = 4
+
 // Option is a functional option for configuring the Client.
 type Option func(*Client)
 
@@ -53,6 +122,121 @@ func WithAuthHeader(authHeader string) Option {
 	}
 }
 
+// WithAuthToken is an option for authenticating with a bearer token.
+func WithAuthToken(token string) Option {
+	return WithAuthHeader("Bearer " + token)
+}
+
+// WithBasicAuth is an option for authenticating with HTTP Basic authentication.
+func WithBasicAuth(username, password string) Option {
+	credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return WithAuthHeader("Basic " + credentials)
+}
+
+// WithTimeout sets the overall timeout of every request. It is applied after all other options,
+// so it also applies to a client supplied through WithHTTPClient or WithRetryableHTTP; such a client
+// is copied rather than modified.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithCompressionNegotiation controls whether GET requests explicitly ask the registry for
+// gzip-compressed responses. Compressed responses are decoded transparently. Enabled by default.
+func WithCompressionNegotiation(enabled bool) Option {
+	return func(c *Client) {
+		c.disableCompression = !enabled
+	}
+}
+
+// DefaultRequestCompressionThreshold is the body size from which requests are compressed when request
+// compression is enabled without an explicit threshold.
+const DefaultRequestCompressionThreshold = 8 * 1024
+
+// WithRequestCompression gzip-encodes the body of POST and PUT requests of at least threshold bytes and
+// sets Content-Encoding: gzip, e.g. for uploads of large Protobuf or OpenAPI artifacts. A threshold of
+// zero or less means DefaultRequestCompressionThreshold. Bodies that already carry a Content-Encoding,
+// bodies of unknown length and ZIP archives are sent as-is.
+func WithRequestCompression(threshold int) Option {
+	return func(c *Client) {
+		if threshold <= 0 {
+			threshold = DefaultRequestCompressionThreshold
+		}
+		c.compressionThreshold = threshold
+	}
+}
+
+// WithMaxConcurrency sets the maximum number of concurrent requests issued by helpers
+// that fan out over groups, artifacts or versions.
+func WithMaxConcurrency(n int) Option {
+	return func(c *Client) {
+		c.MaxConcurrency = n
+	}
+}
+
+// WithMaxURLLength sets the longest search URL the client sends. Longer searches fail with
+// models.ErrQueryTooLong without a request being made, instead of being rejected by a proxy with 414 URI Too
+// Long. By default search URLs are not limited.
+func WithMaxURLLength(n int) Option {
+	return func(c *Client) {
+		c.MaxURLLength = n
+	}
+}
+
+// WithMaxElapsedTime sets an overall time budget for pagination helpers and bulk operations such as
+// AllArtifactsInGroup or ApplyRuleToAllArtifacts. When the budget is exhausted they stop and return the
+// partial result together with models.ErrBudgetExceeded. A retry of a single request that could not start
+// within the budget is not made, and the last response is returned instead.
+func WithMaxElapsedTime(d time.Duration) Option {
+	return func(c *Client) {
+		c.MaxElapsedTime = d
+	}
+}
+
+// WithMaxItemsFetched caps the number of versions AllArtifactVersions and ListAllVersionsInGroup collect.
+// Once n items have been fetched they stop and return the partial result together with models.ErrTruncated.
+func WithMaxItemsFetched(n int) Option {
+	return func(c *Client) {
+		c.MaxItemsFetched = n
+	}
+}
+
+// WithDefaultIfExists sets the IfExists policy used by CreateArtifact calls that pass nil params
+// or leave IfExists unset. An IfExists set on the call always wins.
+func WithDefaultIfExists(ifExists models.IfExistsType) Option {
+	return func(c *Client) {
+		c.DefaultIfExists = ifExists
+	}
+}
+
+// WithVersionStrategy sets how CreateArtifactVersion labels versions created without an explicit version:
+// models.VersionAuto leaves it to the registry, models.VersionTimestamp uses the UTC creation time and
+// models.VersionSemverBump bumps the patch number of the latest version. A version set on the request always wins.
+func WithVersionStrategy(strategy models.VersionStrategy) Option {
+	return func(c *Client) {
+		c.VersionStrategy = strategy
+	}
+}
+
+// WithDefaultBranch sets the branch used by branch operations and branch content fetches that are called
+// with an empty branch ID, and by apis.VersionsAPI.GetArtifactContent called with an empty selector, e.g. a
+// "main" branch in environment-promotion tooling. Deleting or updating a branch always requires an explicit
+// branch ID.
+func WithDefaultBranch(branchID string) Option {
+	return func(c *Client) {
+		c.DefaultBranch = branchID
+	}
+}
+
+// WithDefaultGroupLabels sets labels added to every group created with CreateGroup, e.g. a "created-by"
+// label for governance tooling. A label passed to the call with the same key always wins.
+func WithDefaultGroupLabels(labels map[string]string) Option {
+	return func(c *Client) {
+		c.DefaultGroupLabels = labels
+	}
+}
+
 // defaultHTTPClient provides a preconfigured HTTP client for the SDK.
 func defaultHTTPClient() *http.Client {
 	return &http.Client{
@@ -69,6 +253,7 @@ func defaultHTTPClient() *http.Client {
 	}
 }
 
+// NewClient creates a new Client for baseURL configured with the given functional options.
 func NewClient(baseURL string, options ...Option) *Client {
 	client := &Client{
 		BaseURL:    baseURL,
@@ -80,14 +265,181 @@ func NewClient(baseURL string, options ...Option) *Client {
 		opt(client)
 	}
 
+	if client.timeout > 0 && client.HTTPClient != nil {
+		// Copy the HTTP client so that a client supplied through WithHTTPClient is left untouched.
+		httpClient := *client.HTTPClient
+		httpClient.Timeout = client.timeout
+		client.HTTPClient = &httpClient
+	}
+	client.wrapTransport()
+
 	return client
 }
 
 // Do perform an HTTP request with optional authentication.
+// Transient failures are retried according to the retry options; see WithMaxRetries.
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
-	if c.AuthHeader != "" {
-		req.Header.Set("Authorization", c.AuthHeader)
+	defer c.checkSLA(req, time.Now())
+
+	if c.modelDecoders != nil {
+		req = req.WithContext(plumbing.WithModelDecoders(req.Context(), c.modelDecoders))
+	}
+	if c.statusHook != nil {
+		req = req.WithContext(plumbing.WithStatusHook(req.Context(), plumbing.StatusHook(c.statusHook)))
+	}
+	if err := c.authorize(req); err != nil {
+		return nil, err
+	}
+	if ct := req.Header.Get("Content-Type"); ct == "" || ct == "*/*" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if etag := ifMatchFromContext(req.Context()); etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
+	if !c.disableCompression && req.Method == http.MethodGet && req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	if err := c.compressBody(req); err != nil {
+		return nil, err
+	}
+	if err := c.editRequest(req); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(req)
+		if c.tokenSource != nil && resp != nil && resp.StatusCode == http.StatusUnauthorized {
+			c.tokenSource.invalidate()
+		}
+		// A streamed body was consumed by the attempt and cannot be sent again, so its outcome is final.
+		if attempt >= c.retry.maxRetries || !canRewindBody(req) || !c.retry.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+		// A wait the caller cannot afford ends the retries with the last outcome, e.g. a 429 whose
+		// Retry-After tells the caller when to come back.
+		wait := c.retry.delay(attempt+1, resp)
+		if !c.canWait(req.Context(), start, wait) {
+			return resp, err
+		}
+		if resp != nil {
+			plumbing.DrainBody(resp)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if err := rewindBody(req); err != nil {
+			return nil, err
+		}
+		// A 401 above dropped the cached token, so the next attempt must not resend the stale one.
+		if c.tokenSource != nil {
+			if err := c.authorize(req); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// canWait reports whether a retry after wait still starts before the deadline of ctx and within the
+// client's MaxElapsedTime, counted from start.
+func (c *Client) canWait(ctx context.Context, start time.Time, wait time.Duration) bool {
+	next := time.Now().Add(wait)
+	if deadline, ok := ctx.Deadline(); ok && next.After(deadline) {
+		return false
 	}
-	req.Header.Set("Content-Type", "application/json")
-	return c.HTTPClient.Do(req)
+	return c.MaxElapsedTime <= 0 || next.Sub(start) <= c.MaxElapsedTime
+}
+
+// send executes a single attempt of the request.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	var conns connCounter
+	resp, err := c.HTTPClient.Do(c.traceConnections(req, &conns))
+	c.observe(req, resp, err, start, conns)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeResponse(resp)
+}
+
+// decodeResponse transparently decompresses a gzip-encoded response body.
+func decodeResponse(resp *http.Response) (*http.Response, error) {
+	if resp.Header.Get("Content-Encoding") != "gzip" {
+		return resp, nil
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		plumbing.DrainBody(resp)
+		return nil, err
+	}
+
+	resp.Body = &gzipReadCloser{Reader: gz, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// compressBody gzip-encodes the body of a POST or PUT request that reaches the compression threshold.
+// The body is buffered so it can be replayed on retries. Bodies of unknown length are streamed and
+// left alone, as are ZIP archives, which are already compressed.
+func (c *Client) compressBody(req *http.Request) error {
+	if c.compressionThreshold <= 0 || (req.Method != http.MethodPost && req.Method != http.MethodPut) {
+		return nil
+	}
+	if req.Body == nil || req.Body == http.NoBody || req.Header.Get("Content-Encoding") != "" {
+		return nil
+	}
+	if req.ContentLength <= 0 || req.Header.Get("Content-Type") == "application/zip" {
+		return nil
+	}
+	if req.ContentLength < int64(c.compressionThreshold) {
+		return nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return errors.Wrap(err, "failed to read request body")
+	}
+
+	if len(body) >= c.compressionThreshold {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		if _, err := gz.Write(body); err != nil {
+			return errors.Wrap(err, "failed to compress request body")
+		}
+		if err := gz.Close(); err != nil {
+			return errors.Wrap(err, "failed to compress request body")
+		}
+		body = buf.Bytes()
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	req.ContentLength = int64(len(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.Body, _ = req.GetBody()
+	return nil
+}
+
+// gzipReadCloser closes both the gzip reader and the underlying response body.
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (g *gzipReadCloser) Close() error {
+	_ = g.Reader.Close()
+	return g.body.Close()
 }
@@ -1,12 +1,27 @@
 package client_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/mollie/go-apicurio-registry/client"
+	"github.com/mollie/go-apicurio-registry/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -35,6 +50,108 @@ func TestNewClient_WithAuthHeader(t *testing.T) {
 	assert.Equal(t, "Bearer test-token", c.AuthHeader)
 }
 
+func TestNewClient_WithTimeout(t *testing.T) {
+	customHTTPClient := &http.Client{}
+
+	c := client.NewClient(
+		"https://example.com",
+		client.WithTimeout(5*time.Second),
+		client.WithHTTPClient(customHTTPClient),
+	)
+
+	assert.NotSame(t, customHTTPClient, c.HTTPClient)
+	assert.Equal(t, 5*time.Second, c.HTTPClient.Timeout)
+	assert.Zero(t, customHTTPClient.Timeout, "the supplied HTTP client must not be modified")
+}
+
+func TestNewClientFromConfig(t *testing.T) {
+	t.Run("Equivalent To Options", func(t *testing.T) {
+		var headers []http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers = append(headers, r.Header.Clone())
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		fromConfig, err := client.NewClientFromConfig(client.Config{
+			BaseURL:            server.URL,
+			AuthToken:          "test-token",
+			Timeout:            5 * time.Second,
+			MaxConcurrency:     8,
+			MaxURLLength:       1024,
+			DisableCompression: true,
+		})
+		assert.NoError(t, err)
+
+		fromOptions := client.NewClient(
+			server.URL,
+			client.WithAuthToken("test-token"),
+			client.WithTimeout(5*time.Second),
+			client.WithMaxConcurrency(8),
+			client.WithMaxURLLength(1024),
+			client.WithCompressionNegotiation(false),
+		)
+
+		assert.Equal(t, fromOptions.BaseURL, fromConfig.BaseURL)
+		assert.Equal(t, fromOptions.AuthHeader, fromConfig.AuthHeader)
+		assert.Equal(t, fromOptions.MaxConcurrency, fromConfig.MaxConcurrency)
+		assert.Equal(t, fromOptions.MaxURLLength, fromConfig.MaxURLLength)
+		assert.Equal(t, fromOptions.HTTPClient.Timeout, fromConfig.HTTPClient.Timeout)
+
+		for _, c := range []*client.Client{fromConfig, fromOptions} {
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			assert.NoError(t, err)
+			resp, err := c.Do(req)
+			assert.NoError(t, err)
+			resp.Body.Close()
+		}
+
+		assert.Len(t, headers, 2)
+		assert.Equal(t, "Bearer test-token", headers[0].Get("Authorization"))
+		assert.Equal(t, headers[0].Get("Authorization"), headers[1].Get("Authorization"))
+		assert.Equal(t, headers[0].Get("Accept-Encoding"), headers[1].Get("Accept-Encoding"))
+	})
+
+	t.Run("Basic Auth", func(t *testing.T) {
+		var authorization string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		c, err := client.NewClientFromConfig(client.Config{
+			BaseURL:   server.URL,
+			BasicAuth: &client.BasicAuth{Username: "registry", Password: "s3cr3t:pass"},
+		})
+		assert.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, "Basic cmVnaXN0cnk6czNjcjN0OnBhc3M=", authorization)
+	})
+
+	t.Run("Basic Auth With Auth Token", func(t *testing.T) {
+		c, err := client.NewClientFromConfig(client.Config{
+			BaseURL:   "https://example.com",
+			AuthToken: "test-token",
+			BasicAuth: &client.BasicAuth{Username: "registry", Password: "secret"},
+		})
+		assert.ErrorContains(t, err, "mutually exclusive")
+		assert.Nil(t, c)
+	})
+
+	t.Run("Empty Base URL", func(t *testing.T) {
+		c, err := client.NewClientFromConfig(client.Config{})
+		assert.Error(t, err)
+		assert.Nil(t, c)
+	})
+}
+
 func TestClient_Do_WithAuthHeader(t *testing.T) {
 	// Create a test HTTP server
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -74,3 +191,866 @@ func TestClient_Do_WithoutAuthHeader(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 }
+
+func TestClient_Do_CompressionNegotiation(t *testing.T) {
+	t.Run("Gzip Response Decoded", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+
+			w.Header().Set("Content-Encoding", "gzip")
+			w.WriteHeader(http.StatusOK)
+			gz := gzip.NewWriter(w)
+			_, err := gz.Write([]byte(`{"count": 1}`))
+			assert.NoError(t, err)
+			assert.NoError(t, gz.Close())
+		})
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		c := client.NewClient(server.URL)
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"count": 1}`, string(body))
+		assert.Empty(t, resp.Header.Get("Content-Encoding"))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.NotEqual(t, "gzip", r.Header.Get("Accept-Encoding"))
+			w.WriteHeader(http.StatusOK)
+		})
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		c := client.NewClient(
+			server.URL,
+			client.WithHTTPClient(&http.Client{Transport: &http.Transport{DisableCompression: true}}),
+			client.WithCompressionNegotiation(false),
+		)
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestClient_Do_RequestCompression(t *testing.T) {
+	type received struct {
+		encoding string
+		body     string
+	}
+	var requests []received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reader io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			assert.NoError(t, err)
+			reader = gz
+		}
+		body, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		requests = append(requests, received{encoding: r.Header.Get("Content-Encoding"), body: string(body)})
+		if len(requests) == 1 && r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := client.NewClientFromConfig(client.Config{
+		BaseURL:              server.URL,
+		CompressRequests:     true,
+		CompressionThreshold: 1024,
+		Retry:                &client.RetryConfig{MaxRetries: 1, BaseDelay: time.Millisecond},
+	})
+	assert.NoError(t, err)
+
+	send := func(method, body string) {
+		req, err := http.NewRequest(method, server.URL, strings.NewReader(body))
+		assert.NoError(t, err)
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	t.Run("Large Body Compressed", func(t *testing.T) {
+		requests = nil
+		large := `{"content": "` + strings.Repeat("message Foo { string bar = 1; } ", 100) + `"}`
+		send(http.MethodPost, large)
+
+		if assert.Len(t, requests, 1) {
+			assert.Equal(t, "gzip", requests[0].encoding)
+			assert.Equal(t, large, requests[0].body)
+		}
+	})
+
+	t.Run("Compressed Body Replayed On Retry", func(t *testing.T) {
+		requests = nil
+		large := strings.Repeat("x", 2048)
+		send(http.MethodPut, large)
+
+		if assert.Len(t, requests, 2) {
+			for _, request := range requests {
+				assert.Equal(t, "gzip", request.encoding)
+				assert.Equal(t, large, request.body)
+			}
+		}
+	})
+
+	t.Run("Small Body Uncompressed", func(t *testing.T) {
+		requests = nil
+		send(http.MethodPost, `{"small": true}`)
+
+		if assert.Len(t, requests, 1) {
+			assert.Empty(t, requests[0].encoding)
+			assert.Equal(t, `{"small": true}`, requests[0].body)
+		}
+	})
+
+	t.Run("Streamed Body Sent As-Is", func(t *testing.T) {
+		requests = nil
+		large := strings.Repeat("x", 2048)
+		req, err := http.NewRequest(http.MethodPut, server.URL, io.MultiReader(strings.NewReader(large)))
+		assert.NoError(t, err)
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode, "a consumed stream cannot be retried")
+		if assert.Len(t, requests, 1) {
+			assert.Empty(t, requests[0].encoding)
+			assert.Equal(t, large, requests[0].body)
+		}
+	})
+
+	t.Run("Zip Archive Uncompressed", func(t *testing.T) {
+		requests = nil
+		archive := "PK\x03\x04" + strings.Repeat("x", 2048)
+		req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(archive))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "application/zip")
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		if assert.Len(t, requests, 1) {
+			assert.Empty(t, requests[0].encoding)
+			assert.Equal(t, archive, requests[0].body)
+		}
+	})
+}
+
+func TestClient_Do_MetricsObserver(t *testing.T) {
+	t.Run("Observed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		var observed client.RequestMetrics
+		c := client.NewClient(server.URL, client.WithMetricsObserver(func(m client.RequestMetrics) {
+			observed = m
+		}))
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.MethodGet, observed.Method)
+		assert.Equal(t, http.StatusOK, observed.StatusCode)
+		assert.NoError(t, observed.Err)
+	})
+
+	t.Run("Panicking Observer Recovered", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"count": 1}`))
+		}))
+		defer server.Close()
+
+		c := client.NewClient(server.URL, client.WithMetricsObserver(func(client.RequestMetrics) {
+			panic("observer failure")
+		}))
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `{"count": 1}`, string(body))
+	})
+
+	t.Run("Connection Reuse", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"count": 1}`))
+		}))
+		defer server.Close()
+
+		send := func(c *client.Client) {
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			assert.NoError(t, err)
+			resp, err := c.Do(req)
+			if assert.NoError(t, err) {
+				_, _ = io.Copy(io.Discard, resp.Body)
+				_ = resp.Body.Close()
+			}
+		}
+
+		var observed []client.RequestMetrics
+		c := client.NewClient(
+			server.URL,
+			client.WithHTTPClient(server.Client()),
+			client.WithMetricsObserver(func(m client.RequestMetrics) { observed = append(observed, m) }),
+			client.WithConnectionReuseMetrics(),
+		)
+		send(c)
+		send(c)
+		if assert.Len(t, observed, 2) {
+			assert.Equal(t, 1, observed[0].NewConnections)
+			assert.Equal(t, 0, observed[0].ReusedConnections)
+			assert.Equal(t, 0, observed[1].NewConnections)
+			assert.Equal(t, 1, observed[1].ReusedConnections)
+		}
+
+		observed = nil
+		c = client.NewClient(
+			server.URL,
+			client.WithHTTPClient(server.Client()),
+			client.WithMetricsObserver(func(m client.RequestMetrics) { observed = append(observed, m) }),
+		)
+		send(c)
+		if assert.Len(t, observed, 1) {
+			assert.Zero(t, observed[0].NewConnections+observed[0].ReusedConnections, "connections are only counted when enabled")
+		}
+	})
+}
+
+func TestClient_Do_RequestEditor(t *testing.T) {
+	t.Run("Signs Query", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "value", r.URL.Query().Get("param"))
+			assert.Equal(t, "Bearer token:/search/artifacts", r.URL.Query().Get("signature"))
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		c := client.NewClient(
+			server.URL,
+			client.WithAuthHeader("Bearer token"),
+			client.WithRequestEditor(func(ctx context.Context, req *http.Request) error {
+				query := req.URL.Query()
+				query.Set("signature", req.Header.Get("Authorization")+":"+req.URL.Path)
+				req.URL.RawQuery = query.Encode()
+				return nil
+			}),
+		)
+
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/search/artifacts?param=value", nil)
+		assert.NoError(t, err)
+
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("Editor Error Aborts Request", func(t *testing.T) {
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		defer server.Close()
+
+		editErr := errors.New("signing failed")
+		c := client.NewClient(server.URL, client.WithRequestEditor(func(context.Context, *http.Request) error {
+			return editErr
+		}))
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+
+		resp, err := c.Do(req)
+		assert.ErrorIs(t, err, editErr)
+		assert.Nil(t, resp)
+		assert.False(t, called)
+	})
+}
+
+func TestClient_DoJSON(t *testing.T) {
+	// 2^53 + 1 is the smallest positive integer float64 cannot represent exactly.
+	const largeID = "9007199254740993"
+	body := `{"globalId":` + largeID + `,"contentId":` + largeID + `}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":404,"title":"Not found"}`))
+			return
+		}
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	t.Run("Typed IDs Keep Precision", func(t *testing.T) {
+		c := client.NewClient(server.URL)
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+
+		var meta models.ArtifactVersionMetadata
+		assert.NoError(t, c.DoJSON(req, &meta))
+		assert.Equal(t, int64(9007199254740993), meta.GlobalID)
+		assert.Equal(t, int64(9007199254740993), meta.ContentID)
+	})
+
+	t.Run("Generic Decoding With UseNumber", func(t *testing.T) {
+		c := client.NewClient(server.URL, client.WithJSONUseNumber())
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+
+		var out map[string]interface{}
+		assert.NoError(t, c.DoJSON(req, &out))
+		assert.Equal(t, json.Number(largeID), out["globalId"])
+	})
+
+	t.Run("Generic Decoding Without UseNumber Loses Precision", func(t *testing.T) {
+		c := client.NewClient(server.URL)
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+
+		var out map[string]interface{}
+		assert.NoError(t, c.DoJSON(req, &out))
+		assert.IsType(t, float64(0), out["globalId"])
+		assert.NotEqual(t, largeID, strconv.FormatFloat(out["globalId"].(float64), 'f', 0, 64))
+	})
+
+	t.Run("API Error", func(t *testing.T) {
+		c := client.NewClient(server.URL)
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/missing", nil)
+		assert.NoError(t, err)
+
+		err = c.DoJSON(req, nil)
+		var apiErr *models.APIError
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.Status)
+	})
+}
+
+func TestClient_CheckConnection(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/system/info", r.URL.Path)
+			_, _ = w.Write([]byte(`{"name":"Apicurio Registry","version":"3.0.5","builtOn":"2024-12-10T08:56:40Z"}`))
+		}))
+		defer server.Close()
+
+		info, err := client.NewClient(server.URL).CheckConnection(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "3.0.5", info.Version)
+
+		builtOn, err := info.BuildTime()
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, time.December, 10, 8, 56, 40, 0, time.UTC), builtOn)
+	})
+
+	t.Run("Server Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"status":500,"title":"Internal Server Error"}`))
+		}))
+		defer server.Close()
+
+		info, err := client.NewClient(server.URL).CheckConnection(context.Background())
+		assert.Nil(t, info)
+		var apiErr *models.APIError
+		if assert.ErrorAs(t, err, &apiErr) {
+			assert.Equal(t, http.StatusInternalServerError, apiErr.Status)
+		}
+	})
+}
+
+func TestClient_Do_TokenSource(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("Authorization"))
+		if r.URL.Path == "/unauthorized" || (r.URL.Path == "/expired" && len(received) == 1) {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"status": 401, "name": "NotAuthorizedException"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	doRequest := func(t *testing.T, c *client.Client, path string) error {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		assert.NoError(t, err)
+		resp, err := c.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	t.Run("Rotating Tokens", func(t *testing.T) {
+		received = nil
+		issued := 0
+		source := client.TokenSourceFunc(func(context.Context) (string, time.Time, error) {
+			issued++
+			return fmt.Sprintf("token-%d", issued), time.Time{}, nil
+		})
+		c := client.NewClient(server.URL, client.WithAuthHeader("Bearer static"), client.WithTokenSource(source))
+
+		assert.NoError(t, doRequest(t, c, "/"))
+		assert.NoError(t, doRequest(t, c, "/"))
+		assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, received)
+	})
+
+	t.Run("Cached Until Unauthorized", func(t *testing.T) {
+		received = nil
+		issued := 0
+		source := client.TokenSourceFunc(func(context.Context) (string, time.Time, error) {
+			issued++
+			return fmt.Sprintf("token-%d", issued), time.Now().Add(time.Hour), nil
+		})
+		c := client.NewClient(server.URL, client.WithTokenSource(source))
+
+		assert.NoError(t, doRequest(t, c, "/"))
+		assert.NoError(t, doRequest(t, c, "/unauthorized"))
+		assert.NoError(t, doRequest(t, c, "/"))
+		assert.Equal(t, []string{"Bearer token-1", "Bearer token-1", "Bearer token-2"}, received)
+	})
+
+	t.Run("Retry After Unauthorized Uses New Token", func(t *testing.T) {
+		received = nil
+		issued := 0
+		source := client.TokenSourceFunc(func(context.Context) (string, time.Time, error) {
+			issued++
+			return fmt.Sprintf("token-%d", issued), time.Now().Add(time.Hour), nil
+		})
+		c := client.NewClient(
+			server.URL,
+			client.WithTokenSource(source),
+			client.WithRetryOnErrorNames("NotAuthorizedException"),
+			client.WithRetryBackoff(time.Millisecond, time.Millisecond),
+		)
+
+		assert.NoError(t, doRequest(t, c, "/expired"))
+		assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, received)
+	})
+
+	t.Run("Token Error Aborts Request", func(t *testing.T) {
+		received = nil
+		tokenErr := errors.New("vault sealed")
+		source := client.TokenSourceFunc(func(context.Context) (string, time.Time, error) {
+			return "", time.Time{}, tokenErr
+		})
+		c := client.NewClient(server.URL, client.WithTokenSource(source))
+
+		assert.ErrorIs(t, doRequest(t, c, "/"), tokenErr)
+		assert.Empty(t, received)
+	})
+
+	t.Run("On Token Refresh", func(t *testing.T) {
+		received = nil
+		expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+		tokenErr := errors.New("vault sealed")
+		fail := false
+		source := client.TokenSourceFunc(func(context.Context) (string, time.Time, error) {
+			if fail {
+				return "", time.Time{}, tokenErr
+			}
+			return "token", expiry, nil
+		})
+		type refresh struct {
+			expiry time.Time
+			err    error
+		}
+		var refreshes []refresh
+		c := client.NewClient(
+			server.URL,
+			client.WithTokenSource(source),
+			client.WithOnTokenRefresh(func(expiry time.Time, err error) {
+				refreshes = append(refreshes, refresh{expiry, err})
+			}),
+		)
+
+		assert.NoError(t, doRequest(t, c, "/"))
+		assert.NoError(t, doRequest(t, c, "/"), "the cached token is reused without a refresh")
+		assert.Equal(t, []refresh{{expiry, nil}}, refreshes)
+
+		fail = true
+		assert.NoError(t, doRequest(t, c, "/unauthorized"))
+		assert.ErrorIs(t, doRequest(t, c, "/"), tokenErr)
+		assert.Len(t, refreshes, 2)
+		assert.ErrorIs(t, refreshes[1].err, tokenErr)
+	})
+
+	t.Run("Static Token Source", func(t *testing.T) {
+		received = nil
+		c := client.NewClient(server.URL, client.WithTokenSource(client.StaticTokenSource("static")))
+
+		assert.NoError(t, doRequest(t, c, "/"))
+		assert.Equal(t, []string{"Bearer static"}, received)
+	})
+}
+
+func TestClient_Do_TokenProvider(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	get := func(c *client.Client) error {
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+		resp, err := c.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	t.Run("Called Before Each Request", func(t *testing.T) {
+		received = nil
+		calls := 0
+		c := client.NewClient(
+			server.URL,
+			client.WithTokenProvider(func(ctx context.Context) (string, error) {
+				calls++
+				return fmt.Sprintf("token-%d", calls), nil
+			}),
+			client.WithBasicAuth("ignored", "ignored"),
+		)
+
+		assert.NoError(t, get(c))
+		assert.NoError(t, get(c))
+		assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, received)
+	})
+
+	t.Run("Provider Error", func(t *testing.T) {
+		received = nil
+		c := client.NewClient(
+			server.URL,
+			client.WithTokenProvider(func(ctx context.Context) (string, error) {
+				return "", errors.New("vault sealed")
+			}),
+		)
+
+		err := get(c)
+		assert.ErrorContains(t, err, "token provider: vault sealed")
+		assert.Empty(t, received)
+	})
+}
+
+func TestClient_Do_TokenFile(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "token")
+	writeToken := func(token string, modTime time.Time) {
+		assert.NoError(t, os.WriteFile(path, []byte(token+"\n"), 0o600))
+		assert.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	c := client.NewClient(server.URL, client.WithTokenFile(path))
+	get := func() error {
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+		resp, err := c.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	start := time.Now().Add(-time.Hour)
+	writeToken("first", start)
+	assert.NoError(t, get())
+	assert.NoError(t, get())
+
+	writeToken("second", start.Add(time.Minute))
+	assert.NoError(t, get())
+
+	assert.Equal(t, []string{"Bearer first", "Bearer first", "Bearer second"}, received)
+
+	assert.NoError(t, os.Remove(path))
+	assert.Error(t, get())
+}
+
+func TestNewClientFromConfig_ClientCredentials(t *testing.T) {
+	tokenRequests := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "registry:read registry:write", r.PostForm.Get("scope"))
+
+		id, secret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		if id != "my-client" || secret != "my-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "invalid_client", "error_description": "bad credentials"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "Bearer", "expires_in": 3600}`, tokenRequests)
+	}))
+	defer tokenServer.Close()
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	get := func(c *client.Client) error {
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+		resp, err := c.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	t.Run("Token Fetched And Cached", func(t *testing.T) {
+		c, err := client.NewClientFromConfig(client.Config{
+			BaseURL:   server.URL,
+			AuthToken: "ignored",
+			ClientCredentials: &client.ClientCredentials{
+				ClientID:     "my-client",
+				ClientSecret: "my-secret",
+				TokenURL:     tokenServer.URL,
+				Scopes:       []string{"registry:read", "registry:write"},
+			},
+		})
+		assert.NoError(t, err)
+
+		assert.NoError(t, get(c))
+		assert.NoError(t, get(c))
+		assert.Equal(t, []string{"Bearer token-1", "Bearer token-1"}, received)
+		assert.Equal(t, 1, tokenRequests)
+	})
+
+	t.Run("Invalid Credentials", func(t *testing.T) {
+		received = nil
+		c, err := client.NewClientFromConfig(client.Config{
+			BaseURL: server.URL,
+			ClientCredentials: &client.ClientCredentials{
+				ClientID:     "my-client",
+				ClientSecret: "wrong",
+				TokenURL:     tokenServer.URL,
+				Scopes:       []string{"registry:read", "registry:write"},
+			},
+		})
+		assert.NoError(t, err)
+
+		err = get(c)
+		assert.ErrorContains(t, err, "invalid_client")
+		assert.Empty(t, received)
+	})
+
+	t.Run("Static Token Without Client Credentials", func(t *testing.T) {
+		received = nil
+		c, err := client.NewClientFromConfig(client.Config{BaseURL: server.URL, AuthToken: "static"})
+		assert.NoError(t, err)
+
+		assert.NoError(t, get(c))
+		assert.Equal(t, []string{"Bearer static"}, received)
+	})
+}
+
+func TestClient_Do_TokenRefresh(t *testing.T) {
+	var tokenRequests atomic.Int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := tokenRequests.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "Bearer", "expires_in": 60}`, n)
+	}))
+	defer tokenServer.Close()
+
+	var mu sync.Mutex
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = append(received, r.Header.Get("Authorization"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	creds := client.ClientCredentials{ClientID: "my-client", ClientSecret: "my-secret", TokenURL: tokenServer.URL}
+	post := func(c *client.Client) error {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/groups/default/artifacts", nil)
+		if err != nil {
+			return err
+		}
+		resp, err := c.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	t.Run("Concurrent Requests Share One Refresh", func(t *testing.T) {
+		tokenRequests.Store(0)
+		received = nil
+		c := client.NewClient(server.URL, client.WithClientCredentials(creds))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, post(c))
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), tokenRequests.Load())
+		assert.Len(t, received, 100)
+		for _, header := range received {
+			assert.Equal(t, "Bearer token-1", header)
+		}
+	})
+
+	t.Run("Refreshed Within Skew Of Expiry", func(t *testing.T) {
+		tokenRequests.Store(0)
+		received = nil
+		c := client.NewClient(
+			server.URL,
+			client.WithClientCredentials(creds),
+			client.WithTokenRefreshSkew(2*time.Minute),
+		)
+
+		assert.NoError(t, post(c))
+		assert.NoError(t, post(c))
+		assert.Equal(t, int32(2), tokenRequests.Load())
+		assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, received)
+	})
+
+	t.Run("Hanging Refresh Times Out", func(t *testing.T) {
+		var calls atomic.Int32
+		source := client.TokenSourceFunc(func(ctx context.Context) (string, time.Time, error) {
+			if calls.Add(1) == 1 {
+				<-ctx.Done()
+				return "", time.Time{}, ctx.Err()
+			}
+			return "token", time.Now().Add(time.Hour), nil
+		})
+		c := client.NewClient(
+			server.URL,
+			client.WithTokenSource(source),
+			client.WithTokenRefreshTimeout(20*time.Millisecond),
+		)
+
+		start := time.Now()
+		assert.ErrorIs(t, post(c), context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.NoError(t, post(c), "the timed out refresh no longer blocks new ones")
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("Waiting Request Cancelled", func(t *testing.T) {
+		c := client.NewClient(server.URL, client.WithClientCredentials(creds))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, nil)
+		assert.NoError(t, err)
+
+		_, err = c.Do(req)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestClient_Do_Transport(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		assert.Equal(t, "outer,inner", r.Header.Get("X-Chain"))
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var order []string
+	middleware := func(name string) client.TransportMiddleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return client.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+":"+req.Header.Get("Authorization"))
+				// RoundTrippers must not modify the request they are given.
+				req = req.Clone(req.Context())
+				if chain := req.Header.Get("X-Chain"); chain != "" {
+					req.Header.Set("X-Chain", chain+","+name)
+				} else {
+					req.Header.Set("X-Chain", name)
+				}
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	var logs bytes.Buffer
+	httpClient := server.Client()
+	baseTransport := httpClient.Transport
+	c := client.NewClient(
+		server.URL,
+		client.WithHTTPClient(httpClient),
+		client.WithAuthToken("secret"),
+		client.WithMaxRetries(1),
+		client.WithRetryBackoff(time.Millisecond, time.Millisecond),
+		client.WithTransport(middleware("outer"), middleware("inner")),
+		client.WithTransport(client.LogRequests(log.New(&logs, "", 0))),
+	)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/system/info", nil)
+	assert.NoError(t, err)
+	resp, err := c.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{
+		"outer:Bearer secret", "inner:Bearer secret",
+		"outer:Bearer secret", "inner:Bearer secret",
+	}, order, "every attempt passes through the chain after authentication")
+	assert.Contains(t, logs.String(), "GET "+server.URL+"/system/info 503")
+	assert.Contains(t, logs.String(), "GET "+server.URL+"/system/info 200")
+	assert.Equal(t, baseTransport, httpClient.Transport, "the supplied HTTP client must not be modified")
+}
@@ -0,0 +1,247 @@
+package client
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/pkg/errors"
+)
+
+// Config is the struct form of the client settings. Every field maps onto a functional option,
+// so NewClientFromConfig(cfg) and NewClient(cfg.BaseURL, cfg.Options()...) build equivalent clients.
+// New settings are added as options first; Config only mirrors them for struct-style construction.
+type Config struct {
+	BaseURL              string                         // URL of the Apicurio Registry API, e.g. http://localhost:8080/apis/registry/v3
+	AuthToken            string                         // Bearer token; ignored when AuthHeader is set
+	AuthHeader           string                         // Complete Authorization header value
+	BasicAuth            *BasicAuth                     // See WithBasicAuth; mutually exclusive with AuthToken
+	TokenSource          TokenSource                    // See WithTokenSource; takes precedence over every other auth setting
+	TokenProvider        TokenProvider                  // See WithTokenProvider; ignored when TokenSource is set
+	TokenFile            string                         // See WithTokenFile; ignored when a token source, provider or client credentials are set
+	ClientCredentials    *ClientCredentials             // See WithClientCredentials; ignored when TokenSource or TokenProvider is set
+	TokenRefreshSkew     time.Duration                  // See WithTokenRefreshSkew
+	TokenRefreshTimeout  time.Duration                  // See WithTokenRefreshTimeout
+	OnTokenRefresh       TokenRefreshCallback           // See WithOnTokenRefresh
+	HTTPClient           *http.Client                   // Custom HTTP client; defaults to a preconfigured client
+	Timeout              time.Duration                  // Overall timeout per request; zero keeps the HTTP client's timeout
+	MaxConcurrency       int                            // See WithMaxConcurrency
+	MaxURLLength         int                            // See WithMaxURLLength
+	DisableCompression   bool                           // See WithCompressionNegotiation
+	CompressRequests     bool                           // See WithRequestCompression
+	CompressionThreshold int                            // See WithRequestCompression; zero means DefaultRequestCompressionThreshold
+	MetricsObserver      MetricsObserver                // See WithMetricsObserver
+	ConnReuseMetrics     bool                           // See WithConnectionReuseMetrics
+	SLAThreshold         time.Duration                  // See WithSLACallback
+	SLACallback          SLACallback                    // See WithSLACallback; ignored without SLAThreshold
+	RequestEditor        RequestEditor                  // See WithRequestEditor
+	StatusHook           StatusHook                     // See WithStatusHook
+	Transport            []TransportMiddleware          // See WithTransport
+	Retry                *RetryConfig                   // See RetryConfig; nil keeps retries disabled
+	RetryOnErrorNames    []string                       // See WithRetryOnErrorNames
+	JitterStrategy       models.JitterStrategy          // See WithJitterStrategy
+	FailFastOnAuth       bool                           // See WithFailFastOnAuthError
+	RetryOnTruncated     bool                           // See WithRetryOnTruncatedBody
+	DefaultIfExists      models.IfExistsType            // See WithDefaultIfExists
+	VersionStrategy      models.VersionStrategy         // See WithVersionStrategy
+	DefaultBranch        string                         // See WithDefaultBranch
+	DefaultGroupLabels   map[string]string              // See WithDefaultGroupLabels
+	MaxElapsedTime       time.Duration                  // See WithMaxElapsedTime
+	MaxItemsFetched      int                            // See WithMaxItemsFetched
+	ContentCacheSize     int                            // See WithContentCache; zero disables the cache
+	EventInvalidation    bool                           // See WithEventDrivenCacheInvalidation
+	JSONUseNumber        bool                           // See WithJSONUseNumber
+	ContentTypes         map[models.ArtifactType]string // See WithContentTypeMap
+	CanonicalizeOnCreate bool                           // See WithCanonicalizeOnCreate
+	MinifyJSONOnWrite    bool                           // See WithMinifyJSONOnWrite
+	ValidateContent      bool                           // See WithValidateRetrievedContent
+	SerializeWrites      bool                           // See WithSerializeWritesPerArtifact
+}
+
+// BasicAuth holds the credentials for HTTP Basic authentication.
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// RetryConfig is the struct form of the retry options. Only idempotent requests and requests marked
+// with WithRetrySafe are retried, after a connection error or a 502, 503 or 504 response.
+type RetryConfig struct {
+	MaxRetries int           // See WithMaxRetries; zero disables retries
+	BaseDelay  time.Duration // Delay before the first retry; zero means DefaultRetryBaseDelay
+	MaxDelay   time.Duration // Cap on the delay between two attempts; zero means DefaultRetryMaxDelay
+	Multiplier float64       // See WithRetryMultiplier; zero means DefaultRetryMultiplier
+	Jitter     bool          // Randomize delays with equal jitter; JitterStrategy takes precedence when set
+	Methods    []string      // See WithRetryableMethods; empty keeps the idempotent methods
+}
+
+// Options converts the Config into the equivalent functional options.
+func (cfg Config) Options() []Option {
+	var opts []Option
+
+	if cfg.HTTPClient != nil {
+		opts = append(opts, WithHTTPClient(cfg.HTTPClient))
+	}
+	if cfg.TokenSource != nil {
+		opts = append(opts, WithTokenSource(cfg.TokenSource))
+	} else if cfg.TokenProvider != nil {
+		opts = append(opts, WithTokenProvider(cfg.TokenProvider))
+	} else if cfg.ClientCredentials != nil {
+		opts = append(opts, WithClientCredentials(*cfg.ClientCredentials))
+	} else if cfg.TokenFile != "" {
+		opts = append(opts, WithTokenFile(cfg.TokenFile))
+	} else if cfg.AuthHeader != "" {
+		opts = append(opts, WithAuthHeader(cfg.AuthHeader))
+	} else if cfg.BasicAuth != nil {
+		opts = append(opts, WithBasicAuth(cfg.BasicAuth.Username, cfg.BasicAuth.Password))
+	} else if cfg.AuthToken != "" {
+		opts = append(opts, WithAuthToken(cfg.AuthToken))
+	}
+	if cfg.TokenRefreshSkew > 0 {
+		opts = append(opts, WithTokenRefreshSkew(cfg.TokenRefreshSkew))
+	}
+	if cfg.TokenRefreshTimeout > 0 {
+		opts = append(opts, WithTokenRefreshTimeout(cfg.TokenRefreshTimeout))
+	}
+	if cfg.OnTokenRefresh != nil {
+		opts = append(opts, WithOnTokenRefresh(cfg.OnTokenRefresh))
+	}
+	if cfg.Timeout > 0 {
+		opts = append(opts, WithTimeout(cfg.Timeout))
+	}
+	if cfg.MaxConcurrency > 0 {
+		opts = append(opts, WithMaxConcurrency(cfg.MaxConcurrency))
+	}
+	if cfg.MaxURLLength > 0 {
+		opts = append(opts, WithMaxURLLength(cfg.MaxURLLength))
+	}
+	if cfg.DisableCompression {
+		opts = append(opts, WithCompressionNegotiation(false))
+	}
+	if cfg.CompressRequests {
+		opts = append(opts, WithRequestCompression(cfg.CompressionThreshold))
+	}
+	if cfg.MetricsObserver != nil {
+		opts = append(opts, WithMetricsObserver(cfg.MetricsObserver))
+	}
+
+	if cfg.ConnReuseMetrics {
+		opts = append(opts, WithConnectionReuseMetrics())
+	}
+	if cfg.SLAThreshold > 0 && cfg.SLACallback != nil {
+		opts = append(opts, WithSLACallback(cfg.SLAThreshold, cfg.SLACallback))
+	}
+	if cfg.RequestEditor != nil {
+		opts = append(opts, WithRequestEditor(cfg.RequestEditor))
+	}
+
+	if cfg.StatusHook != nil {
+		opts = append(opts, WithStatusHook(cfg.StatusHook))
+	}
+	if len(cfg.Transport) > 0 {
+		opts = append(opts, WithTransport(cfg.Transport...))
+	}
+
+	if cfg.Retry != nil {
+		opts = append(opts,
+			WithMaxRetries(cfg.Retry.MaxRetries),
+			WithRetryBackoff(cfg.Retry.BaseDelay, cfg.Retry.MaxDelay),
+			WithRetryMultiplier(cfg.Retry.Multiplier),
+		)
+		if !cfg.Retry.Jitter {
+			opts = append(opts, WithJitterStrategy(models.JitterNone))
+		}
+		if len(cfg.Retry.Methods) > 0 {
+			opts = append(opts, WithRetryableMethods(cfg.Retry.Methods...))
+		}
+	}
+
+	if len(cfg.RetryOnErrorNames) > 0 {
+		opts = append(opts, WithRetryOnErrorNames(cfg.RetryOnErrorNames...))
+	}
+
+	if cfg.RetryOnTruncated {
+		opts = append(opts, WithRetryOnTruncatedBody())
+	}
+
+	if cfg.FailFastOnAuth {
+		opts = append(opts, WithFailFastOnAuthError(true))
+	}
+
+	if cfg.JitterStrategy != "" {
+		opts = append(opts, WithJitterStrategy(cfg.JitterStrategy))
+	}
+
+	if cfg.DefaultIfExists != "" {
+		opts = append(opts, WithDefaultIfExists(cfg.DefaultIfExists))
+	}
+
+	if cfg.VersionStrategy != "" {
+		opts = append(opts, WithVersionStrategy(cfg.VersionStrategy))
+	}
+
+	if cfg.DefaultBranch != "" {
+		opts = append(opts, WithDefaultBranch(cfg.DefaultBranch))
+	}
+
+	if len(cfg.DefaultGroupLabels) > 0 {
+		opts = append(opts, WithDefaultGroupLabels(cfg.DefaultGroupLabels))
+	}
+
+	if cfg.MaxElapsedTime > 0 {
+		opts = append(opts, WithMaxElapsedTime(cfg.MaxElapsedTime))
+	}
+
+	if cfg.MaxItemsFetched > 0 {
+		opts = append(opts, WithMaxItemsFetched(cfg.MaxItemsFetched))
+	}
+
+	if cfg.ContentCacheSize > 0 {
+		opts = append(opts, WithContentCache(cfg.ContentCacheSize))
+	}
+	if cfg.EventInvalidation {
+		opts = append(opts, WithEventDrivenCacheInvalidation())
+	}
+
+	if cfg.JSONUseNumber {
+		opts = append(opts, WithJSONUseNumber())
+	}
+
+	if len(cfg.ContentTypes) > 0 {
+		opts = append(opts, WithContentTypeMap(cfg.ContentTypes))
+	}
+
+	if cfg.CanonicalizeOnCreate {
+		opts = append(opts, WithCanonicalizeOnCreate())
+	}
+
+	if cfg.MinifyJSONOnWrite {
+		opts = append(opts, WithMinifyJSONOnWrite())
+	}
+
+	if cfg.ValidateContent {
+		opts = append(opts, WithValidateRetrievedContent())
+	}
+
+	if cfg.SerializeWrites {
+		opts = append(opts, WithSerializeWritesPerArtifact())
+	}
+
+	return opts
+}
+
+// NewClientFromConfig creates a new Client from a Config.
+func NewClientFromConfig(cfg Config) (*Client, error) {
+	if cfg.BaseURL == "" {
+		return nil, errors.New("base URL cannot be empty")
+	}
+	if cfg.BasicAuth != nil && cfg.AuthToken != "" {
+		return nil, errors.New("basic auth and auth token are mutually exclusive")
+	}
+	if cfg.Retry != nil {
+		if err := validateMethods(cfg.Retry.Methods); err != nil {
+			return nil, errors.Wrap(err, "invalid retryable methods")
+		}
+	}
+	return NewClient(cfg.BaseURL, cfg.Options()...), nil
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"strings"
+
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/pkg/errors"
+)
+
+// WithContentTypeMap overrides the MIME type sent for content of the given artifact types when a request
+// leaves the content type empty, e.g. application/vnd.apache.avro+json for servers that require it.
+// Artifact types missing from the map keep models.DefaultContentType.
+func WithContentTypeMap(contentTypes map[models.ArtifactType]string) Option {
+	return func(c *Client) {
+		if c.contentTypes == nil {
+			c.contentTypes = make(map[models.ArtifactType]string, len(contentTypes))
+		}
+		for artifactType, contentType := range contentTypes {
+			c.contentTypes[artifactType] = contentType
+		}
+	}
+}
+
+// ContentTypeFor returns the MIME type used for content of the given artifact type.
+func (c *Client) ContentTypeFor(artifactType models.ArtifactType) string {
+	if contentType, ok := c.contentTypes[artifactType]; ok {
+		return contentType
+	}
+	return models.DefaultContentType(artifactType)
+}
+
+// WithCanonicalizeOnCreate canonicalizes the content of artifacts created with CreateArtifact before it is
+// uploaded, so that semantically equal content maps to the same content ID and hash on the registry.
+// Only JSON and Avro content is canonicalized: object keys are sorted and insignificant whitespace is removed,
+// which keeps every attribute, including Avro docs and defaults. Content of other artifact types is sent as-is.
+func WithCanonicalizeOnCreate() Option {
+	return func(c *Client) {
+		c.canonicalizeOnCreate = true
+	}
+}
+
+// canonicalizeForCreate returns content in canonical form when WithCanonicalizeOnCreate is enabled and the
+// artifact type supports it, and content unchanged otherwise.
+func (c *Client) canonicalizeForCreate(artifactType models.ArtifactType, content string) (string, error) {
+	if !c.canonicalizeOnCreate || content == "" {
+		return content, nil
+	}
+
+	switch artifactType {
+	case models.Json, models.Avro:
+		canonical, err := models.CanonicalizeJSON([]byte(content))
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to canonicalize %s content", artifactType)
+		}
+		return string(canonical), nil
+	default:
+		return content, nil
+	}
+}
+
+// WithMinifyJSONOnWrite removes insignificant whitespace from JSON content before CreateArtifact,
+// CreateArtifactVersion and UpdateArtifactVersionContent upload it, to save bandwidth on large documents such
+// as OpenAPI definitions. Unlike WithCanonicalizeOnCreate, object keys keep their order. Only content sent as
+// application/json or as a +json media type is minified; other content, and JSON that does not parse, is sent as-is.
+func WithMinifyJSONOnWrite() Option {
+	return func(c *Client) {
+		c.minifyJSONOnWrite = true
+	}
+}
+
+// minifyForWrite returns content without insignificant whitespace when WithMinifyJSONOnWrite is enabled and
+// contentType is a JSON media type, and content unchanged otherwise.
+func (c *Client) minifyForWrite(contentType, content string) string {
+	if !c.minifyJSONOnWrite || content == "" {
+		return content
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+		return content
+	}
+
+	var minified bytes.Buffer
+	if err := json.Compact(&minified, []byte(content)); err != nil {
+		return content
+	}
+	return minified.String()
+}
+
+// WithValidateRetrievedContent checks content fetched from the registry against the local validator of its artifact
+// type, so malformed content stored while validity rules were disabled is detected when it is read rather than
+// when it is used. JSON Schema, Avro and Protobuf content is checked; see models.ValidateContent. Fetches of
+// invalid content fail with an error wrapping models.ErrInvalidContent. Streamed content is not checked.
+func WithValidateRetrievedContent() Option {
+	return func(c *Client) {
+		c.validateContent = true
+	}
+}
+
+// validateRetrievedContent checks content of the given artifact type when WithValidateRetrievedContent is enabled.
+func (c *Client) validateRetrievedContent(artifactType models.ArtifactType, content string) error {
+	if !c.validateContent {
+		return nil
+	}
+	return models.ValidateContent(artifactType, []byte(content))
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+type contextKey int
+
+const (
+	ifMatchKey contextKey = iota
+	operationNameKey
+	retrySafeKey
+	noRetryKey
+)
+
+// WithIfMatch returns a context that makes the request carry an `If-Match: <etag>` header.
+// Updates sent with this context are rejected by the registry with 412 Precondition Failed
+// when the resource changed since the etag was obtained, preventing lost updates. The metadata and
+// content getters return the etag of what they fetched in the ETag field of their result.
+func WithIfMatch(ctx context.Context, etag string) context.Context {
+	return context.WithValue(ctx, ifMatchKey, etag)
+}
+
+// ifMatchFromContext returns the etag set by WithIfMatch, if any.
+func ifMatchFromContext(ctx context.Context) string {
+	etag, _ := ctx.Value(ifMatchKey).(string)
+	return etag
+}
+
+// WithRequestTimeout returns a context that bounds a single API call to d without reconfiguring the
+// shared HTTP client; the shorter of d and the client's timeout applies. Every API method sends its
+// requests with the context it is given. As with context.WithTimeout, cancel must be called to release
+// resources, and for streaming methods only after the stream has been read.
+func WithRequestTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, d)
+}
+
+// WithOperationName returns a context that names the API operation a request belongs to, e.g.
+// "CreateArtifactVersion". Every API method sets it, so request editors, metrics observers and
+// tracing middleware can label requests without parsing URLs.
+func WithOperationName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationNameKey, name)
+}
+
+// OperationName returns the operation name set by WithOperationName, or an empty string.
+func OperationName(ctx context.Context) string {
+	name, _ := ctx.Value(operationNameKey).(string)
+	return name
+}
+
+// WithRetrySafe returns a context that marks a non-idempotent request, such as a POST search or a
+// dry-run, as safe to retry after a connection error, a 502, 503 or 504 response or an error name
+// registered with WithRetryOnErrorNames.
+func WithRetrySafe(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retrySafeKey, true)
+}
+
+// retrySafeFromContext reports whether the context was marked with WithRetrySafe.
+func retrySafeFromContext(ctx context.Context) bool {
+	safe, _ := ctx.Value(retrySafeKey).(bool)
+	return safe
+}
+
+// WithoutRetries returns a context that sends a request once, whatever the client's retry policy, for
+// requests whose answer must reflect the registry's state right now, such as health probes.
+func WithoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey, true)
+}
+
+// noRetryFromContext reports whether the context was marked with WithoutRetries.
+func noRetryFromContext(ctx context.Context) bool {
+	noRetry, _ := ctx.Value(noRetryKey).(bool)
+	return noRetry
+}
@@ -7,55 +7,66 @@
 // Features:
 //
 // - Centralized configuration for base URL and authentication.
-// - Automatic retry mechanisms for transient failures.
+// - Automatic retry mechanisms for transient failures (see `WithMaxRetries` and `RetryConfig`).
 // - Thread-safe implementation suitable for concurrent use.
 // - Extensible architecture to support additional features and middleware.
 //
 // Usage:
 //
-// To use the client, create a new instance using the `NewApicurioClient` function and pass a `Config` structure
-// with the required settings such as BaseURL and AuthToken.
+// To use the client, create a new instance using the `NewClient` function and configure it with
+// functional options such as `WithAuthToken`, `WithTimeout` or `WithHTTPClient`. The struct form,
+// `NewClientFromConfig` with a `Config`, builds an equivalent client from the same settings.
 //
 // Example:
 //
 //	package main
 //
 //	import (
+//		"context"
 //		"fmt"
+//		"time"
+//
+//		"github.com/mollie/go-apicurio-registry/apis"
 //		"github.com/mollie/go-apicurio-registry/client"
 //	)
 //
 //	func main() {
-//		// Define the client configuration
-//		config := client.Config{
-//			BaseURL: "https://my-registry.example.com",
-//			AuthToken: "my-token",
-//		}
-//
 //		// Initialize the client
-//		apiClient := client.NewApicurioClient(config)
+//		apiClient := client.NewClient(
+//			"https://my-registry.example.com/apis/registry/v3",
+//			client.WithAuthToken("my-token"),
+//			client.WithTimeout(10*time.Second),
+//		)
 //
 //		// Check the connection to the registry
-//		status, err := apiClient.CheckConnection()
+//		info, err := apis.NewSystemAPI(apiClient).GetSystemInfo(context.Background())
 //		if err != nil {
 //			fmt.Printf("Error connecting to the registry: %v\n", err)
 //			return
 //		}
-//		fmt.Printf("Registry status: %v\n", status)
+//		fmt.Printf("Registry version: %s\n", info.Version)
 //	}
 //
 // Configuration:
 //
-// The `Config` struct contains the following fields:
+// The `Config` struct mirrors the functional options, including:
 // - BaseURL: The URL of the Apicurio Registry.
 // - AuthToken: A token used for authenticating requests.
+// - BasicAuth: A username and password for registries behind HTTP Basic authentication.
 // - HTTPClient: (Optional) A custom HTTP client for advanced use cases.
 //
+// Authentication:
+//
+// A token source (`WithTokenSource`, `WithTokenProvider`, `WithClientCredentials` or `WithTokenFile`)
+// takes precedence over a static Authorization header, whatever the order of the options. Static headers
+// (`WithAuthHeader`, `WithBasicAuth` and `WithAuthToken`) replace each other, so the last one applied wins.
+// In a `Config`, the precedence is TokenSource, TokenProvider, ClientCredentials, TokenFile, AuthHeader,
+// BasicAuth and AuthToken; setting both BasicAuth and AuthToken is an error.
+//
 // Methods:
 //
-// The client provides several methods to interact with the registry, including:
-// - `CheckConnection`: Verifies if the registry is reachable.
-// - `DoRequest`: Executes raw HTTP requests for advanced use cases.
+// The client provides `Do`, which executes raw HTTP requests with authentication and
+// compression handling applied, for advanced use cases.
 //
 // Thread Safety:
 //
@@ -0,0 +1,8 @@
+package client
+
+import "time"
+
+// RetryBackoff exposes the retry backoff to tests.
+func (c *Client) RetryBackoff(retry int) time.Duration {
+	return c.retry.backoff(retry)
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptrace"
+	"time"
+
+	"github.com/mollie/go-apicurio-registry/internal/plumbing"
+)
+
+// RequestMetrics describes a single HTTP request executed by the client.
+type RequestMetrics struct {
+	Operation  string // Operation name set by WithOperationName; empty for requests sent outside the API methods
+	Method     string
+	URL        string
+	StatusCode int // Zero when the request failed before a response was received
+	Duration   time.Duration
+	Err        error
+
+	// NewConnections and ReusedConnections count the connections the request was sent on, split by whether
+	// they were freshly dialed or taken from the keep-alive pool. Both are zero unless the client was created
+	// with WithConnectionReuseMetrics.
+	NewConnections    int
+	ReusedConnections int
+}
+
+// MetricsObserver is called after every request executed by the client.
+type MetricsObserver func(RequestMetrics)
+
+// WithMetricsObserver registers an observer that receives metrics for every request.
+// A panicking observer is recovered and logged; it never fails the request.
+func WithMetricsObserver(observer MetricsObserver) Option {
+	return func(c *Client) {
+		c.metricsObserver = observer
+	}
+}
+
+// WithConnectionReuseMetrics counts new and reused connections in the RequestMetrics passed to the metrics
+// observer, so connection churn shows up in metrics. A steady stream of new connections usually means
+// keep-alive is not working, e.g. because response bodies are closed without being read to the end.
+func WithConnectionReuseMetrics() Option {
+	return func(c *Client) {
+		c.connReuseMetrics = true
+	}
+}
+
+// SLACallback is called with the operation name and duration of a request that exceeded the SLA threshold.
+type SLACallback func(op string, dur time.Duration)
+
+// WithSLACallback calls fn whenever a request takes longer than threshold, for alerting on a slow registry.
+// The duration covers the whole of Do, including retries and their backoff, up to the response headers.
+// The operation name is the one set by WithOperationName, or the method and path for requests sent outside
+// the API methods. The callback never fails the request; a panicking callback is recovered and logged.
+func WithSLACallback(threshold time.Duration, fn SLACallback) Option {
+	return func(c *Client) {
+		c.slaThreshold = threshold
+		c.slaCallback = fn
+	}
+}
+
+// StatusHook inspects a response before the default status handling of the API methods. Returning handled=true
+// makes the API method return err as-is, skipping its own status check and decoding.
+type StatusHook func(resp *http.Response) (handled bool, err error)
+
+// WithStatusHook registers a hook for deployments that answer with nonstandard statuses, e.g. a 200 response
+// carrying an error body. The hook sees every response handled by the API methods except streamed content; it
+// may read the body freely, as the body is buffered and restored for the default handling.
+// A panicking hook is recovered and reported as the error of the call.
+func WithStatusHook(hook StatusHook) Option {
+	return func(c *Client) {
+		c.statusHook = hook
+	}
+}
+
+// RequestEditor mutates an outgoing request, including its URL and query.
+type RequestEditor func(ctx context.Context, req *http.Request) error
+
+// WithRequestEditor registers an editor that can rewrite every outgoing request, for example to
+// sign the final URL for gateways that require signed query parameters. The editor runs last,
+// after authentication and all other headers are applied, so it sees the request exactly as sent.
+// An error or panic returned by the editor aborts the request.
+func WithRequestEditor(editor RequestEditor) Option {
+	return func(c *Client) {
+		c.requestEditor = editor
+	}
+}
+
+// editRequest applies the request editor, if any.
+func (c *Client) editRequest(req *http.Request) error {
+	if c.requestEditor == nil {
+		return nil
+	}
+
+	var editErr error
+	if hookErr := plumbing.InvokeHook("RequestEditor", func() { editErr = c.requestEditor(req.Context(), req) }); hookErr != nil {
+		return hookErr
+	}
+	return editErr
+}
+
+// connCounter counts the connections obtained for a request.
+type connCounter struct {
+	created int
+	reused  int
+}
+
+func (n *connCounter) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				n.reused++
+			} else {
+				n.created++
+			}
+		},
+	}
+}
+
+// traceConnections makes req count its connections in n when connection reuse metrics are enabled.
+func (c *Client) traceConnections(req *http.Request, n *connCounter) *http.Request {
+	if !c.connReuseMetrics || c.metricsObserver == nil {
+		return req
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), n.trace()))
+}
+
+// observe reports the outcome of a request to the metrics observer, if any.
+func (c *Client) observe(req *http.Request, resp *http.Response, err error, start time.Time, conns connCounter) {
+	if c.metricsObserver == nil {
+		return
+	}
+
+	metrics := RequestMetrics{
+		Operation:         OperationName(req.Context()),
+		Method:            req.Method,
+		URL:               req.URL.String(),
+		Duration:          time.Since(start),
+		Err:               err,
+		NewConnections:    conns.created,
+		ReusedConnections: conns.reused,
+	}
+	if resp != nil {
+		metrics.StatusCode = resp.StatusCode
+	}
+
+	if hookErr := plumbing.InvokeHook("MetricsObserver", func() { c.metricsObserver(metrics) }); hookErr != nil {
+		log.Printf("apicurio: %v", hookErr)
+	}
+}
+
+// checkSLA reports a request that took longer than the SLA threshold to the SLA callback, if any.
+func (c *Client) checkSLA(req *http.Request, start time.Time) {
+	if c.slaCallback == nil {
+		return
+	}
+
+	dur := time.Since(start)
+	if dur <= c.slaThreshold {
+		return
+	}
+
+	op := OperationName(req.Context())
+	if op == "" {
+		op = req.Method + " " + req.URL.Path
+	}
+	if hookErr := plumbing.InvokeHook("SLACallback", func() { c.slaCallback(op, dur) }); hookErr != nil {
+		log.Printf("apicurio: %v", hookErr)
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+
+	"github.com/mollie/go-apicurio-registry/internal/plumbing"
+	"github.com/mollie/go-apicurio-registry/models"
+)
+
+func init() {
+	plumbing.Of = func(c any) plumbing.Client {
+		return internals{c: c.(*Client)}
+	}
+}
+
+// internals gives package apis access to the client features it relies on, without making them part of the
+// public API of Client.
+type internals struct {
+	c *Client
+}
+
+func (i internals) CachedArtifactTypes() (map[models.ArtifactType]bool, bool) {
+	return i.c.cachedArtifactTypes()
+}
+
+func (i internals) CacheArtifactTypes(types []models.ArtifactType) {
+	i.c.cacheArtifactTypes(types)
+}
+
+func (i internals) InvalidateArtifactTypes() {
+	i.c.invalidateArtifactTypes()
+}
+
+func (i internals) ContentCacheEnabled() bool {
+	return i.c.contentCacheEnabled()
+}
+
+func (i internals) CachedContent(globalID int64) (plumbing.CachedContent, bool) {
+	return i.c.cachedContent(globalID)
+}
+
+func (i internals) CacheContent(globalID int64, content plumbing.CachedContent) {
+	i.c.cacheContent(globalID, content)
+}
+
+func (i internals) EvictContent(globalID int64) {
+	i.c.evictContent(globalID)
+}
+
+func (i internals) InvalidateForEvent(event models.RegistryEvent) {
+	i.c.invalidateForEvent(event)
+}
+
+func (i internals) AddInvalidationListener(listener func(event models.RegistryEvent)) (remove func()) {
+	return i.c.addInvalidationListener(listener)
+}
+
+func (i internals) CanonicalizeForCreate(artifactType models.ArtifactType, content string) (string, error) {
+	return i.c.canonicalizeForCreate(artifactType, content)
+}
+
+func (i internals) MinifyForWrite(contentType, content string) string {
+	return i.c.minifyForWrite(contentType, content)
+}
+
+func (i internals) ValidateRetrievedContent(artifactType models.ArtifactType, content string) error {
+	return i.c.validateRetrievedContent(artifactType, content)
+}
+
+func (i internals) LockArtifact(ctx context.Context, groupID, artifactID string) (func(), error) {
+	return i.c.lockArtifact(ctx, groupID, artifactID)
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/mollie/go-apicurio-registry/internal/plumbing"
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/pkg/errors"
+)
+
+// WithJSONUseNumber makes DoJSON decode numbers into json.Number instead of float64 when the target
+// is an interface{} or a map of them. Global IDs and content IDs can exceed 2^53, which float64
+// cannot represent exactly. Typed models are unaffected; they use int64 for all IDs.
+func WithJSONUseNumber() Option {
+	return func(c *Client) {
+		c.jsonUseNumber = true
+	}
+}
+
+// ModelDecoder decodes a JSON response body into v, a pointer to the registered model type.
+type ModelDecoder func(data []byte, v interface{}) error
+
+// WithModelDecoder registers a decoder for responses decoded into the type of target, e.g.
+// WithModelDecoder(models.GroupInfo{}, fn) to map group labels differently. Pointer and value
+// targets register the same type. Responses of other types keep the standard JSON decoding.
+func WithModelDecoder(target interface{}, decoder ModelDecoder) Option {
+	return func(c *Client) {
+		if c.modelDecoders == nil {
+			c.modelDecoders = make(map[reflect.Type]plumbing.ModelDecoder)
+		}
+		c.modelDecoders[plumbing.ModelType(target)] = plumbing.ModelDecoder(decoder)
+	}
+}
+
+// DoJSON performs the request and decodes a successful JSON response body into out.
+// A non-2xx response is returned as a *models.APIError when the body is a problem detail.
+// A nil out discards the body.
+func (c *Client) DoJSON(req *http.Request, out interface{}) error {
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer plumbing.DrainBody(resp)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return errors.Wrapf(err, "unexpected server error: %d", resp.StatusCode)
+		}
+		var apiError models.APIError
+		if err := json.Unmarshal(body, &apiError); err != nil || apiError.Status == 0 {
+			if resp.StatusCode != http.StatusTooManyRequests {
+				return fmt.Errorf("unexpected server error: %d", resp.StatusCode)
+			}
+			apiError = models.APIError{Status: resp.StatusCode, Title: http.StatusText(resp.StatusCode)}
+		}
+		apiError.Status = resp.StatusCode
+		apiError.RetryAfter = models.ParseRetryAfter(resp.Header.Get("Retry-After"))
+		return &apiError
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	if decode, ok := c.modelDecoders[plumbing.ModelType(out)]; ok {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return errors.Wrap(err, "failed to read response body")
+		}
+		if err := decode(body, out); err != nil {
+			return errors.Wrap(err, "failed to parse response body")
+		}
+		return nil
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	if c.jsonUseNumber {
+		decoder.UseNumber()
+	}
+	if err := decoder.Decode(out); err != nil {
+		return errors.Wrap(err, "failed to parse response body")
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mollie/go-apicurio-registry/internal/plumbing"
+	"github.com/pkg/errors"
+)
+
+// ClientCredentials configures the OAuth2 client credentials grant, e.g. against Okta or Keycloak.
+type ClientCredentials struct {
+	ClientID     string
+	ClientSecret string
+	TokenURL     string
+	Scopes       []string
+}
+
+// WithClientCredentials authenticates every request with a bearer token obtained through the OAuth2
+// client credentials grant. Tokens are fetched with the client's HTTP client and cached until shortly
+// before they expire. See WithTokenSource.
+func WithClientCredentials(creds ClientCredentials) Option {
+	return func(c *Client) {
+		c.tokenSource = &cachedTokenSource{source: &clientCredentialsSource{creds: creds, client: c}}
+	}
+}
+
+// clientCredentialsSource is a TokenSource for the OAuth2 client credentials grant.
+type clientCredentialsSource struct {
+	creds  ClientCredentials
+	client *Client
+}
+
+// tokenResponse is the successful response of an OAuth2 token endpoint.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// tokenErrorResponse is the error response of an OAuth2 token endpoint.
+type tokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Token requests a new access token from the token endpoint.
+func (s *clientCredentialsSource) Token(ctx context.Context) (string, time.Time, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.creds.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.creds.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.creds.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "failed to create token request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(s.creds.ClientID), url.QueryEscape(s.creds.ClientSecret))
+
+	httpClient := s.client.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "failed to request token")
+	}
+	defer plumbing.DrainBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		var tokenErr tokenErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&tokenErr); err != nil || tokenErr.Error == "" {
+			return "", time.Time{}, errors.Errorf("token endpoint returned %d", resp.StatusCode)
+		}
+		return "", time.Time{}, errors.Errorf("token endpoint returned %d: %s %s",
+			resp.StatusCode, tokenErr.Error, tokenErr.ErrorDescription)
+	}
+
+	var token tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", time.Time{}, errors.Wrap(err, "failed to parse token response")
+	}
+	if token.AccessToken == "" {
+		return "", time.Time{}, errors.New("token endpoint returned no access token")
+	}
+
+	var expiry time.Time
+	if token.ExpiresIn > 0 {
+		expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return token.AccessToken, expiry, nil
+}
@@ -0,0 +1,324 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/rand/v2"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultMaxRetries is the number of retries used when retries are enabled without an explicit limit.
+	DefaultMaxRetries = 3
+	// DefaultRetryBaseDelay is the delay before the first retry.
+	DefaultRetryBaseDelay = 100 * time.Millisecond
+	// DefaultRetryMaxDelay caps the delay between two attempts.
+	DefaultRetryMaxDelay = 2 * time.Second
+	// DefaultRetryMultiplier is the factor by which the delay grows after each retry.
+	DefaultRetryMultiplier = 2.0
+)
+
+// retryPolicy decides whether and when Do retries a request. The zero value disables retries.
+type retryPolicy struct {
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	multiplier float64
+	jitter     models.JitterStrategy
+	errorNames map[string]bool
+	methods    map[string]bool // methods retried after a connection error, gateway response or named error; nil means idempotent methods
+	failFast   bool            // never retry 401 and 403 responses
+	truncated  bool            // retry GET responses whose JSON body was cut short
+}
+
+// WithMaxRetries sets how many times a request is retried after a transient failure:
+// a connection error, a 429, 502, 503 or 504 response, or an error name registered with WithRetryOnErrorNames.
+// Connection errors, gateway responses and named errors are only retried for idempotent methods (GET, HEAD,
+// OPTIONS, PUT and DELETE, see WithRetryableMethods) and requests marked with WithRetrySafe. A 429 response is retried
+// after the full wait its Retry-After header requests, if any, instead of the backoff. A wait that would outlast
+// the request's context deadline or the client's MaxElapsedTime ends the retries and returns the last response,
+// so the caller gets the 429 with its RetryAfter. Zero disables retries.
+func WithMaxRetries(n int) Option {
+	return func(c *Client) {
+		c.retry.maxRetries = n
+	}
+}
+
+// WithRetryBackoff sets the exponential backoff between retries, starting at base and capped at maxDelay.
+func WithRetryBackoff(base, maxDelay time.Duration) Option {
+	return func(c *Client) {
+		c.retry.baseDelay = base
+		c.retry.maxDelay = maxDelay
+	}
+}
+
+// WithRetryMultiplier sets the factor by which the backoff delay grows after each retry (default 2).
+func WithRetryMultiplier(multiplier float64) Option {
+	return func(c *Client) {
+		c.retry.multiplier = multiplier
+	}
+}
+
+// WithRetryableMethods replaces the methods retried after a connection error or a 502, 503 or 504 response,
+// which default to the idempotent GET, HEAD, OPTIONS, PUT and DELETE. For example, POST can be included for
+// endpoints made safe with idempotency keys, or DELETE left out, and no methods at all turns these retries off.
+// Methods are case-insensitive. Unknown HTTP methods are silently ignored, as an Option cannot fail; use
+// NewClientFromConfig to have them rejected. When none of the methods is known, the default methods are kept,
+// so a typo cannot turn retries off.
+// Requests marked with WithRetrySafe are still retried.
+func WithRetryableMethods(methods ...string) Option {
+	return func(c *Client) {
+		set := make(map[string]bool, len(methods))
+		for _, method := range methods {
+			if normalized := normalizeMethod(method); knownMethods[normalized] {
+				set[normalized] = true
+			}
+		}
+		if len(set) == 0 && len(methods) > 0 {
+			return
+		}
+		c.retry.methods = set
+	}
+}
+
+// WithJitterStrategy selects how the backoff delay is randomized. Full jitter spreads retries of many
+// clients the most, equal jitter keeps each delay at least half the exponential backoff. Defaults to equal jitter.
+func WithJitterStrategy(strategy models.JitterStrategy) Option {
+	return func(c *Client) {
+		c.retry.jitter = strategy
+	}
+}
+
+// WithFailFastOnAuthError makes 401 and 403 responses abort immediately, even when their error name is
+// registered with WithRetryOnErrorNames. Authentication failures are not transient, so retrying them only
+// delays the feedback on a misconfigured client.
+func WithFailFastOnAuthError(enabled bool) Option {
+	return func(c *Client) {
+		c.retry.failFast = enabled
+	}
+}
+
+// WithRetryOnTruncatedBody retries GET requests whose JSON response body ends prematurely, as happens when
+// a flaky connection is cut mid-response. A body that is complete but malformed is not retried. Retries are
+// enabled with DefaultMaxRetries unless WithMaxRetries sets a limit. The body of successful JSON responses
+// to GET requests is buffered to inspect it.
+func WithRetryOnTruncatedBody() Option {
+	return func(c *Client) {
+		c.retry.truncated = true
+		if c.retry.maxRetries == 0 {
+			c.retry.maxRetries = DefaultMaxRetries
+		}
+	}
+}
+
+// WithRetryOnErrorNames retries error responses whose problem details carry one of the given names,
+// e.g. a transient "StorageException", regardless of the HTTP status. Like gateway errors, they are only
+// retried for idempotent methods (see WithRetryableMethods) and requests marked with WithRetrySafe, since the
+// registry may have applied the request before failing. Retries are enabled with DefaultMaxRetries unless
+// WithMaxRetries sets a limit.
+func WithRetryOnErrorNames(names ...string) Option {
+	return func(c *Client) {
+		if c.retry.errorNames == nil {
+			c.retry.errorNames = make(map[string]bool, len(names))
+		}
+		for _, name := range names {
+			c.retry.errorNames[name] = true
+		}
+		if c.retry.maxRetries == 0 {
+			c.retry.maxRetries = DefaultMaxRetries
+		}
+	}
+}
+
+// shouldRetry reports whether the outcome of an attempt is transient.
+// The response body is buffered and restored when it has to be inspected.
+func (p *retryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if noRetryFromContext(req.Context()) {
+		return false
+	}
+	if err != nil {
+		return req.Context().Err() == nil && p.isRetrySafe(req)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		// The request was rejected before being processed, so it is safe to repeat.
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return p.isRetrySafe(req)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		if p.failFast {
+			return false
+		}
+	}
+
+	if len(p.errorNames) > 0 && resp.StatusCode >= http.StatusBadRequest && p.isRetrySafe(req) {
+		return p.errorNames[peekErrorName(resp)]
+	}
+
+	if p.truncated && req.Method == http.MethodGet && resp.StatusCode == http.StatusOK && isJSON(resp) {
+		return peekTruncated(resp)
+	}
+
+	return false
+}
+
+// isRetrySafe reports whether repeating the request cannot apply it twice: its method is idempotent, or one
+// of the methods set with WithRetryableMethods, or the request was marked with WithRetrySafe.
+func (p *retryPolicy) isRetrySafe(req *http.Request) bool {
+	if p.methods != nil {
+		return p.methods[req.Method] || retrySafeFromContext(req.Context())
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return retrySafeFromContext(req.Context())
+}
+
+// knownMethods are the HTTP methods accepted by WithRetryableMethods.
+var knownMethods = map[string]bool{
+	http.MethodGet: true, http.MethodHead: true, http.MethodPost: true, http.MethodPut: true,
+	http.MethodPatch: true, http.MethodDelete: true, http.MethodConnect: true, http.MethodOptions: true,
+	http.MethodTrace: true,
+}
+
+// normalizeMethod returns method in the upper case used by net/http.
+func normalizeMethod(method string) string {
+	return strings.ToUpper(strings.TrimSpace(method))
+}
+
+// validateMethods returns an error naming the first unknown HTTP method.
+func validateMethods(methods []string) error {
+	for _, method := range methods {
+		if !knownMethods[normalizeMethod(method)] {
+			return errors.Errorf("unknown HTTP method %q", method)
+		}
+	}
+	return nil
+}
+
+// isJSON reports whether the response declares a JSON body.
+func isJSON(resp *http.Response) bool {
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// peekTruncated reports whether the JSON response body ends prematurely and restores the body.
+// A read cut short by the connection counts as truncated as well; any other read error is replayed
+// to the caller after the buffered part of the body.
+func peekTruncated(resp *http.Response) bool {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), &errReader{err: err}))
+		return errors.Is(err, io.ErrUnexpectedEOF)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var value json.RawMessage
+	err = json.NewDecoder(bytes.NewReader(body)).Decode(&value)
+	return errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// errReader returns err from every read.
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// delay returns the wait before the given retry (1-based): the full Retry-After of a 429 response when
+// it requests one, otherwise the backoff.
+func (p *retryPolicy) delay(retry int, resp *http.Response) time.Duration {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		if wait := models.ParseRetryAfter(resp.Header.Get("Retry-After")); wait > 0 {
+			return wait
+		}
+	}
+	return p.backoff(retry)
+}
+
+// maxBackoff returns the longest delay between two attempts.
+func (p *retryPolicy) maxBackoff() time.Duration {
+	if p.maxDelay <= 0 {
+		return DefaultRetryMaxDelay
+	}
+	return p.maxDelay
+}
+
+// backoff returns the delay before the given retry (1-based), randomized by the jitter strategy.
+func (p *retryPolicy) backoff(retry int) time.Duration {
+	base, maxDelay := p.baseDelay, p.maxBackoff()
+	if base <= 0 {
+		base = DefaultRetryBaseDelay
+	}
+
+	multiplier := p.multiplier
+	if multiplier < 1 {
+		multiplier = DefaultRetryMultiplier
+	}
+
+	delay := base
+	for i := 1; i < retry && delay < maxDelay; i++ {
+		delay = time.Duration(float64(delay) * multiplier)
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	switch p.jitter {
+	case models.JitterNone:
+		return delay
+	case models.JitterFull:
+		return rand.N(delay + 1)
+	default:
+		half := delay / 2
+		return delay - half + rand.N(half+1)
+	}
+}
+
+// peekErrorName reads the problem-detail name from an error response and restores the body.
+func peekErrorName(resp *http.Response) string {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	var problem struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(body, &problem); err != nil {
+		return ""
+	}
+	return problem.Name
+}
+
+// canRewindBody reports whether the request body can be sent again.
+func canRewindBody(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// rewindBody resets the request body before a retry.
+func rewindBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return errors.Wrap(err, "failed to rewind request body")
+	}
+	req.Body = body
+	return nil
+}
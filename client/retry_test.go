@@ -0,0 +1,594 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mollie/go-apicurio-registry/client"
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFailingServer responds with the given errors, one per attempt, then succeeds.
+func newFailingServer(t *testing.T, failures []models.APIError, attempts *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*attempts++
+		if *attempts <= len(failures) {
+			failure := failures[*attempts-1]
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(failure.Status)
+			assert.NoError(t, json.NewEncoder(w).Encode(failure))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"count": 1}`))
+	}))
+}
+
+func TestClient_Do_RetryOnErrorNames(t *testing.T) {
+	t.Run("Retries Named Transient Error", func(t *testing.T) {
+		attempts := 0
+		server := newFailingServer(t, []models.APIError{
+			{Status: http.StatusInternalServerError, Name: "StorageException"},
+			{Status: http.StatusInternalServerError, Name: "StorageException"},
+		}, &attempts)
+		defer server.Close()
+
+		c := client.NewClient(
+			server.URL,
+			client.WithRetryOnErrorNames("StorageException"),
+			client.WithRetryBackoff(time.Millisecond, 5*time.Millisecond),
+		)
+
+		req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"a": "1"}`))
+		assert.NoError(t, err)
+
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("POST Retried Only When Marked Safe", func(t *testing.T) {
+		failure := models.APIError{Status: http.StatusInternalServerError, Name: "StorageException"}
+		for _, tc := range []struct {
+			name     string
+			ctx      context.Context
+			status   int
+			attempts int
+		}{
+			{name: "Unmarked", ctx: context.Background(), status: http.StatusInternalServerError, attempts: 1},
+			{name: "Marked", ctx: client.WithRetrySafe(context.Background()), status: http.StatusOK, attempts: 2},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				attempts := 0
+				server := newFailingServer(t, []models.APIError{failure}, &attempts)
+				defer server.Close()
+
+				c := client.NewClient(
+					server.URL,
+					client.WithRetryOnErrorNames("StorageException"),
+					client.WithRetryBackoff(time.Millisecond, 5*time.Millisecond),
+				)
+
+				req, err := http.NewRequestWithContext(tc.ctx, http.MethodPost, server.URL, strings.NewReader(`{"a": "1"}`))
+				assert.NoError(t, err)
+
+				resp, err := c.Do(req)
+				assert.NoError(t, err)
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.status, resp.StatusCode)
+				assert.Equal(t, tc.attempts, attempts, "a POST may have been applied before failing")
+			})
+		}
+	})
+
+	t.Run("Does Not Retry Other Error", func(t *testing.T) {
+		attempts := 0
+		server := newFailingServer(t, []models.APIError{
+			{Status: http.StatusInternalServerError, Name: "NullPointerException", Title: "boom"},
+		}, &attempts)
+		defer server.Close()
+
+		c := client.NewClient(
+			server.URL,
+			client.WithRetryOnErrorNames("StorageException"),
+			client.WithRetryBackoff(time.Millisecond, 5*time.Millisecond),
+		)
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Equal(t, 1, attempts)
+
+		// The inspected body is still available to the caller.
+		var apiErr models.APIError
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(body, &apiErr))
+		assert.Equal(t, "NullPointerException", apiErr.Name)
+	})
+
+	t.Run("Gives Up After Max Retries", func(t *testing.T) {
+		attempts := 0
+		failure := models.APIError{Status: http.StatusInternalServerError, Name: "StorageException"}
+		server := newFailingServer(t, []models.APIError{failure, failure, failure}, &attempts)
+		defer server.Close()
+
+		c := client.NewClient(
+			server.URL,
+			client.WithRetryOnErrorNames("StorageException"),
+			client.WithMaxRetries(1),
+			client.WithRetryBackoff(time.Millisecond, 5*time.Millisecond),
+		)
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Equal(t, 2, attempts)
+	})
+}
+
+func TestClient_Do_FailFastOnAuthError(t *testing.T) {
+	unauthorized := models.APIError{Status: http.StatusUnauthorized, Name: "NotAuthorizedException"}
+
+	t.Run("Unauthorized Not Retried", func(t *testing.T) {
+		attempts := 0
+		server := newFailingServer(t, []models.APIError{unauthorized, unauthorized}, &attempts)
+		defer server.Close()
+
+		c := client.NewClient(
+			server.URL,
+			client.WithRetryOnErrorNames("NotAuthorizedException"),
+			client.WithRetryBackoff(time.Second, time.Second),
+			client.WithFailFastOnAuthError(true),
+		)
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+
+		start := time.Now()
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(t, 1, attempts)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("Retried Without Fail Fast", func(t *testing.T) {
+		attempts := 0
+		server := newFailingServer(t, []models.APIError{unauthorized}, &attempts)
+		defer server.Close()
+
+		c := client.NewClient(
+			server.URL,
+			client.WithRetryOnErrorNames("NotAuthorizedException"),
+			client.WithRetryBackoff(time.Millisecond, 5*time.Millisecond),
+		)
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 2, attempts)
+	})
+}
+
+func TestClient_Do_RetryOnTruncatedBody(t *testing.T) {
+	newServer := func(first string, attempts *int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*attempts++
+			w.Header().Set("Content-Type", "application/json")
+			if *attempts == 1 {
+				_, _ = w.Write([]byte(first))
+				return
+			}
+			_, _ = w.Write([]byte(`{"count": 1}`))
+		}))
+	}
+	get := func(t *testing.T, c *client.Client, url string) string {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		assert.NoError(t, err)
+
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		return string(body)
+	}
+
+	t.Run("Truncated Body Retried", func(t *testing.T) {
+		attempts := 0
+		server := newServer(`{"count": `, &attempts)
+		defer server.Close()
+
+		c := client.NewClient(
+			server.URL,
+			client.WithRetryOnTruncatedBody(),
+			client.WithRetryBackoff(time.Millisecond, 5*time.Millisecond),
+		)
+
+		assert.Equal(t, `{"count": 1}`, get(t, c, server.URL))
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("Connection Cut Retried", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.Header().Set("Content-Type", "application/json")
+			if attempts == 1 {
+				// Announce more bytes than are sent, so the client sees the connection close mid-body.
+				w.Header().Set("Content-Length", "100")
+				_, _ = w.Write([]byte(`{"count": `))
+				return
+			}
+			_, _ = w.Write([]byte(`{"count": 1}`))
+		}))
+		defer server.Close()
+
+		c := client.NewClient(
+			server.URL,
+			client.WithRetryOnTruncatedBody(),
+			client.WithRetryBackoff(time.Millisecond, 5*time.Millisecond),
+		)
+
+		assert.Equal(t, `{"count": 1}`, get(t, c, server.URL))
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("Malformed Body Not Retried", func(t *testing.T) {
+		attempts := 0
+		server := newServer(`{"count": ]`, &attempts)
+		defer server.Close()
+
+		c := client.NewClient(
+			server.URL,
+			client.WithRetryOnTruncatedBody(),
+			client.WithRetryBackoff(time.Millisecond, 5*time.Millisecond),
+		)
+
+		assert.Equal(t, `{"count": ]`, get(t, c, server.URL))
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("Disabled By Default", func(t *testing.T) {
+		attempts := 0
+		server := newServer(`{"count": `, &attempts)
+		defer server.Close()
+
+		c := client.NewClient(server.URL)
+
+		assert.Equal(t, `{"count": `, get(t, c, server.URL))
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestClient_JitterStrategy(t *testing.T) {
+	const (
+		base     = 10 * time.Millisecond
+		maxDelay = 80 * time.Millisecond
+		samples  = 1000
+	)
+
+	for _, tc := range []struct {
+		name     string
+		strategy models.JitterStrategy
+		lower    func(d time.Duration) time.Duration
+	}{
+		{name: "Default Is Equal", strategy: "", lower: func(d time.Duration) time.Duration { return d / 2 }},
+		{name: "Equal", strategy: models.JitterEqual, lower: func(d time.Duration) time.Duration { return d / 2 }},
+		{name: "Full", strategy: models.JitterFull, lower: func(time.Duration) time.Duration { return 0 }},
+		{name: "None", strategy: models.JitterNone, lower: func(d time.Duration) time.Duration { return d }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := client.NewClient(
+				"http://localhost",
+				client.WithRetryBackoff(base, maxDelay),
+				client.WithJitterStrategy(tc.strategy),
+			)
+
+			for retry, delay := range []time.Duration{10, 20, 40, 80, 80} {
+				delay *= time.Millisecond
+				lower := tc.lower(delay)
+				var sum time.Duration
+				for i := 0; i < samples; i++ {
+					d := c.RetryBackoff(retry + 1)
+					assert.GreaterOrEqual(t, d, lower)
+					assert.LessOrEqual(t, d, delay)
+					sum += d
+				}
+				if lower < delay {
+					// The mean of a uniform distribution over [lower, delay] sits in the middle.
+					mean := sum / samples
+					mid := (lower + delay) / 2
+					assert.InDelta(t, float64(mid), float64(mean), float64(delay-lower)/10)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_Do_RetryConfig(t *testing.T) {
+	var attempts []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts = append(attempts, time.Now())
+		if len(attempts) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	newClient := func(t *testing.T) *client.Client {
+		c, err := client.NewClientFromConfig(client.Config{
+			BaseURL: server.URL,
+			Retry: &client.RetryConfig{
+				MaxRetries: 3,
+				BaseDelay:  20 * time.Millisecond,
+				MaxDelay:   time.Second,
+				Multiplier: 3,
+			},
+		})
+		assert.NoError(t, err)
+		return c
+	}
+
+	t.Run("Retries With Growing Backoff", func(t *testing.T) {
+		attempts = nil
+		c := newClient(t)
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		if assert.Len(t, attempts, 3) {
+			assert.GreaterOrEqual(t, attempts[1].Sub(attempts[0]), 20*time.Millisecond)
+			assert.GreaterOrEqual(t, attempts[2].Sub(attempts[1]), 60*time.Millisecond)
+		}
+		assert.Equal(t, 20*time.Millisecond, c.RetryBackoff(1))
+		assert.Equal(t, 60*time.Millisecond, c.RetryBackoff(2))
+		assert.Equal(t, 180*time.Millisecond, c.RetryBackoff(3))
+	})
+
+	t.Run("POST Not Retried", func(t *testing.T) {
+		attempts = nil
+		c := newClient(t)
+
+		req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{}`))
+		assert.NoError(t, err)
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Len(t, attempts, 1)
+	})
+
+	t.Run("Retry-Safe POST Retried", func(t *testing.T) {
+		attempts = nil
+		c := newClient(t)
+
+		ctx := client.WithRetrySafe(context.Background())
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, strings.NewReader(`{}`))
+		assert.NoError(t, err)
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Len(t, attempts, 3)
+	})
+}
+
+func TestClient_Do_RetryAfter(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		retryAfter func() string
+	}{
+		{name: "Seconds", retryAfter: func() string { return "1" }},
+		{name: "HTTP Date", retryAfter: func() string { return time.Now().Add(2 * time.Second).UTC().Format(http.TimeFormat) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts []time.Time
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts = append(attempts, time.Now())
+				if len(attempts) == 1 {
+					w.Header().Set("Retry-After", tc.retryAfter())
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			// The backoff alone would wait far longer than the test timeout.
+			c := client.NewClient(
+				server.URL,
+				client.WithMaxRetries(1),
+				client.WithRetryBackoff(time.Minute, time.Minute),
+				client.WithJitterStrategy(models.JitterNone),
+			)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, strings.NewReader(`{}`))
+			assert.NoError(t, err)
+			resp, err := c.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			if assert.Len(t, attempts, 2) {
+				// HTTP dates have a one second resolution, so the wait can be up to a second shorter.
+				assert.GreaterOrEqual(t, attempts[1].Sub(attempts[0]), 900*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestClient_Do_RetryAfterLongerThanMaxDelay(t *testing.T) {
+	var attempts []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts = append(attempts, time.Now())
+		if len(attempts) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := client.NewClient(
+		server.URL,
+		client.WithMaxRetries(1),
+		client.WithRetryBackoff(time.Millisecond, 10*time.Millisecond),
+	)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+	resp, err := c.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	if assert.Len(t, attempts, 2) {
+		assert.GreaterOrEqual(t, attempts[1].Sub(attempts[0]), time.Second, "the server's wait is not shortened")
+	}
+}
+
+func TestClient_Do_RetryAfterBeyondDeadline(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name    string
+		options []client.Option
+		timeout time.Duration
+	}{
+		{name: "Context Deadline", timeout: time.Second},
+		{name: "Max Elapsed Time", options: []client.Option{client.WithMaxElapsedTime(time.Second)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts = 0
+			c := client.NewClient(server.URL, append(tc.options, client.WithMaxRetries(3))...)
+
+			ctx := context.Background()
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+			assert.NoError(t, err)
+
+			start := time.Now()
+			resp, err := c.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "the 429 is returned instead of waiting past the deadline")
+			assert.Equal(t, "30", resp.Header.Get("Retry-After"))
+			assert.Equal(t, 1, attempts)
+			assert.Less(t, time.Since(start), time.Second)
+		})
+	}
+}
+
+func TestClient_Do_RetryableMethods(t *testing.T) {
+	attempts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts[r.Method]++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c, err := client.NewClientFromConfig(client.Config{
+		BaseURL: server.URL,
+		Retry: &client.RetryConfig{
+			MaxRetries: 2,
+			BaseDelay:  time.Millisecond,
+			Methods:    []string{"post", http.MethodGet},
+		},
+	})
+	assert.NoError(t, err)
+
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete} {
+		req, err := http.NewRequest(method, server.URL, strings.NewReader(`{}`))
+		assert.NoError(t, err)
+		resp, err := c.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, map[string]int{
+		http.MethodGet:    3,
+		http.MethodPost:   3,
+		http.MethodPut:    1,
+		http.MethodDelete: 1,
+	}, attempts)
+
+	t.Run("Unknown Method", func(t *testing.T) {
+		_, err := client.NewClientFromConfig(client.Config{
+			BaseURL: server.URL,
+			Retry:   &client.RetryConfig{MaxRetries: 2, Methods: []string{"FETCH"}},
+		})
+		assert.ErrorContains(t, err, `unknown HTTP method "FETCH"`)
+	})
+
+	t.Run("Only Unknown Methods Keep The Defaults", func(t *testing.T) {
+		clear(attempts)
+		c := client.NewClient(
+			server.URL,
+			client.WithMaxRetries(2),
+			client.WithRetryBackoff(time.Millisecond, time.Millisecond),
+			client.WithRetryableMethods("PSOT"),
+		)
+
+		for _, method := range []string{http.MethodGet, http.MethodPost} {
+			req, err := http.NewRequest(method, server.URL, strings.NewReader(`{}`))
+			assert.NoError(t, err)
+			resp, err := c.Do(req)
+			assert.NoError(t, err)
+			resp.Body.Close()
+		}
+		assert.Equal(t, map[string]int{http.MethodGet: 3, http.MethodPost: 1}, attempts)
+	})
+}
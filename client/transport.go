@@ -0,0 +1,68 @@
+package client
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+// TransportMiddleware wraps the transport of the client's HTTP client, e.g. for tracing or logging.
+type TransportMiddleware func(http.RoundTripper) http.RoundTripper
+
+// WithTransport wraps the transport of the HTTP client in the given middlewares without replacing the
+// client. The first middleware is the outermost: it sees each request first and each response last.
+// Repeated calls append to the chain. The middlewares run below Do, so they see every attempt of a
+// retried request separately, with the Authorization and all other headers already set, and responses
+// before gzip decoding. The wrapping is applied to a copy of a client supplied through WithHTTPClient.
+func WithTransport(middlewares ...TransportMiddleware) Option {
+	return func(c *Client) {
+		c.transportMiddlewares = append(c.transportMiddlewares, middlewares...)
+	}
+}
+
+// wrapTransport installs the transport middlewares on a copy of the HTTP client.
+func (c *Client) wrapTransport() {
+	if len(c.transportMiddlewares) == 0 || c.HTTPClient == nil {
+		return
+	}
+
+	transport := c.HTTPClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(c.transportMiddlewares) - 1; i >= 0; i-- {
+		transport = c.transportMiddlewares[i](transport)
+	}
+
+	httpClient := *c.HTTPClient
+	httpClient.Transport = transport
+	c.HTTPClient = &httpClient
+}
+
+// RoundTripperFunc adapts a function to an http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// LogRequests is a TransportMiddleware that logs the method, URL, status and duration of every request
+// to logger, or to the standard logger when logger is nil.
+func LogRequests(logger *log.Logger) TransportMiddleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				logger.Printf("%s %s failed after %s: %v", req.Method, req.URL, time.Since(start), err)
+				return nil, err
+			}
+			logger.Printf("%s %s %d %s", req.Method, req.URL, resp.StatusCode, time.Since(start))
+			return resp, nil
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/mollie/go-apicurio-registry/internal/plumbing"
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/pkg/errors"
+)
+
+// WarmUp opens a connection to the registry ahead of the first real request by pinging the system info endpoint.
+// When the content cache is enabled (see WithContentCache), the content of the given global IDs is preloaded
+// into it so hot paths such as deserializers do not pay for the first lookup.
+func (c *Client) WarmUp(ctx context.Context, globalIDs ...int64) error {
+	ctx = WithOperationName(ctx, "WarmUp")
+
+	if _, _, err := c.warmUpGet(ctx, fmt.Sprintf("%s/system/info", c.BaseURL)); err != nil {
+		return errors.Wrap(err, "failed to ping registry")
+	}
+
+	if !c.contentCacheEnabled() {
+		return nil
+	}
+
+	for _, globalID := range globalIDs {
+		body, header, err := c.warmUpGet(ctx, fmt.Sprintf("%s/ids/globalIds/%d?returnType=true", c.BaseURL, globalID))
+		if err != nil {
+			return errors.Wrapf(err, "failed to preload global ID %d", globalID)
+		}
+
+		artifactType, _ := models.ParseArtifactType(header.Get("X-Registry-ArtifactType"))
+		c.cacheContent(globalID, plumbing.CachedContent{
+			Content:      string(body),
+			ArtifactType: artifactType,
+			GroupID:      header.Get("X-Registry-GroupId"),
+			ArtifactID:   header.Get("X-Registry-ArtifactId"),
+		})
+	}
+
+	return nil
+}
+
+// CheckConnection verifies that the registry is reachable and the client is authorized by fetching the system info,
+// which it returns so callers can log or check the registry version. See models.SystemInfoResponse.BuildTime for
+// the build timestamp.
+func (c *Client) CheckConnection(ctx context.Context) (*models.SystemInfoResponse, error) {
+	ctx = WithOperationName(ctx, "CheckConnection")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/system/info", c.BaseURL), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create HTTP request")
+	}
+
+	var info models.SystemInfoResponse
+	if err := c.DoJSON(req, &info); err != nil {
+		return nil, errors.Wrap(err, "failed to connect to registry")
+	}
+	return &info, nil
+}
+
+// warmUpGet performs a GET request and returns the body of a 200 response.
+func (c *Client) warmUpGet(ctx context.Context, url string) ([]byte, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, errors.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return body, resp.Header, nil
+}
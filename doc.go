@@ -16,13 +16,15 @@
 // The library is structured into the following key components:
 //
 //  1. **Client**: Provides an entry point for interacting with the registry.
-//     Use the `client.NewApicurioClient` function to create a new client instance.
+//     Use the `client.NewClient` function to create a new client instance.
 //
 //  2. **APIs**: Contains modular functions for specific operations such as managing artifacts,
 //     branches, versions, groups, and performing administrative tasks.
 //
 // 3. **Models**: Defines data structures for requests, responses, and errors used across the library.
 //
+// 4. **Serde**: Kafka serializers that are wire-compatible with the Confluent Schema Registry serializers.
+//
 // Example Usage:
 //
 // The following example demonstrates how to create a new artifact and retrieve its metadata:
@@ -30,35 +32,44 @@
 //	package main
 //
 //	import (
+//		"context"
 //		"fmt"
+//
+//		"github.com/mollie/go-apicurio-registry/apis"
 //		"github.com/mollie/go-apicurio-registry/client"
 //		"github.com/mollie/go-apicurio-registry/models"
 //	)
 //
 //	func main() {
+//		ctx := context.Background()
+//
 //		// Initialize the client
-//		config := client.Config{
-//			BaseURL: "https://my-registry.example.com",
-//			AuthToken: "my-token",
-//		}
-//		apiClient := client.NewApicurioClient(config)
+//		apiClient := client.NewClient(
+//			"https://my-registry.example.com/apis/registry/v3",
+//			client.WithAuthToken("my-token"),
+//		)
+//		artifactsAPI := apis.NewArtifactsAPI(apiClient)
 //
 //		// Create a new artifact
-//		artifact := models.Artifact{
-//			GroupID: "example-group",
-//			ID: "example-artifact",
-//			Content: []byte(`{"type": "record", "name": "Example", "fields": [{"name": "field1", "type": "string"}]}`),
-//			ContentType: "application/json",
+//		artifact := models.CreateArtifactRequest{
+//			ArtifactID:   "example-artifact",
+//			ArtifactType: models.Avro,
+//			FirstVersion: models.CreateVersionRequest{
+//				Content: models.CreateContentRequest{
+//					Content:     `{"type": "record", "name": "Example", "fields": [{"name": "field1", "type": "string"}]}`,
+//					ContentType: "application/json",
+//				},
+//			},
 //		}
-//		response, err := apiClient.Artifacts.Create(artifact)
+//		response, err := artifactsAPI.CreateArtifact(ctx, "example-group", artifact, nil)
 //		if err != nil {
 //			fmt.Printf("Error creating artifact: %v\n", err)
 //			return
 //		}
-//		fmt.Printf("Artifact created with ID: %s\n", response.ID)
+//		fmt.Printf("Artifact created with ID: %s\n", response.ArtifactID)
 //
 //		// Retrieve artifact metadata
-//		metadata, err := apiClient.Artifacts.GetMetadata("example-group", "example-artifact")
+//		metadata, err := apis.NewMetadataAPI(apiClient).GetArtifactMetadata(ctx, "example-group", "example-artifact")
 //		if err != nil {
 //			fmt.Printf("Error retrieving metadata: %v\n", err)
 //			return
@@ -0,0 +1,53 @@
+package lru_test
+
+import (
+	"testing"
+
+	"github.com/mollie/go-apicurio-registry/internal/lru"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	t.Run("Evicts Least Recently Used", func(t *testing.T) {
+		cache := lru.New[int, string](2)
+		cache.Add(1, "one")
+		cache.Add(2, "two")
+
+		_, ok := cache.Get(1)
+		assert.True(t, ok)
+
+		cache.Add(3, "three")
+		_, ok = cache.Get(2)
+		assert.False(t, ok, "2 was least recently used")
+
+		value, ok := cache.Get(1)
+		assert.True(t, ok)
+		assert.Equal(t, "one", value)
+		assert.Equal(t, 2, cache.Len())
+	})
+
+	t.Run("Remove And Purge", func(t *testing.T) {
+		cache := lru.New[string, int](4)
+		cache.Add("a", 1)
+		cache.Add("b", 2)
+
+		assert.True(t, cache.Remove("a"))
+		assert.False(t, cache.Remove("a"))
+		assert.Equal(t, 1, cache.Len())
+
+		cache.Purge()
+		assert.Equal(t, 0, cache.Len())
+	})
+	t.Run("Remove Func", func(t *testing.T) {
+		cache := lru.New[int, string](4)
+		cache.Add(1, "odd")
+		cache.Add(2, "even")
+		cache.Add(3, "odd")
+
+		removed := cache.RemoveFunc(func(_ int, value string) bool { return value == "odd" })
+		assert.Equal(t, 2, removed)
+		assert.Equal(t, 1, cache.Len())
+		_, ok := cache.Get(2)
+		assert.True(t, ok)
+	})
+}
@@ -0,0 +1,17 @@
+package plumbing
+
+import (
+	"io"
+	"net/http"
+)
+
+// maxDrainBytes bounds how much of an unread response body DrainBody discards. Connections with more left
+// to read are closed rather than drained.
+const maxDrainBytes = 1 << 20
+
+// DrainBody reads what is left of the response body and closes it, so the connection can be reused.
+// Decoding a JSON document stops at its end, which would otherwise leave trailing bytes unread.
+func DrainBody(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+	_ = resp.Body.Close()
+}
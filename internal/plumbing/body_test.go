@@ -0,0 +1,45 @@
+package plumbing_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/mollie/go-apicurio-registry/internal/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+// trackingBody records how much of it was read and whether it was closed.
+type trackingBody struct {
+	io.Reader
+	read   int
+	closed bool
+}
+
+func (b *trackingBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	b.read += n
+	return n, err
+}
+
+func (b *trackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestDrainBody(t *testing.T) {
+	t.Run("Small Body Is Drained", func(t *testing.T) {
+		body := &trackingBody{Reader: bytes.NewReader(make([]byte, 1024))}
+		plumbing.DrainBody(&http.Response{Body: body})
+		assert.Equal(t, 1024, body.read)
+		assert.True(t, body.closed)
+	})
+
+	t.Run("Large Body Is Closed Without Reading It All", func(t *testing.T) {
+		body := &trackingBody{Reader: bytes.NewReader(make([]byte, 4<<20))}
+		plumbing.DrainBody(&http.Response{Body: body})
+		assert.Equal(t, 1<<20, body.read)
+		assert.True(t, body.closed)
+	})
+}
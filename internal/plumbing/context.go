@@ -0,0 +1,96 @@
+package plumbing
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/pkg/errors"
+)
+
+type contextKey int
+
+const (
+	modelDecodersKey contextKey = iota
+	statusHookKey
+)
+
+// StatusHook inspects a response before the default status handling of the API methods; see client.StatusHook.
+type StatusHook func(resp *http.Response) (handled bool, err error)
+
+// WithStatusHook returns a context that carries the status hook of the client sending the request.
+func WithStatusHook(ctx context.Context, hook StatusHook) context.Context {
+	return context.WithValue(ctx, statusHookKey, hook)
+}
+
+// ApplyStatusHook runs the status hook of the client that sent resp, if any, and reports whether the hook handled
+// the response. It is called by the API methods before their default status handling.
+func ApplyStatusHook(resp *http.Response) (bool, error) {
+	if resp.Request == nil {
+		return false, nil
+	}
+	hook, _ := resp.Request.Context().Value(statusHookKey).(StatusHook)
+	if hook == nil {
+		return false, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return true, errors.Wrap(err, "failed to read response body")
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var (
+		handled bool
+		hookErr error
+	)
+	if panicErr := InvokeHook("StatusHook", func() { handled, hookErr = hook(resp) }); panicErr != nil {
+		return true, panicErr
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return handled, hookErr
+}
+
+// ModelDecoder decodes a JSON response body into v; see client.ModelDecoder.
+type ModelDecoder func(data []byte, v interface{}) error
+
+// WithModelDecoders returns a context that carries the model decoders of the client sending the request,
+// keyed by ModelType.
+func WithModelDecoders(ctx context.Context, decoders map[reflect.Type]ModelDecoder) context.Context {
+	return context.WithValue(ctx, modelDecodersKey, decoders)
+}
+
+// ModelDecoderFor returns the decoder registered for the type of v on the client that sent the request
+// ctx belongs to. The API methods use it to decode response bodies.
+func ModelDecoderFor(ctx context.Context, v interface{}) (ModelDecoder, bool) {
+	decoders, _ := ctx.Value(modelDecodersKey).(map[reflect.Type]ModelDecoder)
+	if decoders == nil {
+		return nil, false
+	}
+	decoder, ok := decoders[ModelType(v)]
+	return decoder, ok
+}
+
+// ModelType returns the pointer type decoders are registered and looked up by.
+func ModelType(v interface{}) reflect.Type {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() != reflect.Pointer {
+		t = reflect.PointerTo(t)
+	}
+	return t
+}
+
+// InvokeHook runs a user-supplied hook, converting a panic into a *models.HookPanicError.
+func InvokeHook(name string, hook func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &models.HookPanicError{Hook: name, Value: r}
+		}
+	}()
+	hook()
+	return nil
+}
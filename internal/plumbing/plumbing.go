@@ -0,0 +1,61 @@
+// Package plumbing connects package client to package apis. It carries the client features the API methods
+// rely on that are not part of the public API: request context values set by Client.Do, caches, per-artifact
+// locks and the handling of content before it is sent and after it is fetched.
+package plumbing
+
+import (
+	"context"
+
+	"github.com/mollie/go-apicurio-registry/models"
+)
+
+// Client is the part of a *client.Client that package apis uses beyond its public API.
+type Client interface {
+	// CachedArtifactTypes returns a copy of the cached set of supported artifact types and whether the
+	// cache is populated.
+	CachedArtifactTypes() (map[models.ArtifactType]bool, bool)
+	// CacheArtifactTypes stores the set of artifact types supported by the registry.
+	CacheArtifactTypes(types []models.ArtifactType)
+	// InvalidateArtifactTypes clears the cached set of supported artifact types.
+	InvalidateArtifactTypes()
+
+	// ContentCacheEnabled reports whether the client caches content by global ID.
+	ContentCacheEnabled() bool
+	// CachedContent returns the cached content for a global ID.
+	CachedContent(globalID int64) (CachedContent, bool)
+	// CacheContent stores content for a global ID when the content cache is enabled.
+	CacheContent(globalID int64, content CachedContent)
+	// EvictContent removes the cached content for a global ID.
+	EvictContent(globalID int64)
+	// InvalidateForEvent evicts the cached content an event makes stale, when event-driven invalidation is enabled.
+	InvalidateForEvent(event models.RegistryEvent)
+	// AddInvalidationListener registers a function that InvalidateForEvent calls with every event it applies,
+	// for caches kept outside the client, and returns a function that unregisters it. Nothing is registered
+	// when event-driven invalidation is disabled.
+	AddInvalidationListener(listener func(event models.RegistryEvent)) (remove func())
+
+	// CanonicalizeForCreate returns content in canonical form when canonicalization on create is enabled and
+	// the artifact type supports it, and content unchanged otherwise.
+	CanonicalizeForCreate(artifactType models.ArtifactType, content string) (string, error)
+	// MinifyForWrite returns JSON content without insignificant whitespace when minification is enabled,
+	// and content unchanged otherwise.
+	MinifyForWrite(contentType, content string) string
+	// ValidateRetrievedContent checks fetched content when validation of retrieved content is enabled.
+	ValidateRetrievedContent(artifactType models.ArtifactType, content string) error
+
+	// LockArtifact waits until no other write to the artifact is in progress and returns a function that
+	// releases the artifact. It returns immediately unless writes are serialized per artifact.
+	LockArtifact(ctx context.Context, groupID, artifactID string) (func(), error)
+}
+
+// Of returns the Client view of c, which must be a *client.Client. It is set when package client is
+// initialized, which happens before any package that imports client uses it.
+var Of func(c any) Client
+
+// CachedContent is the content of an artifact version cached by its global ID.
+type CachedContent struct {
+	Content      string
+	ArtifactType models.ArtifactType // Empty when the registry did not report the type
+	GroupID      string              // Empty when the registry did not report the group
+	ArtifactID   string              // Empty when the registry did not report the artifact
+}
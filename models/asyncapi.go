@@ -0,0 +1,64 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ExtractAsyncAPIChannels returns the channels declared by an AsyncAPI document, in declaration order.
+// Both JSON and YAML documents are accepted. For AsyncAPI 2.x the channel names are the keys of the
+// channels object; for AsyncAPI 3.x the channel address is returned, falling back to the channel key
+// when the address is absent or null.
+func ExtractAsyncAPIChannels(content []byte) ([]string, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, errors.Wrap(err, "failed to parse AsyncAPI document")
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("invalid AsyncAPI document: expected an object")
+	}
+	root := document.Content[0]
+
+	version := mappingValue(root, "asyncapi")
+	if version == nil || version.Kind != yaml.ScalarNode {
+		return nil, errors.New("invalid AsyncAPI document: missing asyncapi version")
+	}
+
+	channels := mappingValue(root, "channels")
+	if channels == nil {
+		return []string{}, nil
+	}
+	if channels.Kind != yaml.MappingNode {
+		return nil, errors.New("invalid AsyncAPI document: channels must be an object")
+	}
+
+	isV3 := strings.HasPrefix(version.Value, "3.")
+	names := make([]string, 0, len(channels.Content)/2)
+	for i := 0; i+1 < len(channels.Content); i += 2 {
+		name := channels.Content[i].Value
+		if isV3 {
+			if address := mappingValue(channels.Content[i+1], "address"); address != nil &&
+				address.Kind == yaml.ScalarNode && address.Tag != "!!null" {
+				name = address.Value
+			}
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// mappingValue returns the value node for key in a YAML mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
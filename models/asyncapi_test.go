@@ -0,0 +1,52 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractAsyncAPIChannels(t *testing.T) {
+	t.Run("AsyncAPI 2 YAML", func(t *testing.T) {
+		content := []byte(`
+asyncapi: 2.6.0
+info:
+  title: Payments
+  version: 1.0.0
+channels:
+  payments/created:
+    subscribe:
+      message:
+        payload:
+          type: object
+  payments/refunded:
+    publish:
+      message:
+        payload:
+          type: object
+`)
+		channels, err := models.ExtractAsyncAPIChannels(content)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"payments/created", "payments/refunded"}, channels)
+	})
+
+	t.Run("AsyncAPI 3 JSON", func(t *testing.T) {
+		content := []byte(`{
+			"asyncapi": "3.0.0",
+			"info": {"title": "Payments", "version": "1.0.0"},
+			"channels": {
+				"paymentCreated": {"address": "payments.created"},
+				"dynamic": {"address": null}
+			}
+		}`)
+		channels, err := models.ExtractAsyncAPIChannels(content)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"payments.created", "dynamic"}, channels)
+	})
+
+	t.Run("Not AsyncAPI", func(t *testing.T) {
+		_, err := models.ExtractAsyncAPIChannels([]byte(`{"openapi": "3.0.0", "paths": {}}`))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,248 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var avroNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateAvroSchema parses an Avro schema before it is uploaded, so malformed schemas are caught without a
+// round-trip to the registry. It checks that records, enums and fixed types have valid names that are defined
+// only once, that every record field has a name and a type, that type references resolve to primitives or to
+// named types defined earlier in the schema, and that unions neither nest nor list the same type twice.
+// The returned *SchemaError points at the part of the schema that failed.
+func ValidateAvroSchema(content []byte) error {
+	root, err := decodeJSON(content)
+	if err != nil {
+		return syntaxSchemaError("Avro schema", err)
+	}
+	v := avroValidator{defined: make(map[string]bool)}
+	return v.validate(root, "", "")
+}
+
+// avroValidator walks an Avro schema in definition order.
+type avroValidator struct {
+	defined map[string]bool // Fullnames of the named types defined so far
+}
+
+func avroError(path, format string, args ...interface{}) *SchemaError {
+	return &SchemaError{Format: "Avro schema", Path: path, Message: fmt.Sprintf(format, args...)}
+}
+
+func (v *avroValidator) validate(schema interface{}, namespace, path string) error {
+	switch s := schema.(type) {
+	case string:
+		return v.validateReference(s, namespace, path)
+	case []interface{}:
+		return v.validateUnion(s, namespace, path)
+	case map[string]interface{}:
+		return v.validateComplex(s, namespace, path)
+	}
+	return avroError(path, "schema must be a type name, a union or an object, got %s", jsonKind(schema))
+}
+
+// validateReference checks that name is a primitive type or a named type defined earlier.
+func (v *avroValidator) validateReference(name, namespace, path string) error {
+	if avroPrimitives[name] || v.defined[avroFullname(name, namespace)] || v.defined[name] {
+		return nil
+	}
+	return avroError(path, "undefined type %q", name)
+}
+
+func (v *avroValidator) validateUnion(branches []interface{}, namespace, path string) error {
+	seen := make(map[string]bool, len(branches))
+	for i, branch := range branches {
+		branchPath := fmt.Sprintf("%s/%d", path, i)
+		if _, ok := branch.([]interface{}); ok {
+			return avroError(branchPath, "unions must not immediately contain other unions")
+		}
+		if err := v.validate(branch, namespace, branchPath); err != nil {
+			return err
+		}
+		key := avroUnionKey(branch, namespace)
+		if seen[key] {
+			return avroError(branchPath, "union contains %s more than once", key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+func (v *avroValidator) validateComplex(s map[string]interface{}, namespace, path string) error {
+	value, found := s["type"]
+	if !found {
+		return avroError(path, "missing type")
+	}
+	typeName, ok := value.(string)
+	if !ok {
+		// {"type": {...}} or {"type": [...]} wraps another schema.
+		return v.validate(value, namespace, path+"/type")
+	}
+
+	switch typeName {
+	case "record", "error", "enum", "fixed":
+		return v.validateNamed(s, typeName, namespace, path)
+	case "array":
+		items, found := s["items"]
+		if !found {
+			return avroError(path, "array without items")
+		}
+		return v.validate(items, namespace, path+"/items")
+	case "map":
+		values, found := s["values"]
+		if !found {
+			return avroError(path, "map without values")
+		}
+		return v.validate(values, namespace, path+"/values")
+	}
+	// Primitive with attributes (e.g. a logical type) or a reference to a named type.
+	return v.validateReference(typeName, namespace, path+"/type")
+}
+
+func (v *avroValidator) validateNamed(s map[string]interface{}, typeName, namespace, path string) error {
+	value, found := s["name"]
+	if !found {
+		return avroError(path, "%s without name", typeName)
+	}
+	name, ok := value.(string)
+	if !ok {
+		return avroError(path+"/name", "name must be a string")
+	}
+	if ns, found := s["namespace"]; found {
+		nsName, ok := ns.(string)
+		if !ok || (nsName != "" && !validAvroFullname(nsName)) {
+			return avroError(path+"/namespace", "invalid namespace %v", ns)
+		}
+		if !strings.Contains(name, ".") {
+			namespace = nsName
+		}
+	}
+	fullname := avroFullname(name, namespace)
+	if !validAvroFullname(fullname) {
+		return avroError(path+"/name", "invalid name %q", name)
+	}
+	if avroPrimitives[fullname[strings.LastIndex(fullname, ".")+1:]] {
+		return avroError(path+"/name", "%s redefines a primitive type", fullname)
+	}
+	if v.defined[fullname] {
+		return avroError(path+"/name", "type %s is defined more than once", fullname)
+	}
+	// Defined before the fields are checked, so records can refer to themselves.
+	v.defined[fullname] = true
+
+	switch typeName {
+	case "record", "error":
+		return v.validateFields(s, typeName, fullname, path)
+	case "enum":
+		return validateAvroSymbols(s, fullname, path)
+	default:
+		size, ok := s["size"].(json.Number)
+		if n, err := size.Int64(); !ok || err != nil || n < 0 {
+			return avroError(path, "fixed %s must have a non-negative integer size", fullname)
+		}
+		return nil
+	}
+}
+
+func (v *avroValidator) validateFields(s map[string]interface{}, typeName, fullname, path string) error {
+	fields, ok := s["fields"].([]interface{})
+	if !ok {
+		return avroError(path, "%s %s must have an array of fields", typeName, fullname)
+	}
+
+	names := make(map[string]bool, len(fields))
+	for i, f := range fields {
+		fieldPath := fmt.Sprintf("%s/fields/%d", path, i)
+		field, ok := f.(map[string]interface{})
+		if !ok {
+			return avroError(fieldPath, "field must be an object")
+		}
+		value, found := field["name"]
+		if !found {
+			return avroError(fieldPath, "field without name")
+		}
+		name, ok := value.(string)
+		if !ok || !avroNamePattern.MatchString(name) {
+			return avroError(fieldPath+"/name", "invalid field name %v", value)
+		}
+		if names[name] {
+			return avroError(fieldPath+"/name", "field %s is defined more than once", name)
+		}
+		names[name] = true
+
+		fieldType, found := field["type"]
+		if !found {
+			return avroError(fieldPath, "field %s without type", name)
+		}
+		if err := v.validate(fieldType, avroNamespace(fullname), fieldPath+"/type"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateAvroSymbols(s map[string]interface{}, fullname, path string) error {
+	symbols, ok := s["symbols"].([]interface{})
+	if !ok {
+		return avroError(path, "enum %s must have an array of symbols", fullname)
+	}
+
+	seen := make(map[string]bool, len(symbols))
+	for i, value := range symbols {
+		symbol, ok := value.(string)
+		if !ok || !avroNamePattern.MatchString(symbol) {
+			return avroError(fmt.Sprintf("%s/symbols/%d", path, i), "invalid symbol %v", value)
+		}
+		if seen[symbol] {
+			return avroError(fmt.Sprintf("%s/symbols/%d", path, i), "symbol %s is listed more than once", symbol)
+		}
+		seen[symbol] = true
+	}
+	if value, found := s["default"]; found {
+		if symbol, ok := value.(string); !ok || !seen[symbol] {
+			return avroError(path+"/default", "default %v is not a symbol of enum %s", value, fullname)
+		}
+	}
+	return nil
+}
+
+// validAvroFullname reports whether every dot-separated part of a fullname is a valid Avro name.
+func validAvroFullname(fullname string) bool {
+	for _, part := range strings.Split(fullname, ".") {
+		if !avroNamePattern.MatchString(part) {
+			return false
+		}
+	}
+	return true
+}
+
+// avroUnionKey identifies a union branch: named types by fullname, other types by type name.
+func avroUnionKey(schema interface{}, namespace string) string {
+	switch s := schema.(type) {
+	case string:
+		if avroPrimitives[s] {
+			return s
+		}
+		return avroFullname(s, namespace)
+	case map[string]interface{}:
+		typeName, ok := s["type"].(string)
+		if !ok {
+			return avroUnionKey(s["type"], namespace)
+		}
+		switch typeName {
+		case "record", "error", "enum", "fixed":
+			name, _ := s["name"].(string)
+			if ns, ok := s["namespace"].(string); ok && !strings.Contains(name, ".") {
+				namespace = ns
+			}
+			return avroFullname(name, namespace)
+		case "array", "map":
+			return typeName
+		}
+		return avroUnionKey(typeName, namespace)
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAvroSchema(t *testing.T) {
+	const stubContent = `{"type": "record", "name": "Test", "fields": [{"name": "field1", "type": "string"}]}`
+
+	t.Run("Valid Schema", func(t *testing.T) {
+		assert.NoError(t, models.ValidateAvroSchema([]byte(stubContent)))
+
+		schema := `{
+			"type": "record",
+			"name": "Order",
+			"namespace": "com.example",
+			"fields": [
+				{"name": "id", "type": {"type": "string", "logicalType": "uuid"}},
+				{"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["OPEN", "PAID"], "default": "OPEN"}},
+				{"name": "previous", "type": ["null", "com.example.Order"], "default": null},
+				{"name": "lines", "type": {"type": "array", "items": {
+					"type": "record", "name": "Line", "fields": [
+						{"name": "status", "type": "Status"},
+						{"name": "checksum", "type": {"type": "fixed", "name": "MD5", "size": 16}}
+					]
+				}}},
+				{"name": "attributes", "type": {"type": "map", "values": ["string", "long"]}}
+			]
+		}`
+		assert.NoError(t, models.ValidateAvroSchema([]byte(schema)))
+		assert.NoError(t, models.ValidateAvroSchema([]byte(`"string"`)))
+	})
+
+	t.Run("Missing Field Type", func(t *testing.T) {
+		schema := `{"type": "record", "name": "Test", "fields": [{"name": "field1"}]}`
+
+		err := models.ValidateAvroSchema([]byte(schema))
+		assert.ErrorIs(t, err, models.ErrInvalidContent)
+		var schemaErr *models.SchemaError
+		if assert.ErrorAs(t, err, &schemaErr) {
+			assert.Equal(t, "/fields/0", schemaErr.Path)
+		}
+		assert.EqualError(t, err, "invalid Avro schema at /fields/0: field field1 without type")
+	})
+
+	t.Run("Syntax Error", func(t *testing.T) {
+		err := models.ValidateAvroSchema([]byte(`{"type": "record",}`))
+		var schemaErr *models.SchemaError
+		if assert.ErrorAs(t, err, &schemaErr) {
+			assert.Equal(t, "Avro schema", schemaErr.Format)
+			assert.Equal(t, int64(19), schemaErr.Offset)
+		}
+	})
+
+	t.Run("Invalid Schemas", func(t *testing.T) {
+		tests := []struct {
+			schema string
+			path   string
+		}{
+			{`{"type": "record", "fields": []}`, ""},
+			{`{"type": "record", "name": "1Test", "fields": []}`, "/name"},
+			{`{"type": "record", "name": "Test", "fields": [{"name": "a", "type": "Missing"}]}`, "/fields/0/type"},
+			{`{"type": "record", "name": "Test", "fields": [{"name": "a", "type": "int"}, {"name": "a", "type": "int"}]}`, "/fields/1/name"},
+			{`{"type": "enum", "name": "E", "symbols": ["A", "A"]}`, "/symbols/1"},
+			{`{"type": "enum", "name": "E", "symbols": ["A"], "default": "B"}`, "/default"},
+			{`{"type": "fixed", "name": "F"}`, ""},
+			{`{"type": "array"}`, ""},
+			{`["null", ["int"]]`, "/1"},
+			{`["string", {"type": "string"}]`, "/1"},
+			{`[{"type": "fixed", "name": "F", "size": 1}, {"type": "fixed", "name": "F", "size": 2}]`, "/1/name"},
+			{`42`, ""},
+		}
+		for _, tt := range tests {
+			var schemaErr *models.SchemaError
+			if assert.ErrorAs(t, models.ValidateAvroSchema([]byte(tt.schema)), &schemaErr, tt.schema) {
+				assert.Equal(t, tt.path, schemaErr.Path, tt.schema)
+			}
+		}
+	})
+}
@@ -0,0 +1,241 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// CanonicalizeJSON returns the canonical form of a JSON document: object keys sorted,
+// insignificant whitespace removed and numbers kept exactly as written.
+// Semantically equal documents produce identical bytes, so the result can be hashed and compared.
+func CanonicalizeJSON(content []byte) ([]byte, error) {
+	value, err := decodeJSON(content)
+	if err != nil {
+		return nil, err
+	}
+	return encodeJSON(value)
+}
+
+// CanonicalizeAvro returns the Parsing Canonical Form of an Avro schema as defined by the Avro specification:
+// names are replaced by fullnames, attributes irrelevant to parsing (doc, aliases, defaults, logical types, ...)
+// are removed, primitive schemas are written in their simple form and the remaining attributes are ordered.
+func CanonicalizeAvro(schema []byte) ([]byte, error) {
+	value, err := decodeJSON(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeAvroCanonical(&buf, value, "", make(map[string]bool)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ValidateContent checks content with the local validator of its artifact type: ValidateJSONSchema for Json,
+// ValidateAvroSchema for Avro and ValidateProtobuf for Protobuf. The returned error wraps ErrInvalidContent.
+// Content of other artifact types is accepted as-is.
+func ValidateContent(artifactType ArtifactType, content []byte) error {
+	switch artifactType {
+	case Json:
+		return ValidateJSONSchema(content)
+	case Avro:
+		return ValidateAvroSchema(content)
+	case Protobuf:
+		return ValidateProtobuf(content)
+	}
+	return nil
+}
+
+var avroPrimitives = map[string]bool{
+	"null": true, "boolean": true, "int": true, "long": true,
+	"float": true, "double": true, "bytes": true, "string": true,
+}
+
+// decodeJSON parses a single JSON document, preserving numbers as json.Number.
+func decodeJSON(content []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, errors.Wrap(err, "failed to parse JSON content")
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("failed to parse JSON content: unexpected data after document")
+	}
+	return value, nil
+}
+
+// encodeJSON serializes a value without HTML escaping or trailing newline. Map keys are sorted by encoding/json.
+func encodeJSON(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, errors.Wrap(err, "failed to serialize JSON content")
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func writeAvroString(buf *bytes.Buffer, s string) error {
+	encoded, err := encodeJSON(s)
+	if err != nil {
+		return err
+	}
+	buf.Write(encoded)
+	return nil
+}
+
+// avroFullname resolves a (possibly short) name against the enclosing namespace.
+func avroFullname(name, namespace string) string {
+	if strings.Contains(name, ".") || namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// avroNamespace returns the namespace part of a fullname.
+func avroNamespace(fullname string) string {
+	if i := strings.LastIndex(fullname, "."); i >= 0 {
+		return fullname[:i]
+	}
+	return ""
+}
+
+// writeAvroCanonical writes the canonical form of schema. Named types are written in full on their
+// first occurrence and as their fullname afterwards.
+func writeAvroCanonical(buf *bytes.Buffer, schema interface{}, namespace string, defined map[string]bool) error {
+	switch s := schema.(type) {
+	case string:
+		if avroPrimitives[s] {
+			return writeAvroString(buf, s)
+		}
+		return writeAvroString(buf, avroFullname(s, namespace))
+
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, branch := range s {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeAvroCanonical(buf, branch, namespace, defined); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+
+	case map[string]interface{}:
+		return writeAvroComplex(buf, s, namespace, defined)
+	}
+
+	return errors.Errorf("invalid Avro schema: unexpected %T", schema)
+}
+
+func writeAvroComplex(buf *bytes.Buffer, s map[string]interface{}, namespace string, defined map[string]bool) error {
+	typeName, ok := s["type"].(string)
+	if !ok {
+		// {"type": {...}} or {"type": [...]} wraps another schema.
+		if inner, found := s["type"]; found {
+			return writeAvroCanonical(buf, inner, namespace, defined)
+		}
+		return errors.New("invalid Avro schema: missing type")
+	}
+
+	switch typeName {
+	case "record", "error", "enum", "fixed":
+	case "array":
+		buf.WriteString(`{"type":"array","items":`)
+		if err := writeAvroCanonical(buf, s["items"], namespace, defined); err != nil {
+			return err
+		}
+		buf.WriteByte('}')
+		return nil
+	case "map":
+		buf.WriteString(`{"type":"map","values":`)
+		if err := writeAvroCanonical(buf, s["values"], namespace, defined); err != nil {
+			return err
+		}
+		buf.WriteByte('}')
+		return nil
+	default:
+		// Primitive with attributes (e.g. a logical type) or a reference to a named type.
+		return writeAvroCanonical(buf, typeName, namespace, defined)
+	}
+
+	name, ok := s["name"].(string)
+	if !ok || name == "" {
+		return errors.Errorf("invalid Avro schema: %s without name", typeName)
+	}
+	if ns, ok := s["namespace"].(string); ok && !strings.Contains(name, ".") {
+		namespace = ns
+	}
+	fullname := avroFullname(name, namespace)
+	if defined[fullname] {
+		return writeAvroString(buf, fullname)
+	}
+	defined[fullname] = true
+
+	buf.WriteString(`{"name":`)
+	if err := writeAvroString(buf, fullname); err != nil {
+		return err
+	}
+	buf.WriteString(`,"type":`)
+	if err := writeAvroString(buf, typeName); err != nil {
+		return err
+	}
+
+	switch typeName {
+	case "record", "error":
+		fields, _ := s["fields"].([]interface{})
+		buf.WriteString(`,"fields":[`)
+		for i, f := range fields {
+			field, ok := f.(map[string]interface{})
+			if !ok {
+				return errors.Errorf("invalid Avro schema: field of %s is not an object", fullname)
+			}
+			fieldName, _ := field["name"].(string)
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(`{"name":`)
+			if err := writeAvroString(buf, fieldName); err != nil {
+				return err
+			}
+			buf.WriteString(`,"type":`)
+			if err := writeAvroCanonical(buf, field["type"], avroNamespace(fullname), defined); err != nil {
+				return err
+			}
+			buf.WriteByte('}')
+		}
+		buf.WriteByte(']')
+	case "enum":
+		symbols, _ := s["symbols"].([]interface{})
+		encoded, err := encodeJSON(symbols)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(`,"symbols":`)
+		buf.Write(encoded)
+	case "fixed":
+		size, ok := s["size"].(json.Number)
+		if !ok {
+			return errors.Errorf("invalid Avro schema: fixed %s without size", fullname)
+		}
+		n, err := size.Int64()
+		if err != nil {
+			return errors.Wrapf(err, "invalid Avro schema: size of fixed %s", fullname)
+		}
+		buf.WriteString(`,"size":`)
+		buf.WriteString(strconv.FormatInt(n, 10))
+	}
+
+	buf.WriteByte('}')
+	return nil
+}
@@ -0,0 +1,95 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalizeJSON(t *testing.T) {
+	t.Run("Equivalent Documents", func(t *testing.T) {
+		a := []byte(`{"b": [1, 2.50, {"z": true, "a": null}], "a": "x<y"}`)
+		b := []byte("{\n  \"a\": \"x<y\",\n  \"b\": [1, 2.50, {\"a\": null, \"z\": true}]\n}\n")
+
+		canonicalA, err := models.CanonicalizeJSON(a)
+		assert.NoError(t, err)
+		canonicalB, err := models.CanonicalizeJSON(b)
+		assert.NoError(t, err)
+
+		assert.Equal(t, `{"a":"x<y","b":[1,2.50,{"a":null,"z":true}]}`, string(canonicalA))
+		assert.Equal(t, canonicalA, canonicalB)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := models.CanonicalizeJSON([]byte(`{"a": `))
+		assert.Error(t, err)
+
+		_, err = models.CanonicalizeJSON([]byte(`{} {}`))
+		assert.Error(t, err)
+	})
+}
+
+func TestCanonicalizeAvro(t *testing.T) {
+	t.Run("Equivalent Schemas", func(t *testing.T) {
+		a := []byte(`{
+			"type": "record",
+			"name": "User",
+			"namespace": "com.example",
+			"doc": "A user",
+			"fields": [
+				{"name": "id", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+				{"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["ACTIVE", "INACTIVE"]}, "default": "ACTIVE"},
+				{"name": "previous", "type": ["null", "Status"], "default": null},
+				{"name": "tags", "type": {"type": "array", "items": "string"}},
+				{"name": "hash", "type": {"type": "fixed", "name": "Hash", "size": 16}}
+			]
+		}`)
+		b := []byte(`{"fields":[{"type":"long","name":"id"},{"type":{"symbols":["ACTIVE","INACTIVE"],"name":"com.example.Status","type":"enum"},"name":"status"},{"name":"previous","type":["null","com.example.Status"]},{"name":"tags","type":{"items":{"type":"string"},"type":"array"}},{"name":"hash","type":{"size":16,"type":"fixed","name":"Hash","namespace":"com.example"}}],"name":"com.example.User","type":"record"}`)
+
+		canonicalA, err := models.CanonicalizeAvro(a)
+		assert.NoError(t, err)
+		canonicalB, err := models.CanonicalizeAvro(b)
+		assert.NoError(t, err)
+
+		expected := `{"name":"com.example.User","type":"record","fields":[` +
+			`{"name":"id","type":"long"},` +
+			`{"name":"status","type":{"name":"com.example.Status","type":"enum","symbols":["ACTIVE","INACTIVE"]}},` +
+			`{"name":"previous","type":["null","com.example.Status"]},` +
+			`{"name":"tags","type":{"type":"array","items":"string"}},` +
+			`{"name":"hash","type":{"name":"com.example.Hash","type":"fixed","size":16}}]}`
+		assert.Equal(t, expected, string(canonicalA))
+		assert.Equal(t, canonicalA, canonicalB)
+	})
+
+	t.Run("Primitive", func(t *testing.T) {
+		canonical, err := models.CanonicalizeAvro([]byte(`{"type": "string"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, `"string"`, string(canonical))
+	})
+
+	t.Run("Missing Name", func(t *testing.T) {
+		_, err := models.CanonicalizeAvro([]byte(`{"type": "record", "fields": []}`))
+		assert.Error(t, err)
+	})
+}
+
+func TestValidateContent(t *testing.T) {
+	validAvro := []byte(`{"type": "record", "name": "User", "fields": [{"name": "id", "type": "string"}]}`)
+	assert.NoError(t, models.ValidateContent(models.Avro, validAvro))
+	assert.NoError(t, models.ValidateContent(models.Json, []byte(`{"type": "object"}`)))
+	assert.NoError(t, models.ValidateContent(models.Protobuf, []byte(`syntax = "proto3";`)))
+	assert.NoError(t, models.ValidateContent(models.GraphQL, []byte(`not checked`)), "types without a local validator are accepted")
+
+	err := models.ValidateContent(models.Avro, []byte(`{"type": "record", "fields": []}`))
+	assert.ErrorIs(t, err, models.ErrInvalidContent)
+
+	err = models.ValidateContent(models.Json, []byte(`{"type": `))
+	assert.ErrorIs(t, err, models.ErrInvalidContent)
+
+	err = models.ValidateContent(models.Json, []byte(`{"required": "id"}`))
+	assert.ErrorIs(t, err, models.ErrInvalidContent, "JSON Schema keywords are checked")
+
+	err = models.ValidateContent(models.Protobuf, []byte(`syntax = "proto3"; message A { string id = 0; }`))
+	assert.ErrorIs(t, err, models.ErrInvalidContent)
+}
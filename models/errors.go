@@ -1,19 +1,125 @@
 package models
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
 
 var (
-	ErrUnknownArtifactType = fmt.Errorf("unknown artifact type")
+	ErrUnknownArtifactType     = fmt.Errorf("unknown artifact type")
+	ErrInvalidInput            = fmt.Errorf("input did not pass validation with regex")
+	ErrArtifactTypeMismatch    = fmt.Errorf("artifact type does not match the expected type")
+	ErrPreconditionFailed      = fmt.Errorf("precondition failed: the resource was modified concurrently")
+	ErrDraftStateConflict      = fmt.Errorf("isDraft contradicts the requested version state")
+	ErrUnsupportedArtifactType = fmt.Errorf("artifact type is not supported by the registry")
+	ErrBudgetExceeded          = fmt.Errorf("operation time budget exceeded")
+	ErrTruncated               = fmt.Errorf("result truncated at the maximum number of items fetched")
+	ErrNoEnabledVersion        = fmt.Errorf("artifact has no enabled version")
+	ErrInvalidContent          = fmt.Errorf("content is not valid for its artifact type")
+	ErrRangeNotSupported       = fmt.Errorf("the registry does not support range requests")
+	ErrEventsUnsupported       = fmt.Errorf("the registry does not expose an event stream")
+	ErrNotDraft                = fmt.Errorf("version is not in DRAFT state")
+	ErrNotSemver               = fmt.Errorf("version is not a semantic version")
+	ErrCyclicReference         = fmt.Errorf("artifact references form a cycle")
+	ErrQueryTooLong            = fmt.Errorf("search query exceeds the maximum URL length")
 )
 
+// FieldValidationError is returned when a single input field fails validation.
+// It unwraps to ErrInvalidInput so callers can use errors.Is as well as errors.As.
+type FieldValidationError struct {
+	Field  string // Human-readable name of the offending field, e.g. "Group ID"
+	Value  string // The rejected value
+	Reason string // Why the value was rejected
+}
+
+// Error satisfies the error interface and formats the FieldValidationError as a string.
+func (e *FieldValidationError) Error() string {
+	return fmt.Sprintf("%s='%s', %s: %s", e.Field, e.Value, e.Reason, ErrInvalidInput)
+}
+
+// Unwrap returns ErrInvalidInput.
+func (e *FieldValidationError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// SchemaError is returned by ValidateJSONSchema, ValidateAvroSchema and ValidateProtobuf. Path is a JSON Pointer
+// to the offending part of the schema, e.g. "/properties/id/type"; syntax errors have no path and report the byte
+// offset instead. Protobuf errors report a line and column. It unwraps to ErrInvalidContent.
+type SchemaError struct {
+	Format  string // Schema format, e.g. "JSON Schema" or "Avro schema"
+	Path    string
+	Offset  int64
+	Line    int // Line of the error in line-based formats such as Protobuf, starting at 1
+	Column  int // Column of the error on Line, starting at 1
+	Message string
+}
+
+// Error satisfies the error interface and formats the SchemaError as a string.
+func (e *SchemaError) Error() string {
+	format := e.Format
+	if format == "" {
+		format = "schema"
+	}
+	if e.Path == "" && e.Line > 0 {
+		return fmt.Sprintf("invalid %s at line %d, column %d: %s", format, e.Line, e.Column, e.Message)
+	}
+	if e.Path == "" && e.Offset > 0 {
+		return fmt.Sprintf("invalid %s at offset %d: %s", format, e.Offset, e.Message)
+	}
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("invalid %s at %s: %s", format, path, e.Message)
+}
+
+// Unwrap returns ErrInvalidContent.
+func (e *SchemaError) Unwrap() error {
+	return ErrInvalidContent
+}
+
 // APIError represents the structure of an error response from the API.
 type APIError struct {
 	Detail   string `json:"detail"`   // A human-readable explanation specific to the problem
 	Type     string `json:"type"`     // A URI reference identifying the problem type
 	Title    string `json:"title"`    // A short, human-readable summary of the problem type
-	Status   int    `json:"status"`   // The HTTP status code
+	Status   int    `json:"status"`   // The HTTP status code of the response
 	Instance string `json:"instance"` // A URI reference identifying the specific occurrence
 	Name     string `json:"name"`     // The name of the error (e.g., server exception class name)
+
+	Causes []RuleViolationCause `json:"causes,omitempty"` // Rule violations reported with a 409 response
+
+	RetryAfter time.Duration `json:"-"` // Wait requested by the Retry-After header of a 429 response; zero when absent
+}
+
+// ParseRetryAfter returns the wait requested by a Retry-After header value, given either as a number of
+// seconds or as an HTTP date. It returns zero for an empty or invalid value or a date in the past.
+func ParseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+// RuleViolationCause describes a single rule violation reported by the registry.
+type RuleViolationCause struct {
+	Description string `json:"description"`
+	Context     string `json:"context"`
 }
 
 // Error satisfies the error interface and formats the APIError as a string.
@@ -21,3 +127,30 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("[%d] %s: %s (detail: %s, instance: %s, type: %s)",
 		e.Status, e.Title, e.Name, e.Detail, e.Instance, e.Type)
 }
+
+// Is reports whether the APIError matches a sentinel error, allowing errors.Is(err, ErrPreconditionFailed)
+// for 412 responses.
+func (e *APIError) Is(target error) bool {
+	return target == ErrPreconditionFailed && e.Status == http.StatusPreconditionFailed
+}
+
+// CompatibilityResult returns the rule violations carried by the error as a typed CompatibilityResult.
+// It returns nil when the error does not describe any rule violations.
+func (e *APIError) CompatibilityResult() *CompatibilityResult {
+	if len(e.Causes) == 0 {
+		return nil
+	}
+	return NewCompatibilityResult(e.Causes)
+}
+
+// HookPanicError is reported when a user-supplied hook (observer, callback, editor) panics.
+// The panic is recovered so it cannot crash the request path of the caller.
+type HookPanicError struct {
+	Hook  string      // Name of the hook that panicked
+	Value interface{} // Value passed to panic
+}
+
+// Error satisfies the error interface and formats the HookPanicError as a string.
+func (e *HookPanicError) Error() string {
+	return fmt.Sprintf("hook %s panicked: %v", e.Hook, e.Value)
+}
@@ -0,0 +1,34 @@
+package models_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Run("Seconds", func(t *testing.T) {
+		assert.Equal(t, 120*time.Second, models.ParseRetryAfter("120"))
+		assert.Equal(t, time.Duration(0), models.ParseRetryAfter("0"))
+	})
+
+	t.Run("HTTP Date", func(t *testing.T) {
+		date := time.Now().Add(90 * time.Second).UTC().Format(http.TimeFormat)
+		wait := models.ParseRetryAfter(date)
+		assert.InDelta(t, float64(90*time.Second), float64(wait), float64(2*time.Second))
+	})
+
+	t.Run("Date In The Past", func(t *testing.T) {
+		date := time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)
+		assert.Equal(t, time.Duration(0), models.ParseRetryAfter(date))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), models.ParseRetryAfter(""))
+		assert.Equal(t, time.Duration(0), models.ParseRetryAfter("-5"))
+		assert.Equal(t, time.Duration(0), models.ParseRetryAfter("soon"))
+	})
+}
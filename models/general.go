@@ -12,6 +12,27 @@ const (
 	IfExistsFail                IfExistsType = "FAIL"                   // (default) - server rejects the content with a 409 error
 	IfExistsCreate              IfExistsType = "CREATE_VERSION"         // server creates a new version of the existing artifact and returns it
 	IfExistsFindOrCreateVersion IfExistsType = "FIND_OR_CREATE_VERSION" // server returns an existing version that matches the provided content if such a version exists, otherwise a new version is created
+
+	// IfExistsReturnOrUpdate is the registry v2 name of IfExistsFindOrCreateVersion.
+	IfExistsReturnOrUpdate = IfExistsFindOrCreateVersion
+)
+
+// ImportConflictStrategy determines how an import handles artifacts that already exist in the registry.
+type ImportConflictStrategy string
+
+const (
+	ImportConflictSkip      ImportConflictStrategy = "SKIP"      // existing artifacts are left untouched and reported as skipped
+	ImportConflictOverwrite ImportConflictStrategy = "OVERWRITE" // the imported content becomes a new latest version of the existing artifact
+	ImportConflictFail      ImportConflictStrategy = "FAIL"      // the import stops with the registry's 409 error
+)
+
+// VersionStrategy determines the label of versions created without an explicit version.
+type VersionStrategy string
+
+const (
+	VersionAuto       VersionStrategy = "AUTO"        // (default) - the registry assigns the next version
+	VersionTimestamp  VersionStrategy = "TIMESTAMP"   // the UTC creation time, e.g. 20261016142530
+	VersionSemverBump VersionStrategy = "SEMVER_BUMP" // the latest version with its patch number bumped, e.g. 1.4.2 after 1.4.1
 )
 
 // State represents the state of an artifact.
@@ -127,6 +148,23 @@ func ParseArtifactType(artifactType string) (ArtifactType, error) {
 	}
 }
 
+// DefaultContentType returns the MIME type used for content of the given artifact type when the
+// request does not set one. It returns an empty string for an unknown or empty type.
+func DefaultContentType(artifactType ArtifactType) string {
+	switch artifactType {
+	case Avro, Json, KConnect, OpenAPI, AsyncAPI:
+		return "application/json"
+	case Protobuf:
+		return "application/x-protobuf"
+	case GraphQL:
+		return "application/graphql"
+	case WSDL, XSD, XML:
+		return "application/xml"
+	default:
+		return ""
+	}
+}
+
 type Rule string
 
 const (
@@ -157,3 +195,51 @@ const (
 	ValidityLevelSyntaxOnly RuleLevel = "SYNTAX_ONLY"
 	ValidityLevelFull       RuleLevel = "FULL"
 )
+
+// LogLevel represents the level of a registry logger.
+type LogLevel string
+
+const (
+	LogLevelTrace   LogLevel = "TRACE"
+	LogLevelDebug   LogLevel = "DEBUG"
+	LogLevelInfo    LogLevel = "INFO"
+	LogLevelWarn    LogLevel = "WARN"
+	LogLevelError   LogLevel = "ERROR"
+	LogLevelSevere  LogLevel = "SEVERE"
+	LogLevelWarning LogLevel = "WARNING"
+	LogLevelConfig  LogLevel = "CONFIG"
+	LogLevelFine    LogLevel = "FINE"
+	LogLevelFiner   LogLevel = "FINER"
+	LogLevelFinest  LogLevel = "FINEST"
+)
+
+// JitterStrategy selects how the client randomizes the backoff between retries.
+type JitterStrategy string
+
+const (
+	JitterEqual JitterStrategy = "EQUAL" // (default) half the delay is fixed, the other half random: [d/2, d]
+	JitterFull  JitterStrategy = "FULL"  // the whole delay is random: [0, d]; spreads out retry storms the most
+	JitterNone  JitterStrategy = "NONE"  // the plain exponential delay d
+)
+
+// ReconcileActionKind identifies a change made by GroupAPI.Reconcile.
+type ReconcileActionKind string
+
+const (
+	ReconcileCreateArtifact ReconcileActionKind = "CREATE_ARTIFACT" // the artifact is created with its first desired version
+	ReconcileCreateVersion  ReconcileActionKind = "CREATE_VERSION"  // a desired version missing from the artifact is added
+	ReconcileDeleteArtifact ReconcileActionKind = "DELETE_ARTIFACT" // an artifact that is not desired is deleted
+	ReconcileCreateRule     ReconcileActionKind = "CREATE_RULE"     // a desired artifact rule is added
+	ReconcileUpdateRule     ReconcileActionKind = "UPDATE_RULE"     // an artifact rule is changed to the desired level
+	ReconcileDeleteRule     ReconcileActionKind = "DELETE_RULE"     // an artifact rule that is not desired is removed
+)
+
+// RegistryEventType identifies the change reported by a models.RegistryEvent. Event types not listed here
+// are passed through as sent by the registry.
+type RegistryEventType string
+
+const (
+	EventArtifactCreated RegistryEventType = "ARTIFACT_CREATED"
+	EventArtifactUpdated RegistryEventType = "ARTIFACT_UPDATED"
+	EventArtifactDeleted RegistryEventType = "ARTIFACT_DELETED"
+)
@@ -0,0 +1,233 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var jsonSchemaTypes = map[string]bool{
+	"null": true, "boolean": true, "object": true, "array": true,
+	"number": true, "integer": true, "string": true,
+}
+
+// Keywords whose value is a schema, an array of schemas or an object of schemas.
+var (
+	jsonSchemaSubschema = []string{
+		"additionalItems", "additionalProperties", "contains", "else", "if", "not",
+		"propertyNames", "then", "unevaluatedItems", "unevaluatedProperties",
+	}
+	jsonSchemaSubschemaArrays = []string{"allOf", "anyOf", "oneOf", "prefixItems"}
+	jsonSchemaSubschemaMaps   = []string{"$defs", "definitions", "dependentSchemas", "patternProperties", "properties"}
+)
+
+// ValidateJSONSchema checks a JSON Schema document before it is uploaded, so malformed schemas are caught
+// without a round-trip to the registry. It checks that the document parses, that every schema is an object
+// or a boolean, that type, required and enum are well-formed and that local $ref pointers such as
+// "#/definitions/id" resolve within the document. Remote references are not followed.
+// The returned *SchemaError points at the part of the schema that failed.
+func ValidateJSONSchema(content []byte) error {
+	root, err := decodeJSON(content)
+	if err != nil {
+		return syntaxSchemaError("JSON Schema", err)
+	}
+	err = validateJSONSchema(root, root, "")
+	var schemaErr *SchemaError
+	if errors.As(err, &schemaErr) {
+		schemaErr.Format = "JSON Schema"
+	}
+	return err
+}
+
+// syntaxSchemaError converts a failure to parse a schema document into a *SchemaError.
+func syntaxSchemaError(format string, err error) *SchemaError {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return &SchemaError{Format: format, Offset: syntaxErr.Offset, Message: syntaxErr.Error()}
+	}
+	return &SchemaError{Format: format, Message: err.Error()}
+}
+
+func validateJSONSchema(root, schema interface{}, path string) error {
+	if _, ok := schema.(bool); ok {
+		return nil
+	}
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		return &SchemaError{Path: path, Message: fmt.Sprintf("schema must be an object or a boolean, got %s", jsonKind(schema))}
+	}
+
+	if err := validateJSONSchemaType(s, path); err != nil {
+		return err
+	}
+	if ref, found := s["$ref"]; found {
+		refString, ok := ref.(string)
+		if !ok {
+			return &SchemaError{Path: path + "/$ref", Message: "$ref must be a string"}
+		}
+		if !resolvesLocally(root, refString) {
+			return &SchemaError{Path: path + "/$ref", Message: fmt.Sprintf("$ref %q does not resolve within the document", refString)}
+		}
+	}
+	if required, found := s["required"]; found {
+		items, ok := required.([]interface{})
+		if !ok {
+			return &SchemaError{Path: path + "/required", Message: "required must be an array of strings"}
+		}
+		for i, item := range items {
+			if _, ok := item.(string); !ok {
+				return &SchemaError{Path: fmt.Sprintf("%s/required/%d", path, i), Message: "required must be an array of strings"}
+			}
+		}
+	}
+	if enum, found := s["enum"]; found {
+		if _, ok := enum.([]interface{}); !ok {
+			return &SchemaError{Path: path + "/enum", Message: "enum must be an array"}
+		}
+	}
+
+	for _, keyword := range jsonSchemaSubschema {
+		if sub, found := s[keyword]; found {
+			if err := validateJSONSchema(root, sub, path+"/"+keyword); err != nil {
+				return err
+			}
+		}
+	}
+	// items is a schema, or an array of schemas in drafts before 2020-12.
+	if items, found := s["items"]; found {
+		if list, ok := items.([]interface{}); ok {
+			for i, sub := range list {
+				if err := validateJSONSchema(root, sub, fmt.Sprintf("%s/items/%d", path, i)); err != nil {
+					return err
+				}
+			}
+		} else if err := validateJSONSchema(root, items, path+"/items"); err != nil {
+			return err
+		}
+	}
+	for _, keyword := range jsonSchemaSubschemaArrays {
+		value, found := s[keyword]
+		if !found {
+			continue
+		}
+		list, ok := value.([]interface{})
+		if !ok || len(list) == 0 {
+			return &SchemaError{Path: path + "/" + keyword, Message: keyword + " must be a non-empty array of schemas"}
+		}
+		for i, sub := range list {
+			if err := validateJSONSchema(root, sub, fmt.Sprintf("%s/%s/%d", path, keyword, i)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, keyword := range jsonSchemaSubschemaMaps {
+		value, found := s[keyword]
+		if !found {
+			continue
+		}
+		schemas, ok := value.(map[string]interface{})
+		if !ok {
+			return &SchemaError{Path: path + "/" + keyword, Message: keyword + " must be an object of schemas"}
+		}
+		names := make([]string, 0, len(schemas))
+		for name := range schemas {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := validateJSONSchema(root, schemas[name], path+"/"+keyword+"/"+escapePointer(name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateJSONSchemaType checks that type is a known type name or a non-empty array of unique ones.
+func validateJSONSchemaType(s map[string]interface{}, path string) error {
+	value, found := s["type"]
+	if !found {
+		return nil
+	}
+	names, ok := value.([]interface{})
+	if !ok {
+		names = []interface{}{value}
+	} else if len(names) == 0 {
+		return &SchemaError{Path: path + "/type", Message: "type must not be an empty array"}
+	}
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		typeName, ok := name.(string)
+		if !ok || !jsonSchemaTypes[typeName] {
+			return &SchemaError{Path: path + "/type", Message: fmt.Sprintf("unknown type %v", name)}
+		}
+		if seen[typeName] {
+			return &SchemaError{Path: path + "/type", Message: fmt.Sprintf("type %s is listed more than once", typeName)}
+		}
+		seen[typeName] = true
+	}
+	return nil
+}
+
+// resolvesLocally reports whether a $ref resolves within the document. References to other documents and
+// to anchors are assumed to resolve.
+func resolvesLocally(root interface{}, ref string) bool {
+	if !strings.HasPrefix(ref, "#") || (len(ref) > 1 && ref[1] != '/') {
+		return true
+	}
+	pointer, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return false
+	}
+	if pointer == "" {
+		return true
+	}
+
+	node := root
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch n := node.(type) {
+		case map[string]interface{}:
+			child, ok := n[token]
+			if !ok {
+				return false
+			}
+			node = child
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(n) {
+				return false
+			}
+			node = n[i]
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// escapePointer escapes a key for use as a JSON Pointer token.
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// jsonKind names the JSON type of a decoded value for error messages.
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case []interface{}:
+		return "array"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,73 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateJSONSchema(t *testing.T) {
+	t.Run("Valid Schema", func(t *testing.T) {
+		schema := `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"type": "object",
+			"properties": {
+				"id": {"$ref": "#/definitions/id"},
+				"tags": {"type": "array", "items": {"type": "string"}},
+				"note": {"type": ["string", "null"]}
+			},
+			"required": ["id"],
+			"additionalProperties": false,
+			"definitions": {"id": {"type": "string", "format": "uuid"}}
+		}`
+		assert.NoError(t, models.ValidateJSONSchema([]byte(schema)))
+	})
+
+	t.Run("Syntax Error", func(t *testing.T) {
+		err := models.ValidateJSONSchema([]byte(`{"type": "object",}`))
+		assert.ErrorIs(t, err, models.ErrInvalidContent)
+
+		var schemaErr *models.SchemaError
+		if assert.ErrorAs(t, err, &schemaErr) {
+			assert.Equal(t, int64(19), schemaErr.Offset)
+		}
+	})
+
+	t.Run("Unresolved Ref", func(t *testing.T) {
+		schema := `{"properties": {"id": {"$ref": "#/definitions/missing"}}, "definitions": {"id": {}}}`
+
+		err := models.ValidateJSONSchema([]byte(schema))
+		assert.ErrorIs(t, err, models.ErrInvalidContent)
+		var schemaErr *models.SchemaError
+		if assert.ErrorAs(t, err, &schemaErr) {
+			assert.Equal(t, "/properties/id/$ref", schemaErr.Path)
+		}
+		assert.EqualError(t, err, `invalid JSON Schema at /properties/id/$ref: $ref "#/definitions/missing" does not resolve within the document`)
+	})
+
+	t.Run("Invalid Keywords", func(t *testing.T) {
+		tests := []struct {
+			schema string
+			path   string
+		}{
+			{`{"type": "text"}`, "/type"},
+			{`{"properties": {"a/b": {"type": ["string", "string"]}}}`, "/properties/a~1b/type"},
+			{`{"items": [{"type": "string"}, 5]}`, "/items/1"},
+			{`{"anyOf": []}`, "/anyOf"},
+			{`{"required": ["id", 1]}`, "/required/1"},
+			{`[]`, ""},
+		}
+		for _, tt := range tests {
+			var schemaErr *models.SchemaError
+			if assert.ErrorAs(t, models.ValidateJSONSchema([]byte(tt.schema)), &schemaErr, tt.schema) {
+				assert.Equal(t, tt.path, schemaErr.Path, tt.schema)
+			}
+		}
+	})
+
+	t.Run("External And Anchor Refs", func(t *testing.T) {
+		schema := `{"allOf": [{"$ref": "https://example.com/schemas/base.json"}, {"$ref": "#node"}, {"$ref": "#"}]}`
+		assert.NoError(t, models.ValidateJSONSchema([]byte(schema)))
+	})
+}
@@ -14,21 +14,26 @@ type ArtifactReference struct {
 
 // SearchedArtifact represents the search result of an artifact.
 type SearchedArtifact struct {
-	GroupId      string       `json:"groupId"`
-	ArtifactId   string       `json:"artifactId"`
-	Name         string       `json:"name"`
-	Description  string       `json:"description"`
-	ArtifactType ArtifactType `json:"artifactType"`
-	Owner        string       `json:"owner"`
-	CreatedOn    string       `json:"createdOn"`
-	ModifiedBy   string       `json:"modifiedBy"`
-	ModifiedOn   string       `json:"modifiedOn"`
+	GroupId      string            `json:"groupId"`
+	ArtifactId   string            `json:"artifactId"`
+	Name         string            `json:"name"`
+	Description  string            `json:"description"`
+	ArtifactType ArtifactType      `json:"artifactType"`
+	Owner        string            `json:"owner"`
+	CreatedOn    string            `json:"createdOn"`
+	ModifiedBy   string            `json:"modifiedBy"`
+	ModifiedOn   string            `json:"modifiedOn"`
+	Labels       map[string]string `json:"labels,omitempty"`
 }
 
 // ArtifactContent represents the content of an artifact + the type of the artifact.
 type ArtifactContent struct {
 	Content      string       `json:"content"`
 	ArtifactType ArtifactType `json:"artifactType"`
+	Version      string       `json:"version,omitempty"`    // Concrete version the content was resolved from, when known
+	GroupID      string       `json:"groupId,omitempty"`    // Group of the artifact, when reported by the registry
+	ArtifactID   string       `json:"artifactId,omitempty"` // Artifact the content belongs to, when reported by the registry
+	ETag         string       `json:"-"`                    // ETag of the response, for client.WithIfMatch; empty when the registry sent none
 }
 
 // ArtifactDetail represents the detailed information about an artifact.
@@ -62,6 +67,23 @@ type ArtifactVersionMetadata struct {
 	Version   string `json:"version"`
 	GlobalID  int64  `json:"globalId"`
 	ContentID int64  `json:"contentId"`
+	State     State  `json:"state,omitempty"`
+	ETag      string `json:"-"` // ETag of the response, for client.WithIfMatch; empty when the registry sent none
+}
+
+// ArtifactVersionFull combines the metadata and the content of a single artifact version.
+type ArtifactVersionFull struct {
+	ArtifactVersionMetadata
+	ArtifactType ArtifactType `json:"artifactType"` // Type reported with the content, falling back to the metadata
+	Content      string       `json:"content"`
+}
+
+// ResolvedSchema is the content of an artifact version together with the content of every version it
+// references, directly or through other references.
+type ResolvedSchema struct {
+	Content      string            // Content of the root version
+	ArtifactType ArtifactType      // Type of the root version; empty when the registry did not report it
+	References   map[string]string // Content of each referenced version by reference name
 }
 
 // ArtifactMetadata represents metadata for an artifact.
@@ -69,6 +91,7 @@ type ArtifactMetadata struct {
 	BaseMetadata
 	ModifiedBy string `json:"modifiedBy"`
 	ModifiedOn string `json:"modifiedOn"`
+	ETag       string `json:"-"` // ETag of the response, for client.WithIfMatch; empty when the registry sent none
 }
 
 // ArtifactComment represents a comment on a specific artifact version.
@@ -123,6 +146,20 @@ type GroupInfo struct {
 	Labels      map[string]string `json:"labels"`
 }
 
+// GroupSummary represents a group together with the number of artifacts it contains.
+type GroupSummary struct {
+	GroupId       string            `json:"groupId"`
+	Description   string            `json:"description"`
+	Labels        map[string]string `json:"labels"`
+	ArtifactCount int               `json:"artifactCount"`
+}
+
+// System-defined branches maintained by the registry for every artifact.
+const (
+	BranchLatest = "latest" // tracks the most recent version of the artifact
+	BranchDrafts = "drafts" // tracks the versions of the artifact in DRAFT state
+)
+
 type BranchInfo struct {
 	GroupId       string `json:"groupId"`
 	ArtifactId    string `json:"artifactId"`
@@ -134,3 +171,36 @@ type BranchInfo struct {
 	ModifiedOn    string `json:"modifiedOn"`
 	ModifiedBy    string `json:"modifiedBy"`
 }
+
+// IsSystem reports whether the branch is defined and maintained by the registry rather than by a user.
+func (b BranchInfo) IsSystem() bool {
+	return b.SystemDefined
+}
+
+// LoggerConfig represents the runtime configuration of a single registry logger.
+type LoggerConfig struct {
+	Name  string   `json:"name"`
+	Level LogLevel `json:"level"`
+}
+
+// ConfigPropertyDefinition describes a dynamic configuration property of the registry.
+type ConfigPropertyDefinition struct {
+	Name          string   `json:"name"`                    // Name of the property, e.g. apicurio.ccompat.legacy-id-mode.enabled
+	Value         string   `json:"value"`                   // Current value of the property
+	Type          string   `json:"type"`                    // Java type of the value, e.g. java.lang.Boolean
+	Label         string   `json:"label"`                   // Short display name
+	Description   string   `json:"description"`             // Human-readable description
+	AllowedValues []string `json:"allowedValues,omitempty"` // Values the property accepts; empty unless the server reports them
+}
+
+// ImportArtifact is a single artifact to import into a group.
+type ImportArtifact struct {
+	GroupID  string
+	Artifact CreateArtifactRequest
+}
+
+// SchemaProposal is a candidate new version of an artifact, checked for compatibility before it is published.
+type SchemaProposal struct {
+	ArtifactID string
+	Content    CreateContentRequest
+}
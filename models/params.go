@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 	"github.com/go-playground/validator/v10"
+	"net/http"
 	"net/url"
 	"regexp"
 	"strconv"
@@ -50,6 +51,7 @@ const (
 type GetArtifactByGlobalIDParams struct {
 	HandleReferencesType HandleReferencesType `validate:"omitempty,oneof=PRESERVE DEREFERENCE REWRITE"`
 	ReturnArtifactType   bool                 `validate:"omitempty"`
+	ExpectedArtifactType ArtifactType         `validate:"omitempty,artifacttype"` // Fail with ErrArtifactTypeMismatch if the stored type differs
 }
 
 func (p *GetArtifactByGlobalIDParams) Validate() error {
@@ -61,7 +63,7 @@ func (p *GetArtifactByGlobalIDParams) ToQuery() url.Values {
 	if p.HandleReferencesType != "" {
 		query.Set("references", string(p.HandleReferencesType))
 	}
-	if p.ReturnArtifactType {
+	if p.ReturnArtifactType || p.ExpectedArtifactType != "" {
 		query.Set("returnType", "true")
 	}
 	return query
@@ -183,6 +185,10 @@ type CreateArtifactParams struct {
 	IfExists  IfExistsType `validate:"oneof=FAIL CREATE_VERSION FIND_OR_CREATE_VERSION"` // IfExists behavior @See IfExistsType
 	Canonical bool         // Indicates whether to canonicalize the artifact content.
 	DryRun    bool         // If true, no changes are made, only checks are performed.
+
+	// ValidateLocally checks the content of JSON Schema, Avro and Protobuf artifacts with ValidateJSONSchema,
+	// ValidateAvroSchema or ValidateProtobuf before upload.
+	ValidateLocally bool
 }
 
 // Validate validates the CreateArtifactParams struct.
@@ -277,6 +283,7 @@ func (p *ArtifactVersionReferencesParams) ToQuery() url.Values {
 // ArtifactReferenceParams represents the query parameters for artifact references.
 type ArtifactReferenceParams struct {
 	HandleReferencesType HandleReferencesType `validate:"omitempty,oneof=PRESERVE DEREFERENCE REWRITE"`
+	ExpectedArtifactType ArtifactType         `validate:"omitempty,artifacttype"` // Fail with ErrArtifactTypeMismatch if the stored type differs
 }
 
 // Validate validates the ArtifactReferenceParams struct.
@@ -293,6 +300,36 @@ func (p *ArtifactReferenceParams) ToQuery() url.Values {
 	return query
 }
 
+// ContentSelector selects the artifact version whose content is fetched: either a fixed version or the
+// version currently at the tip of a branch. Leaving both empty selects the latest version.
+type ContentSelector struct {
+	Version string `validate:"excluded_with=Branch"` // Version to fetch, e.g. "1.0.0"
+	Branch  string // Branch whose tip is fetched, e.g. "latest" or "drafts"
+}
+
+// Validate validates the ContentSelector struct.
+func (s *ContentSelector) Validate() error {
+	return structValidator.Struct(s)
+}
+
+// Expression returns the version expression sent to the registry for this selector.
+func (s ContentSelector) Expression() string {
+	switch {
+	case s.Branch != "":
+		return "branch=" + s.Branch
+	case s.Version != "":
+		return s.Version
+	default:
+		return "branch=" + BranchLatest
+	}
+}
+
+// ReconcileParams represents the optional parameters of GroupAPI.Reconcile.
+type ReconcileParams struct {
+	DryRun bool // Report the actions that would be taken without changing the registry
+	Prune  bool // Delete the artifacts of the group that are not desired
+}
+
 // SearchVersionParams represents the query parameters for searching artifact versions.
 type SearchVersionParams struct {
 	Version      string  `validate:"omitempty,version"`
@@ -374,8 +411,8 @@ type SearchVersionByContentParams struct {
 	Limit        int          `validate:"omitempty,gte=0"`
 	Order        Order        `validate:"omitempty,oneof=asc desc"`
 	OrderBy      OrderBy      `validate:"omitempty,oneof=name createdOn"`
-	GroupID      string       `validate:"omitempty,groupid"`
-	ArtifactID   string       `validate:"omitempty,artifactid"`
+	GroupID      string       `validate:"omitempty,groupid"`    // Restrict the search to versions in this group
+	ArtifactID   string       `validate:"omitempty,artifactid"` // Restrict the search to versions of this artifact
 }
 
 // Validate validates the SearchVersionByContentParams struct.
@@ -521,6 +558,10 @@ func (p *ListArtifactsVersionsParams) ToQuery() url.Values {
 type ListBranchesParams struct {
 	Offset int `validate:"omitempty,gte=0"` // Number of branches to skip
 	Limit  int `validate:"omitempty,gte=0"` // Number of branches to return
+
+	// ExcludeSystem drops system-defined branches such as "latest" and "drafts" from the result.
+	// The filter is applied client-side, so a page may hold fewer than Limit branches.
+	ExcludeSystem bool
 }
 
 func (p *ListBranchesParams) Validate() error {
@@ -539,6 +580,34 @@ func (p *ListBranchesParams) ToQuery() url.Values {
 
 }
 
+// CheckCompatibilityBatchParams represents the optional parameters for checking a batch of schema proposals.
+type CheckCompatibilityBatchParams struct {
+	StopOnFirstIncompatible bool // Skip the remaining proposals once one is incompatible
+}
+
+// ImportParams represents the optional parameters for importing registry data. Both IDs are always sent,
+// so a zero value asks the registry to assign new IDs; pass nil params to keep the registry's defaults.
+type ImportParams struct {
+	PreserveGlobalID  bool // Keep the global IDs of the imported versions
+	PreserveContentID bool // Keep the content IDs of the imported content
+}
+
+// ToQuery converts the ImportParams into URL query parameters, as read by registry 3.x.
+func (p *ImportParams) ToQuery() url.Values {
+	query := url.Values{}
+	query.Set("preserveGlobalId", strconv.FormatBool(p.PreserveGlobalID))
+	query.Set("preserveContentId", strconv.FormatBool(p.PreserveContentID))
+	return query
+}
+
+// ToHeaders converts the ImportParams into the X-Registry-Preserve-* headers read by registry 2.x.
+func (p *ImportParams) ToHeaders() http.Header {
+	headers := http.Header{}
+	headers.Set("X-Registry-Preserve-GlobalId", strconv.FormatBool(p.PreserveGlobalID))
+	headers.Set("X-Registry-Preserve-ContentId", strconv.FormatBool(p.PreserveContentID))
+	return headers
+}
+
 // CustomValidationFunctions registers custom validation functions with the validator.
 func CustomValidationFunctions(validate *validator.Validate) error {
 	// Validation for Version: ^[a-zA-Z0-9._\-+]{1,256}$
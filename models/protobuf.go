@@ -0,0 +1,944 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Field numbers are limited to 29 bits; the range 19000-19999 is reserved for the Protobuf implementation.
+const (
+	protoMaxFieldNumber      = 1<<29 - 1
+	protoReservedFieldsFirst = 19000
+	protoReservedFieldsLast  = 19999
+)
+
+var protoScalars = map[string]bool{
+	"double": true, "float": true, "int32": true, "int64": true, "uint32": true, "uint64": true,
+	"sint32": true, "sint64": true, "fixed32": true, "fixed64": true, "sfixed32": true, "sfixed64": true,
+	"bool": true, "string": true, "bytes": true,
+}
+
+// protoMapKeys are the scalar types allowed as map keys: any integral type, bool and string.
+var protoMapKeys = map[string]bool{
+	"int32": true, "int64": true, "uint32": true, "uint64": true, "sint32": true, "sint64": true,
+	"fixed32": true, "fixed64": true, "sfixed32": true, "sfixed64": true, "bool": true, "string": true,
+}
+
+// ValidateProtobuf parses a .proto definition before it is uploaded, so malformed definitions are caught
+// without a round-trip to the registry, whose Protobuf errors are terse. It checks the syntax of the file,
+// that field numbers are valid and unique within their message, that proto3 enums start at zero and that
+// message and enum types referenced by fields, RPCs and extensions are defined in the file. References that
+// cannot be resolved locally are accepted when the file has imports, as they may be defined by an imported file.
+// The returned *SchemaError carries the line and column of the part of the definition that failed.
+func ValidateProtobuf(content []byte) error {
+	tokens, err := tokenizeProto(string(content))
+	if err != nil {
+		return err
+	}
+	p := protoParser{tokens: tokens, syntax: "proto2", types: make(map[string]bool)}
+	if err := p.parseFile(); err != nil {
+		return err
+	}
+	return p.resolveReferences()
+}
+
+type protoTokenKind int
+
+const (
+	protoEOF protoTokenKind = iota
+	protoIdent
+	protoInt
+	protoFloat
+	protoString
+	protoSymbol
+)
+
+type protoToken struct {
+	kind   protoTokenKind
+	text   string
+	line   int
+	column int
+}
+
+func (t protoToken) String() string {
+	switch t.kind {
+	case protoEOF:
+		return "end of file"
+	case protoString:
+		return "string " + t.text
+	}
+	return strconv.Quote(t.text)
+}
+
+func protoError(tok protoToken, format string, args ...interface{}) *SchemaError {
+	return &SchemaError{Format: "Protobuf schema", Line: tok.line, Column: tok.column, Message: fmt.Sprintf(format, args...)}
+}
+
+// tokenizeProto splits a .proto definition into tokens, dropping whitespace and comments.
+func tokenizeProto(src string) ([]protoToken, error) {
+	var tokens []protoToken
+	line, column := 1, 1
+	advance := func(n int) {
+		for _, r := range src[:n] {
+			if r == '\n' {
+				line, column = line+1, 1
+			} else {
+				column++
+			}
+		}
+		src = src[n:]
+	}
+
+	for {
+		switch {
+		case src == "":
+			return append(tokens, protoToken{kind: protoEOF, line: line, column: column}), nil
+		case strings.ContainsRune(" \t\r\n\f\v", rune(src[0])):
+			advance(1)
+			continue
+		case strings.HasPrefix(src, "//"):
+			end := strings.IndexByte(src, '\n')
+			if end < 0 {
+				end = len(src)
+			}
+			advance(end)
+			continue
+		case strings.HasPrefix(src, "/*"):
+			end := strings.Index(src[2:], "*/")
+			if end < 0 {
+				return nil, protoError(protoToken{line: line, column: column}, "unterminated comment")
+			}
+			advance(end + 4)
+			continue
+		}
+
+		tok := protoToken{line: line, column: column}
+		c := src[0]
+		n := 1
+		switch {
+		case isProtoLetter(c):
+			for n < len(src) && (isProtoLetter(src[n]) || isProtoDigit(src[n])) {
+				n++
+			}
+			tok.kind = protoIdent
+		case isProtoDigit(c) || (c == '.' && len(src) > 1 && isProtoDigit(src[1])):
+			n, tok.kind = scanProtoNumber(src)
+			if n < len(src) && (isProtoLetter(src[n]) || src[n] == '.') {
+				return nil, protoError(tok, "invalid number %q", src[:n+1])
+			}
+		case c == '"' || c == '\'':
+			for n < len(src) && src[n] != c {
+				if src[n] == '\n' {
+					break
+				}
+				if src[n] == '\\' {
+					n++
+				}
+				n++
+			}
+			if n >= len(src) || src[n] != c {
+				return nil, protoError(tok, "unterminated string")
+			}
+			n++
+			tok.kind = protoString
+		case strings.IndexByte("=;{}[]()<>,.:-+", c) >= 0:
+			tok.kind = protoSymbol
+		default:
+			return nil, protoError(tok, "unexpected character %q", c)
+		}
+		tok.text = src[:n]
+		tokens = append(tokens, tok)
+		advance(n)
+	}
+}
+
+// scanProtoNumber returns the length of the integer or floating-point literal at the start of src.
+func scanProtoNumber(src string) (int, protoTokenKind) {
+	n := 0
+	if strings.HasPrefix(src, "0x") || strings.HasPrefix(src, "0X") {
+		n = 2
+		for n < len(src) && strings.IndexByte("0123456789abcdefABCDEF", src[n]) >= 0 {
+			n++
+		}
+		return n, protoInt
+	}
+
+	kind := protoInt
+	for n < len(src) && isProtoDigit(src[n]) {
+		n++
+	}
+	if n < len(src) && src[n] == '.' {
+		kind = protoFloat
+		n++
+		for n < len(src) && isProtoDigit(src[n]) {
+			n++
+		}
+	}
+	if n < len(src) && (src[n] == 'e' || src[n] == 'E') {
+		kind = protoFloat
+		n++
+		if n < len(src) && (src[n] == '+' || src[n] == '-') {
+			n++
+		}
+		for n < len(src) && isProtoDigit(src[n]) {
+			n++
+		}
+	}
+	return n, kind
+}
+
+func isProtoLetter(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isProtoDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// protoParser checks the grammar of a .proto file and collects the types it defines and references.
+type protoParser struct {
+	tokens  []protoToken
+	pos     int
+	syntax  string // "proto2", "proto3" or "editions"
+	pkg     string
+	imports bool
+	types   map[string]bool // Fully qualified names of the messages and enums defined, with a leading dot
+	refs    []protoReference
+}
+
+// protoReference is a type name used in the scope of a message or of the package.
+type protoReference struct {
+	tok   protoToken // Start of the name
+	name  string
+	scope string
+}
+
+// protoFields tracks the field names and numbers of a message, including those of its oneofs.
+type protoFields struct {
+	names   map[string]bool
+	numbers map[int64]string
+}
+
+func newProtoFields() *protoFields {
+	return &protoFields{names: make(map[string]bool), numbers: make(map[int64]string)}
+}
+
+func (p *protoParser) peek() protoToken {
+	return p.tokens[p.pos]
+}
+
+// peekSecond returns the token after the next one.
+func (p *protoParser) peekSecond() protoToken {
+	if p.pos+1 < len(p.tokens) {
+		return p.tokens[p.pos+1]
+	}
+	return p.tokens[len(p.tokens)-1]
+}
+
+func (p *protoParser) next() protoToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != protoEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is the given symbol or keyword.
+func (p *protoParser) accept(text string) bool {
+	if tok := p.peek(); (tok.kind == protoSymbol || tok.kind == protoIdent) && tok.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *protoParser) expect(text string) error {
+	if !p.accept(text) {
+		return protoError(p.peek(), "expected %q, got %s", text, p.peek())
+	}
+	return nil
+}
+
+func (p *protoParser) ident(what string) (protoToken, error) {
+	tok := p.next()
+	if tok.kind != protoIdent {
+		return tok, protoError(tok, "expected %s, got %s", what, tok)
+	}
+	return tok, nil
+}
+
+// fullIdent parses a dotted name such as foo.bar.Baz, with a leading dot when absolute is allowed.
+func (p *protoParser) fullIdent(what string, absolute bool) (protoToken, string, error) {
+	start := p.peek()
+	name := ""
+	if absolute && p.accept(".") {
+		name = "."
+	}
+	for {
+		tok, err := p.ident(what)
+		if err != nil {
+			return start, "", err
+		}
+		name += tok.text
+		if !p.accept(".") {
+			return start, name, nil
+		}
+		name += "."
+	}
+}
+
+func (p *protoParser) stringLiteral(what string) (protoToken, string, error) {
+	tok := p.next()
+	if tok.kind != protoString {
+		return tok, "", protoError(tok, "expected %s, got %s", what, tok)
+	}
+	value := tok.text[1 : len(tok.text)-1]
+	// Adjacent string literals are concatenated.
+	for p.peek().kind == protoString {
+		next := p.next().text
+		value += next[1 : len(next)-1]
+	}
+	return tok, value, nil
+}
+
+func (p *protoParser) parseFile() error {
+	if p.accept("syntax") {
+		if err := p.expect("="); err != nil {
+			return err
+		}
+		tok, syntax, err := p.stringLiteral("syntax")
+		if err != nil {
+			return err
+		}
+		if syntax != "proto2" && syntax != "proto3" {
+			return protoError(tok, "unknown syntax %q, expected \"proto2\" or \"proto3\"", syntax)
+		}
+		p.syntax = syntax
+		if err := p.expect(";"); err != nil {
+			return err
+		}
+	} else if p.accept("edition") {
+		if err := p.expect("="); err != nil {
+			return err
+		}
+		if _, _, err := p.stringLiteral("edition"); err != nil {
+			return err
+		}
+		p.syntax = "editions"
+		if err := p.expect(";"); err != nil {
+			return err
+		}
+	}
+
+	for p.peek().kind != protoEOF {
+		tok := p.next()
+		var err error
+		switch {
+		case tok.text == ";":
+		case tok.kind != protoIdent:
+			err = protoError(tok, "unexpected %s", tok)
+		case tok.text == "package":
+			err = p.parsePackage(tok)
+		case tok.text == "import":
+			err = p.parseImport()
+		case tok.text == "option":
+			err = p.parseOption()
+		case tok.text == "message":
+			err = p.parseMessage(p.packageScope())
+		case tok.text == "enum":
+			err = p.parseEnum(p.packageScope())
+		case tok.text == "service":
+			err = p.parseService()
+		case tok.text == "extend":
+			err = p.parseExtend(p.packageScope())
+		case tok.text == "syntax" || tok.text == "edition":
+			err = protoError(tok, "%s must be the first statement of the file", tok.text)
+		default:
+			err = protoError(tok, "unexpected %s", tok)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *protoParser) packageScope() string {
+	if p.pkg == "" {
+		return ""
+	}
+	return "." + p.pkg
+}
+
+func (p *protoParser) parsePackage(tok protoToken) error {
+	if p.pkg != "" {
+		return protoError(tok, "package is declared more than once")
+	}
+	_, name, err := p.fullIdent("package name", false)
+	if err != nil {
+		return err
+	}
+	p.pkg = name
+	return p.expect(";")
+}
+
+func (p *protoParser) parseImport() error {
+	if !p.accept("public") {
+		p.accept("weak")
+	}
+	if _, _, err := p.stringLiteral("import path"); err != nil {
+		return err
+	}
+	p.imports = true
+	return p.expect(";")
+}
+
+// parseOption parses the remainder of an option statement: name = constant;
+func (p *protoParser) parseOption() error {
+	if err := p.parseOptionAssignment(); err != nil {
+		return err
+	}
+	return p.expect(";")
+}
+
+func (p *protoParser) parseOptionAssignment() error {
+	for {
+		if p.accept("(") {
+			if _, _, err := p.fullIdent("option name", true); err != nil {
+				return err
+			}
+			if err := p.expect(")"); err != nil {
+				return err
+			}
+		} else if _, err := p.ident("option name"); err != nil {
+			return err
+		}
+		if !p.accept(".") {
+			break
+		}
+	}
+	if err := p.expect("="); err != nil {
+		return err
+	}
+	return p.parseConstant()
+}
+
+// parseConstant parses an option value: an identifier, a signed number, a string or a text format aggregate.
+func (p *protoParser) parseConstant() error {
+	tok := p.peek()
+	switch {
+	case tok.kind == protoString:
+		_, _, err := p.stringLiteral("constant")
+		return err
+	case tok.kind == protoIdent:
+		_, _, err := p.fullIdent("constant", false)
+		return err
+	case tok.text == "{":
+		return p.skipAggregate()
+	}
+
+	if p.accept("-") || p.accept("+") {
+		tok = p.peek()
+		if tok.kind == protoIdent && (tok.text == "inf" || tok.text == "nan") {
+			p.next()
+			return nil
+		}
+	}
+	if tok = p.next(); tok.kind != protoInt && tok.kind != protoFloat {
+		return protoError(tok, "expected constant, got %s", tok)
+	}
+	return nil
+}
+
+// skipAggregate skips a text format message value in braces, as used by options of message type.
+func (p *protoParser) skipAggregate() error {
+	open := p.next()
+	for depth := 1; depth > 0; {
+		tok := p.next()
+		switch {
+		case tok.kind == protoEOF:
+			return protoError(open, "unterminated option value")
+		case tok.text == "{":
+			depth++
+		case tok.text == "}":
+			depth--
+		}
+	}
+	return nil
+}
+
+// parseFieldOptions parses the optional [name = value, ...] list of a field or enum value.
+func (p *protoParser) parseFieldOptions() error {
+	if !p.accept("[") {
+		return nil
+	}
+	for {
+		if err := p.parseOptionAssignment(); err != nil {
+			return err
+		}
+		if !p.accept(",") {
+			break
+		}
+	}
+	return p.expect("]")
+}
+
+func (p *protoParser) define(tok protoToken, scope, name string) (string, error) {
+	fullName := scope + "." + name
+	if p.types[fullName] {
+		return "", protoError(tok, "%s is defined more than once", strings.TrimPrefix(fullName, "."))
+	}
+	p.types[fullName] = true
+	return fullName, nil
+}
+
+func (p *protoParser) parseMessage(scope string) error {
+	name, err := p.ident("message name")
+	if err != nil {
+		return err
+	}
+	fullName, err := p.define(name, scope, name.text)
+	if err != nil {
+		return err
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	return p.parseMessageBody(fullName, newProtoFields())
+}
+
+// parseMessageBody parses the statements of a message up to and including its closing brace.
+func (p *protoParser) parseMessageBody(scope string, fields *protoFields) error {
+	for {
+		tok := p.peek()
+		var err error
+		switch {
+		case tok.kind == protoEOF:
+			return protoError(tok, "unexpected end of file, expected \"}\"")
+		case p.accept("}"):
+			return nil
+		case p.accept(";"):
+		case p.accept("message"):
+			err = p.parseMessage(scope)
+		case p.accept("enum"):
+			err = p.parseEnum(scope)
+		case p.accept("extend"):
+			err = p.parseExtend(scope)
+		case p.accept("option"):
+			err = p.parseOption()
+		case p.accept("oneof"):
+			err = p.parseOneof(scope, fields)
+		case p.accept("reserved"):
+			err = p.parseReserved()
+		case p.accept("extensions"):
+			err = p.parseExtensions()
+		default:
+			err = p.parseField(scope, fields, true)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// parseField parses a normal field, a map field or a proto2 group. Labels are only allowed outside oneofs.
+func (p *protoParser) parseField(scope string, fields *protoFields, labeled bool) error {
+	label := p.peek()
+	if labeled && label.kind == protoIdent {
+		switch label.text {
+		case "required":
+			if p.syntax != "proto2" {
+				return protoError(label, "required fields are not allowed in %s", p.syntax)
+			}
+			p.next()
+		case "optional", "repeated":
+			p.next()
+		}
+	}
+
+	typeTok := p.peek()
+	switch {
+	case typeTok.kind == protoIdent && typeTok.text == "map" && p.peekSecond().text == "<":
+		if err := p.parseMapType(scope); err != nil {
+			return err
+		}
+	case typeTok.kind == protoIdent && typeTok.text == "group" && p.peekSecond().kind == protoIdent:
+		p.next()
+		return p.parseGroup(scope, fields)
+	default:
+		if err := p.parseTypeReference(scope, "field type"); err != nil {
+			return err
+		}
+	}
+
+	name, err := p.ident("field name")
+	if err != nil {
+		return err
+	}
+	if err := p.parseFieldNumber(name, fields); err != nil {
+		return err
+	}
+	if err := p.parseFieldOptions(); err != nil {
+		return err
+	}
+	return p.expect(";")
+}
+
+func (p *protoParser) parseMapType(scope string) error {
+	p.next()
+	if err := p.expect("<"); err != nil {
+		return err
+	}
+	key, err := p.ident("map key type")
+	if err != nil {
+		return err
+	}
+	if !protoMapKeys[key.text] {
+		return protoError(key, "invalid map key type %q, expected an integral type, bool or string", key.text)
+	}
+	if err := p.expect(","); err != nil {
+		return err
+	}
+	if err := p.parseTypeReference(scope, "map value type"); err != nil {
+		return err
+	}
+	return p.expect(">")
+}
+
+// parseGroup parses a proto2 group, which declares a nested message and a field of that type at once.
+func (p *protoParser) parseGroup(scope string, fields *protoFields) error {
+	name, err := p.ident("group name")
+	if err != nil {
+		return err
+	}
+	if p.syntax != "proto2" {
+		return protoError(name, "groups are not allowed in %s", p.syntax)
+	}
+	fullName, err := p.define(name, scope, name.text)
+	if err != nil {
+		return err
+	}
+	fieldName := name
+	fieldName.text = strings.ToLower(name.text)
+	if err := p.parseFieldNumber(fieldName, fields); err != nil {
+		return err
+	}
+	if err := p.parseFieldOptions(); err != nil {
+		return err
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	return p.parseMessageBody(fullName, newProtoFields())
+}
+
+// parseFieldNumber parses "= number" and checks that the field name and number are unique in the message.
+func (p *protoParser) parseFieldNumber(name protoToken, fields *protoFields) error {
+	if fields.names[name.text] {
+		return protoError(name, "field %s is defined more than once", name.text)
+	}
+	fields.names[name.text] = true
+
+	if err := p.expect("="); err != nil {
+		return err
+	}
+	tok := p.next()
+	if tok.kind != protoInt {
+		return protoError(tok, "expected field number, got %s", tok)
+	}
+	number, err := strconv.ParseInt(tok.text, 0, 64)
+	switch {
+	case err != nil || number < 1 || number > protoMaxFieldNumber:
+		return protoError(tok, "field number %s of %s must be between 1 and %d", tok.text, name.text, protoMaxFieldNumber)
+	case number >= protoReservedFieldsFirst && number <= protoReservedFieldsLast:
+		return protoError(tok, "field number %d of %s is reserved for the Protobuf implementation", number, name.text)
+	}
+	if other, found := fields.numbers[number]; found {
+		return protoError(tok, "field number %d of %s is already used by %s", number, name.text, other)
+	}
+	fields.numbers[number] = name.text
+	return nil
+}
+
+// parseTypeReference parses a message or enum type name and records it for resolution.
+func (p *protoParser) parseTypeReference(scope, what string) error {
+	tok, name, err := p.fullIdent(what, true)
+	if err != nil {
+		return err
+	}
+	if !protoScalars[name] {
+		p.refs = append(p.refs, protoReference{tok: tok, name: name, scope: scope})
+	}
+	return nil
+}
+
+func (p *protoParser) parseOneof(scope string, fields *protoFields) error {
+	if _, err := p.ident("oneof name"); err != nil {
+		return err
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for {
+		tok := p.peek()
+		var err error
+		switch {
+		case tok.kind == protoEOF:
+			return protoError(tok, "unexpected end of file, expected \"}\"")
+		case p.accept("}"):
+			return nil
+		case p.accept(";"):
+		case p.accept("option"):
+			err = p.parseOption()
+		default:
+			err = p.parseField(scope, fields, false)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// parseReserved parses reserved field numbers or names: ranges such as "2, 15, 9 to 11" or names.
+func (p *protoParser) parseReserved() error {
+	if tok := p.peek(); tok.kind == protoString || tok.kind == protoIdent {
+		for {
+			if tok := p.next(); tok.kind != protoString && tok.kind != protoIdent {
+				return protoError(tok, "expected reserved name, got %s", tok)
+			}
+			if !p.accept(",") {
+				return p.expect(";")
+			}
+		}
+	}
+	if err := p.parseRanges(); err != nil {
+		return err
+	}
+	return p.expect(";")
+}
+
+func (p *protoParser) parseExtensions() error {
+	if err := p.parseRanges(); err != nil {
+		return err
+	}
+	if err := p.parseFieldOptions(); err != nil {
+		return err
+	}
+	return p.expect(";")
+}
+
+// parseRanges parses a comma-separated list of numbers and ranges such as "9 to 11" or "1000 to max".
+func (p *protoParser) parseRanges() error {
+	for {
+		p.accept("-")
+		if tok := p.next(); tok.kind != protoInt {
+			return protoError(tok, "expected number, got %s", tok)
+		}
+		if p.accept("to") && !p.accept("max") {
+			p.accept("-")
+			if tok := p.next(); tok.kind != protoInt {
+				return protoError(tok, "expected number or \"max\", got %s", tok)
+			}
+		}
+		if !p.accept(",") {
+			return nil
+		}
+	}
+}
+
+func (p *protoParser) parseEnum(scope string) error {
+	name, err := p.ident("enum name")
+	if err != nil {
+		return err
+	}
+	fullName, err := p.define(name, scope, name.text)
+	if err != nil {
+		return err
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+
+	values := make(map[string]bool)
+	for {
+		tok := p.peek()
+		switch {
+		case tok.kind == protoEOF:
+			return protoError(tok, "unexpected end of file, expected \"}\"")
+		case p.accept("}"):
+			if len(values) == 0 {
+				return protoError(name, "enum %s must have at least one value", name.text)
+			}
+			return nil
+		case p.accept(";"):
+		case p.accept("option"):
+			if err := p.parseOption(); err != nil {
+				return err
+			}
+		case p.accept("reserved"):
+			if err := p.parseReserved(); err != nil {
+				return err
+			}
+		default:
+			if err := p.parseEnumValue(fullName, values); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *protoParser) parseEnumValue(enum string, values map[string]bool) error {
+	name, err := p.ident("enum value name")
+	if err != nil {
+		return err
+	}
+	if values[name.text] {
+		return protoError(name, "enum value %s is defined more than once", name.text)
+	}
+	if err := p.expect("="); err != nil {
+		return err
+	}
+	negative := p.accept("-")
+	tok := p.next()
+	if tok.kind != protoInt {
+		return protoError(tok, "expected enum value number, got %s", tok)
+	}
+	if number, err := strconv.ParseInt(tok.text, 0, 64); p.syntax == "proto3" && len(values) == 0 && (negative || err != nil || number != 0) {
+		return protoError(tok, "the first value of enum %s must be zero in proto3", strings.TrimPrefix(enum, "."))
+	}
+	values[name.text] = true
+	if err := p.parseFieldOptions(); err != nil {
+		return err
+	}
+	return p.expect(";")
+}
+
+func (p *protoParser) parseService() error {
+	if _, err := p.ident("service name"); err != nil {
+		return err
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for {
+		tok := p.peek()
+		var err error
+		switch {
+		case tok.kind == protoEOF:
+			return protoError(tok, "unexpected end of file, expected \"}\"")
+		case p.accept("}"):
+			return nil
+		case p.accept(";"):
+		case p.accept("option"):
+			err = p.parseOption()
+		case p.accept("rpc"):
+			err = p.parseRPC()
+		default:
+			err = protoError(tok, "unexpected %s in service", tok)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (p *protoParser) parseRPC() error {
+	if _, err := p.ident("rpc name"); err != nil {
+		return err
+	}
+	if err := p.parseRPCType(); err != nil {
+		return err
+	}
+	if err := p.expect("returns"); err != nil {
+		return err
+	}
+	if err := p.parseRPCType(); err != nil {
+		return err
+	}
+
+	if !p.accept("{") {
+		return p.expect(";")
+	}
+	for {
+		tok := p.peek()
+		switch {
+		case tok.kind == protoEOF:
+			return protoError(tok, "unexpected end of file, expected \"}\"")
+		case p.accept("}"):
+			return nil
+		case p.accept(";"):
+		case p.accept("option"):
+			if err := p.parseOption(); err != nil {
+				return err
+			}
+		default:
+			return protoError(tok, "unexpected %s in rpc", tok)
+		}
+	}
+}
+
+// parseRPCType parses the request or response of an rpc: ( [stream] messageType )
+func (p *protoParser) parseRPCType() error {
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	// "stream" is only a keyword when a type follows; a message may be named stream as well.
+	if second := p.peekSecond(); p.peek().text == "stream" && (second.kind == protoIdent || second.text == ".") {
+		p.next()
+	}
+	if err := p.parseTypeReference(p.packageScope(), "message type"); err != nil {
+		return err
+	}
+	return p.expect(")")
+}
+
+func (p *protoParser) parseExtend(scope string) error {
+	if err := p.parseTypeReference(scope, "extended message"); err != nil {
+		return err
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	fields := newProtoFields()
+	for {
+		tok := p.peek()
+		switch {
+		case tok.kind == protoEOF:
+			return protoError(tok, "unexpected end of file, expected \"}\"")
+		case p.accept("}"):
+			return nil
+		case p.accept(";"):
+		default:
+			if err := p.parseField(scope, fields, true); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// resolveReferences checks that every referenced type is defined, searching the enclosing scopes from the
+// innermost outwards as protoc does.
+func (p *protoParser) resolveReferences() error {
+	for _, ref := range p.refs {
+		if p.resolve(ref) || p.imports {
+			continue
+		}
+		return protoError(ref.tok, "undefined type %q", ref.name)
+	}
+	return nil
+}
+
+func (p *protoParser) resolve(ref protoReference) bool {
+	if strings.HasPrefix(ref.name, ".") {
+		return p.types[ref.name]
+	}
+	for scope := ref.scope; ; scope = scope[:strings.LastIndex(scope, ".")] {
+		if p.types[scope+"."+ref.name] {
+			return true
+		}
+		if scope == "" {
+			return false
+		}
+	}
+}
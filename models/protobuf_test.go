@@ -0,0 +1,108 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateProtobuf(t *testing.T) {
+	t.Run("Valid Definition", func(t *testing.T) {
+		definition := `
+			syntax = "proto3";
+
+			package com.example.orders;
+
+			option java_multiple_files = true;
+
+			/* An order placed by a customer. */
+			message Order {
+				string id = 1;
+				Status status = 2 [deprecated = true];
+				repeated Line lines = 3;
+				map<string, int64> totals = 4;
+				optional .com.example.orders.Order previous = 5;
+				oneof payment {
+					string card_token = 6;
+					Transfer transfer = 7;
+				}
+				reserved 8, 10 to 12;
+				reserved "legacy";
+
+				message Line {
+					string sku = 1;
+					int32 quantity = 2;
+				}
+				message Transfer { string iban = 1; }
+			}
+
+			enum Status {
+				STATUS_UNSPECIFIED = 0;
+				STATUS_OPEN = 1;
+				STATUS_PAID = 2;
+			}
+
+			service Orders {
+				rpc Get (Order) returns (Order);
+				rpc Watch (Order) returns (stream Order) {
+					option idempotency_level = NO_SIDE_EFFECTS;
+				}
+			}
+		`
+		assert.NoError(t, models.ValidateProtobuf([]byte(definition)))
+	})
+
+	t.Run("Undefined Type", func(t *testing.T) {
+		definition := "syntax = \"proto3\";\n\nmessage Order {\n  string id = 1;\n  Customer customer = 2;\n}\n"
+
+		err := models.ValidateProtobuf([]byte(definition))
+		assert.ErrorIs(t, err, models.ErrInvalidContent)
+		var schemaErr *models.SchemaError
+		if assert.ErrorAs(t, err, &schemaErr) {
+			assert.Equal(t, 5, schemaErr.Line)
+			assert.Equal(t, 3, schemaErr.Column)
+		}
+		assert.EqualError(t, err, `invalid Protobuf schema at line 5, column 3: undefined type "Customer"`)
+	})
+
+	t.Run("Imported Types", func(t *testing.T) {
+		definition := `
+			syntax = "proto3";
+			import "google/protobuf/timestamp.proto";
+			message Order { google.protobuf.Timestamp created_at = 1; }
+		`
+		assert.NoError(t, models.ValidateProtobuf([]byte(definition)), "types may be defined by imported files")
+	})
+
+	t.Run("Invalid Definitions", func(t *testing.T) {
+		tests := []struct {
+			definition string
+			line       int
+			message    string
+		}{
+			{"syntax = \"proto4\";", 1, `unknown syntax "proto4", expected "proto2" or "proto3"`},
+			{"syntax = \"proto3\";\nmessage Order {\n  string id = 1\n}", 4, `expected ";", got "}"`},
+			{"message Order {\n  string id = 1;", 2, `unexpected end of file, expected "}"`},
+			{"message Order {\n  string id = 1;\n  int32 total = 1;\n}", 3, "field number 1 of total is already used by id"},
+			{"message Order {\n  string id = 0;\n}", 2, "field number 0 of id must be between 1 and 536870911"},
+			{"message Order {\n  string id = 19000;\n}", 2, "field number 19000 of id is reserved for the Protobuf implementation"},
+			{"syntax = \"proto3\";\nmessage Order {\n  required string id = 1;\n}", 3, "required fields are not allowed in proto3"},
+			{"syntax = \"proto3\";\nenum Status {\n  OPEN = 1;\n}", 3, "the first value of enum Status must be zero in proto3"},
+			{"message Order {\n  map<double, string> totals = 1;\n}", 2, `invalid map key type "double", expected an integral type, bool or string`},
+			{"message Order {}\nmessage Order {}", 2, "Order is defined more than once"},
+			{"message Order {\n  string id = 1; // the ID\n  string note = \"1\";\n}", 3, `expected field number, got string "1"`},
+			{"message Order {\n  string id = 1;\n} /* unterminated", 3, "unterminated comment"},
+			{"service Orders {\n  rpc Get (Order) returns (Order);\n}", 2, `undefined type "Order"`},
+		}
+		for _, tt := range tests {
+			err := models.ValidateProtobuf([]byte(tt.definition))
+			var schemaErr *models.SchemaError
+			if assert.ErrorAs(t, err, &schemaErr, tt.definition) {
+				assert.Equal(t, "Protobuf schema", schemaErr.Format, tt.definition)
+				assert.Equal(t, tt.line, schemaErr.Line, tt.definition)
+				assert.Equal(t, tt.message, schemaErr.Message, tt.definition)
+			}
+		}
+	})
+}
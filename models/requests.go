@@ -1,5 +1,10 @@
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 // ========================================
 // SECTION: Requests
 // ========================================
@@ -12,12 +17,28 @@ type CreateArtifactRequest struct {
 	Description  string               `json:"description,omitempty"`
 	Labels       map[string]string    `json:"labels,omitempty"`
 	FirstVersion CreateVersionRequest `json:"firstVersion,omitempty"`
+
+	// Properties holds provenance metadata such as the commit or pipeline that produced the artifact.
+	// Registry v3 no longer distinguishes properties from labels, so they are merged into Labels
+	// when the request is serialized. Labels win when the same key is present in both maps.
+	Properties map[string]string `json:"-"`
 }
 
 func (r *CreateArtifactRequest) Validate() error {
+	if err := r.FirstVersion.ResolveDraft(); err != nil {
+		return err
+	}
 	return structValidator.Struct(r)
 }
 
+// MarshalJSON implements the json.Marshaler interface, merging Properties into Labels.
+func (r CreateArtifactRequest) MarshalJSON() ([]byte, error) {
+	type alias CreateArtifactRequest
+	a := alias(r)
+	a.Labels = mergeLabels(r.Labels, r.Properties)
+	return json.Marshal(a)
+}
+
 // CreateVersionRequest represents the request to create a version for an artifact.
 type CreateVersionRequest struct {
 	Version     string               `json:"version,omitempty"`
@@ -27,12 +48,56 @@ type CreateVersionRequest struct {
 	Labels      map[string]string    `json:"labels,omitempty"`
 	Branches    []string             `json:"branches,omitempty"`
 	IsDraft     bool                 `json:"isDraft"`
+
+	// State is the target state of the new version. StateDraft sets IsDraft automatically;
+	// the registry creates every other version as ENABLED, so any other value must not be combined with IsDraft.
+	State State `json:"-" validate:"omitempty,oneof=ENABLED DISABLED DEPRECATED DRAFT"`
+
+	// Properties holds provenance metadata for the version. See CreateArtifactRequest.Properties.
+	Properties map[string]string `json:"-"`
+
+	// ValidateLocally checks the content before it is uploaded: with ValidateAvroSchema when ArtifactType is Avro,
+	// with ValidateProtobuf when it is Protobuf and with ValidateJSONSchema when it is Json. Without an
+	// ArtifactType the content is not checked.
+	ValidateLocally bool `json:"-"`
+
+	// ArtifactType is the type of the artifact the version is added to. It only selects the check made by
+	// ValidateLocally and is not sent.
+	ArtifactType ArtifactType `json:"-"`
 }
 
 func (r *CreateVersionRequest) Validate() error {
+	if err := r.ResolveDraft(); err != nil {
+		return err
+	}
 	return structValidator.Struct(r)
 }
 
+// ResolveDraft sets IsDraft when State is StateDraft and rejects contradictory combinations.
+// Since IsDraft is a plain bool, an unset IsDraft cannot be told apart from an explicit false, so
+// the contradiction reported is IsDraft combined with a non-draft State.
+func (r *CreateVersionRequest) ResolveDraft() error {
+	if r.State == StateDraft {
+		r.IsDraft = true
+		return nil
+	}
+	if r.IsDraft && r.State != "" {
+		return fmt.Errorf("%w: isDraft=true, state=%s", ErrDraftStateConflict, r.State)
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface, merging Properties into Labels.
+func (r CreateVersionRequest) MarshalJSON() ([]byte, error) {
+	type alias CreateVersionRequest
+	a := alias(r)
+	a.Labels = mergeLabels(r.Labels, r.Properties)
+	if r.State == StateDraft {
+		a.IsDraft = true
+	}
+	return json.Marshal(a)
+}
+
 // CreateContentRequest represents the content of an artifact.
 type CreateContentRequest struct {
 	Content     string              `json:"content" validate:"required"`
@@ -84,3 +149,58 @@ func (r *CreateBranchRequest) Validate() error {
 type UpdateBranchMetaDataRequest struct {
 	Description string `json:"description,omitempty"`
 }
+
+// mergeLabels returns the union of labels and properties, with labels taking precedence.
+func mergeLabels(labels, properties map[string]string) map[string]string {
+	if len(properties) == 0 {
+		return labels
+	}
+	merged := make(map[string]string, len(labels)+len(properties))
+	for k, v := range properties {
+		merged[k] = v
+	}
+	for k, v := range labels {
+		merged[k] = v
+	}
+	return merged
+}
+
+// UpdateLoggerRequest represents the request to change the level of a registry logger.
+type UpdateLoggerRequest struct {
+	Level LogLevel `json:"level" validate:"required,oneof=TRACE DEBUG INFO WARN ERROR SEVERE WARNING CONFIG FINE FINER FINEST"`
+}
+
+func (r *UpdateLoggerRequest) Validate() error {
+	return structValidator.Struct(r)
+}
+
+// DesiredArtifact is the desired state of an artifact in a group; see GroupAPI.Reconcile.
+type DesiredArtifact struct {
+	ArtifactID   string       `validate:"required,artifactid"`
+	ArtifactType ArtifactType `validate:"omitempty,artifacttype"`
+
+	// Versions that must exist, oldest first, matched by their Version. The first one creates the artifact.
+	// Existing versions that are not listed are kept, as the registry treats versions as history.
+	Versions []CreateVersionRequest `validate:"required,min=1,dive"`
+
+	// Rules maps each artifact rule to its level. Artifact rules that are not listed are removed.
+	Rules map[Rule]RuleLevel
+}
+
+// Validate validates the DesiredArtifact struct and checks that every version names its Version once.
+func (a *DesiredArtifact) Validate() error {
+	if err := structValidator.Struct(a); err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(a.Versions))
+	for _, v := range a.Versions {
+		if v.Version == "" {
+			return fmt.Errorf("artifact %s: every desired version needs a Version", a.ArtifactID)
+		}
+		if seen[v.Version] {
+			return fmt.Errorf("artifact %s: version %s is listed more than once", a.ArtifactID, v.Version)
+		}
+		seen[v.Version] = true
+	}
+	return nil
+}
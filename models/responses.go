@@ -1,9 +1,24 @@
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
 // ========================================
 // SECTION: Responses
 // ========================================
 
+// Page is one page of a paged listing together with the total number of items reported by the registry,
+// e.g. for "showing 20 of 345".
+type Page[T any] struct {
+	Items []T // Items on this page
+	Count int // Total number of items across all pages
+}
+
 // SearchArtifactsAPIResponse represents the response from the search artifacts API.
 type SearchArtifactsAPIResponse struct {
 	Artifacts []SearchedArtifact `json:"artifacts"`
@@ -18,7 +33,25 @@ type ListArtifactsResponse struct {
 
 // CreateArtifactResponse represents the response from the create artifact API.
 type CreateArtifactResponse struct {
-	Artifact ArtifactDetail `json:"artifact"`
+	Artifact ArtifactDetail           `json:"artifact"`
+	Version  *ArtifactVersionMetadata `json:"version,omitempty"` // The created or found version, if reported
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Registry 3.x releases differ in whether
+// the created artifact is nested under "artifact" or returned at the top level; both shapes are accepted.
+// Only the nested shape reports the version separately.
+func (r *CreateArtifactResponse) UnmarshalJSON(data []byte) error {
+	var nested struct {
+		Artifact *ArtifactDetail          `json:"artifact"`
+		Version  *ArtifactVersionMetadata `json:"version"`
+	}
+	if err := json.Unmarshal(data, &nested); err == nil && nested.Artifact != nil {
+		r.Artifact = *nested.Artifact
+		r.Version = nested.Version
+		return nil
+	}
+	r.Version = nil
+	return json.Unmarshal(data, &r.Artifact)
 }
 
 // ArtifactVersionListResponse represents the response of GetArtifactVersions.
@@ -27,6 +60,64 @@ type ArtifactVersionListResponse struct {
 	Versions []ArtifactVersion `json:"versions"`
 }
 
+// ImportSummary reports the outcome of an artifact import. Artifacts are identified as "groupId/artifactId".
+type ImportSummary struct {
+	Imported []string
+	Skipped  []string
+}
+
+// ReconcileResult reports the changes GroupAPI.Reconcile made, in the order they were made. In a dry run
+// the actions are the ones that would have been made.
+type ReconcileResult struct {
+	DryRun  bool
+	Actions []ReconcileAction
+}
+
+// ReconcileAction is a single change made by GroupAPI.Reconcile. Version is set for version changes,
+// Rule for rule changes and Level for created and updated rules.
+type ReconcileAction struct {
+	Kind       ReconcileActionKind
+	ArtifactID string
+	Version    string
+	Rule       Rule
+	Level      RuleLevel
+}
+
+// String formats the action for logs, e.g. "CREATE_VERSION orders@2.0.0" or "UPDATE_RULE orders COMPATIBILITY=FULL".
+func (a ReconcileAction) String() string {
+	switch {
+	case a.Version != "":
+		return fmt.Sprintf("%s %s@%s", a.Kind, a.ArtifactID, a.Version)
+	case a.Level != "":
+		return fmt.Sprintf("%s %s %s=%s", a.Kind, a.ArtifactID, a.Rule, a.Level)
+	case a.Rule != "":
+		return fmt.Sprintf("%s %s %s", a.Kind, a.ArtifactID, a.Rule)
+	default:
+		return fmt.Sprintf("%s %s", a.Kind, a.ArtifactID)
+	}
+}
+
+// RegistryEvent is a change reported on the registry's event stream; see SystemAPI.SubscribeEvents.
+// Data holds the event payload as sent, for fields not decoded here or payloads that are not JSON objects.
+type RegistryEvent struct {
+	Type       RegistryEventType `json:"type"`
+	GroupID    string            `json:"groupId"`
+	ArtifactID string            `json:"artifactId"`
+	Version    string            `json:"version,omitempty"`
+	Data       json.RawMessage   `json:"-"`
+}
+
+// DeleteGroupResult reports the outcome of deleting all artifacts in a group.
+type DeleteGroupResult struct {
+	Deleted int `json:"deleted"` // Number of artifacts deleted
+}
+
+// AsyncOperation is a long-running server task accepted with 202 Accepted. StatusURL is the absolute
+// URL from the Location header that reports the task's progress; see AdminAPI.WaitForOperation.
+type AsyncOperation struct {
+	StatusURL string `json:"statusUrl"`
+}
+
 type StateResponse struct {
 	State State `json:"state"`
 }
@@ -43,6 +134,18 @@ type SystemInfoResponse struct {
 	BuiltOn     string `json:"builtOn"`
 }
 
+// BuildTime parses BuiltOn, the build timestamp of the registry. It returns the zero time when BuiltOn is empty.
+func (r *SystemInfoResponse) BuildTime() (time.Time, error) {
+	if r.BuiltOn == "" {
+		return time.Time{}, nil
+	}
+	builtOn, err := time.Parse(time.RFC3339, r.BuiltOn)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid build timestamp %q: %w", r.BuiltOn, err)
+	}
+	return builtOn, nil
+}
+
 type SystemResourceLimitInfoResponse struct {
 	MaxTotalSchemasCount              int `json:"maxTotalSchemasCount"`
 	MaxSchemaSizeBytes                int `json:"maxSchemaSizeBytes"`
@@ -114,3 +217,81 @@ type BranchesInfoResponse struct {
 	Branches []BranchInfo `json:"branches"`
 	Count    int          `json:"count"`
 }
+
+// Incompatibility is a single structured difference reported by a compatibility check.
+type Incompatibility struct {
+	Type    string // Type of the incompatibility, e.g. READER_FIELD_MISSING_DEFAULT_VALUE; empty when unknown
+	Path    string // Location of the incompatibility within the schema
+	Message string // Human-readable description
+}
+
+// CompatibilityResult represents the outcome of a compatibility check.
+type CompatibilityResult struct {
+	Compatible        bool
+	Incompatibilities []Incompatibility
+}
+
+// Reasons returns a human-readable description of every incompatibility, in the order reported by the registry.
+func (r *CompatibilityResult) Reasons() []string {
+	reasons := make([]string, 0, len(r.Incompatibilities))
+	for _, incompatibility := range r.Incompatibilities {
+		reasons = append(reasons, incompatibility.String())
+	}
+	return reasons
+}
+
+// String formats the incompatibility as "TYPE at PATH: MESSAGE", leaving out the parts that are unknown.
+func (i Incompatibility) String() string {
+	switch {
+	case i.Type != "" && i.Path != "":
+		return fmt.Sprintf("%s at %s: %s", i.Type, i.Path, i.Message)
+	case i.Type != "":
+		return fmt.Sprintf("%s: %s", i.Type, i.Message)
+	case i.Path != "":
+		return fmt.Sprintf("at %s: %s", i.Path, i.Message)
+	default:
+		return i.Message
+	}
+}
+
+// NewCompatibilityResult builds a CompatibilityResult from the rule violation causes returned by the registry.
+// Avro causes carry the formatted Avro incompatibility (type, location and message), while
+// JSON Schema and Protobuf causes carry a description and the path in the context.
+func NewCompatibilityResult(causes []RuleViolationCause) *CompatibilityResult {
+	result := &CompatibilityResult{Compatible: len(causes) == 0}
+	for _, cause := range causes {
+		result.Incompatibilities = append(result.Incompatibilities, parseIncompatibility(cause))
+	}
+	return result
+}
+
+// BatchCompatibilityResult aggregates the compatibility checks of a set of schema proposals.
+type BatchCompatibilityResult struct {
+	Compatible bool                            // True when every evaluated proposal is compatible
+	Results    map[string]*CompatibilityResult // Result per artifact ID of every evaluated proposal
+	Skipped    []string                        // Artifact IDs left unevaluated after short-circuiting
+}
+
+// avroIncompatibilityPattern matches the string form of an Avro SchemaCompatibility.Incompatibility.
+var avroIncompatibilityPattern = regexp.MustCompile(`^Incompatibility\{type:([A-Z_]+), location:([^,]*), message:(.*?)(?:, reader:.*)?\}$`)
+
+// typedDescriptionPattern matches descriptions of the form "TYPE_NAME: message".
+var typedDescriptionPattern = regexp.MustCompile(`^([A-Z][A-Z0-9_]+):\s*(.*)$`)
+
+func parseIncompatibility(cause RuleViolationCause) Incompatibility {
+	description := strings.TrimSpace(cause.Description)
+
+	if m := avroIncompatibilityPattern.FindStringSubmatch(description); m != nil {
+		path := m[2]
+		if path == "" {
+			path = cause.Context
+		}
+		return Incompatibility{Type: m[1], Path: path, Message: m[3]}
+	}
+
+	if m := typedDescriptionPattern.FindStringSubmatch(description); m != nil {
+		return Incompatibility{Type: m[1], Path: cause.Context, Message: m[2]}
+	}
+
+	return Incompatibility{Path: cause.Context, Message: description}
+}
@@ -0,0 +1,105 @@
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCompatibilityResult(t *testing.T) {
+	t.Run("Avro Incompatibilities", func(t *testing.T) {
+		payload := `{
+			"title": "Incompatible artifact",
+			"status": 409,
+			"name": "RuleViolationException",
+			"causes": [
+				{
+					"description": "Incompatibility{type:READER_FIELD_MISSING_DEFAULT_VALUE, location:/fields/1, message:age, reader:{\"type\":\"record\"}, writer:{\"type\":\"record\"}}",
+					"context": "/fields/1"
+				},
+				{
+					"description": "Incompatibility{type:TYPE_MISMATCH, location:/fields/0/type, message:reader type: INT not compatible with writer type: STRING, reader:\"int\", writer:\"string\"}",
+					"context": "/fields/0/type"
+				}
+			]
+		}`
+
+		var apiErr models.APIError
+		assert.NoError(t, json.Unmarshal([]byte(payload), &apiErr))
+
+		result := apiErr.CompatibilityResult()
+		assert.NotNil(t, result)
+		assert.False(t, result.Compatible)
+		assert.Equal(t, []models.Incompatibility{
+			{Type: "READER_FIELD_MISSING_DEFAULT_VALUE", Path: "/fields/1", Message: "age"},
+			{Type: "TYPE_MISMATCH", Path: "/fields/0/type", Message: "reader type: INT not compatible with writer type: STRING"},
+		}, result.Incompatibilities)
+	})
+
+	t.Run("JSON Schema Incompatibilities", func(t *testing.T) {
+		causes := []models.RuleViolationCause{
+			{Description: "SUBSCHEMA_TYPE_CHANGED: A subschema type changed", Context: "#/properties/age"},
+			{Description: "A required property was added", Context: "#/required"},
+		}
+
+		result := models.NewCompatibilityResult(causes)
+		assert.False(t, result.Compatible)
+		assert.Equal(t, []models.Incompatibility{
+			{Type: "SUBSCHEMA_TYPE_CHANGED", Path: "#/properties/age", Message: "A subschema type changed"},
+			{Path: "#/required", Message: "A required property was added"},
+		}, result.Incompatibilities)
+	})
+
+	t.Run("No Causes", func(t *testing.T) {
+		result := models.NewCompatibilityResult(nil)
+		assert.True(t, result.Compatible)
+		assert.Empty(t, result.Incompatibilities)
+
+		apiErr := models.APIError{Status: 404}
+		assert.Nil(t, apiErr.CompatibilityResult())
+	})
+}
+
+func TestCreateArtifactResponse_UnmarshalJSON(t *testing.T) {
+	expected := models.ArtifactDetail{
+		GroupID:    "test-group",
+		ArtifactID: "artifact-1",
+		Name:       "Test Artifact",
+		CreatedOn:  "2024-12-01T10:00:00Z",
+		ContentID:  7,
+	}
+	artifact := `{
+		"groupId": "test-group",
+		"artifactId": "artifact-1",
+		"name": "Test Artifact",
+		"createdOn": "2024-12-01T10:00:00Z",
+		"contentId": 7
+	}`
+
+	for _, tc := range []struct {
+		name    string
+		payload string
+		version *models.ArtifactVersionMetadata
+	}{
+		{
+			name:    "Nested",
+			payload: `{"artifact": ` + artifact + `, "version": {"version": "1", "globalId": 42}}`,
+			version: &models.ArtifactVersionMetadata{Version: "1", GlobalID: 42},
+		},
+		{name: "Top Level", payload: artifact},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var response models.CreateArtifactResponse
+			assert.NoError(t, json.Unmarshal([]byte(tc.payload), &response))
+			assert.Equal(t, expected, response.Artifact)
+			assert.Equal(t, tc.version, response.Version)
+		})
+	}
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		var response models.CreateArtifactResponse
+		assert.Error(t, json.Unmarshal([]byte(`{"artifact": `), &response))
+	})
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+var semverPattern = regexp.MustCompile(
+	`^v?(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)` +
+		`(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`,
+)
+
+// Semver is a semantic version as defined by https://semver.org.
+type Semver struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string // Dot-separated pre-release identifiers, e.g. "rc.1"
+	Build      string // Dot-separated build metadata, e.g. "sha.5114f85"
+}
+
+// ParseSemver parses a version such as "1.4.2", "1.5.0-rc.1" or "v2.0.0+build.7". A leading "v" is accepted.
+// Versions that are not semantic versions fail with ErrNotSemver.
+func ParseSemver(version string) (Semver, error) {
+	m := semverPattern.FindStringSubmatch(version)
+	if m == nil {
+		return Semver{}, errors.Wrapf(ErrNotSemver, "version %q", version)
+	}
+
+	var v Semver
+	for i, part := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return Semver{}, errors.Wrapf(ErrNotSemver, "version %q: %v", version, err)
+		}
+		*part = n
+	}
+	v.Prerelease = m[4]
+	v.Build = m[5]
+	return v, nil
+}
+
+// SemverBump selects the part of a semantic version that is incremented.
+type SemverBump string
+
+const (
+	SemverMajor SemverBump = "MAJOR" // 1.4.2 becomes 2.0.0
+	SemverMinor SemverBump = "MINOR" // 1.4.2 becomes 1.5.0
+	SemverPatch SemverBump = "PATCH" // 1.4.2 becomes 1.4.3
+)
+
+// Bump returns the next release of the given kind. A pre-release is followed by its own release when that
+// is of the same kind, so 1.5.0-rc.1 becomes 1.5.0 for a minor bump but 2.0.0 for a major one. Build
+// metadata is dropped.
+func (v Semver) Bump(bump SemverBump) (Semver, error) {
+	next := Semver{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	prerelease := v.Prerelease != ""
+	switch bump {
+	case SemverMajor:
+		if !prerelease || v.Minor != 0 || v.Patch != 0 {
+			next.Major++
+		}
+		next.Minor, next.Patch = 0, 0
+	case SemverMinor:
+		if !prerelease || v.Patch != 0 {
+			next.Minor++
+		}
+		next.Patch = 0
+	case SemverPatch:
+		if !prerelease {
+			next.Patch++
+		}
+	default:
+		return Semver{}, errors.Errorf("unknown semver bump %q", bump)
+	}
+	return next, nil
+}
+
+// String formats the version without a leading "v".
+func (v Semver) String() string {
+	s := strconv.Itoa(v.Major) + "." + strconv.Itoa(v.Minor) + "." + strconv.Itoa(v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
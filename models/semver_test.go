@@ -0,0 +1,56 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSemver(t *testing.T) {
+	t.Run("Valid Versions", func(t *testing.T) {
+		v, err := models.ParseSemver("1.4.2")
+		assert.NoError(t, err)
+		assert.Equal(t, models.Semver{Major: 1, Minor: 4, Patch: 2}, v)
+
+		v, err = models.ParseSemver("v2.0.0-rc.1+build.7")
+		assert.NoError(t, err)
+		assert.Equal(t, models.Semver{Major: 2, Prerelease: "rc.1", Build: "build.7"}, v)
+		assert.Equal(t, "2.0.0-rc.1+build.7", v.String())
+	})
+
+	t.Run("Invalid Versions", func(t *testing.T) {
+		for _, version := range []string{"", "1", "1.0", "01.0.0", "1.0.0-", "latest", "20261016142530"} {
+			_, err := models.ParseSemver(version)
+			assert.True(t, errors.Is(err, models.ErrNotSemver), version)
+		}
+	})
+}
+
+func TestSemver_Bump(t *testing.T) {
+	tests := []struct {
+		version string
+		bump    models.SemverBump
+		want    string
+	}{
+		{"1.4.2", models.SemverPatch, "1.4.3"},
+		{"1.4.2", models.SemverMinor, "1.5.0"},
+		{"1.4.2+build.7", models.SemverMajor, "2.0.0"},
+		{"1.5.0-rc.1", models.SemverPatch, "1.5.0"},
+		{"1.5.0-rc.1", models.SemverMinor, "1.5.0"},
+		{"1.5.0-rc.1", models.SemverMajor, "2.0.0"},
+		{"2.0.0-rc.1", models.SemverMajor, "2.0.0"},
+		{"1.5.1-rc.1", models.SemverMinor, "1.6.0"},
+	}
+	for _, tt := range tests {
+		v, err := models.ParseSemver(tt.version)
+		assert.NoError(t, err)
+		next, err := v.Bump(tt.bump)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, next.String(), "%s %s", tt.bump, tt.version)
+	}
+
+	_, err := models.Semver{Major: 1}.Bump("BUILD")
+	assert.Error(t, err)
+}
@@ -0,0 +1,797 @@
+package serde
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/pkg/errors"
+)
+
+// avroSchema is a parsed Avro schema. References to named types point at the node of their definition.
+type avroSchema struct {
+	typ      string        // Primitive type name, "record", "enum", "fixed", "array", "map" or "union"
+	name     string        // Fullname of records, enums and fixed types
+	fields   []avroField   // Fields of a record
+	symbols  []string      // Symbols of an enum
+	size     int           // Size of a fixed type
+	items    *avroSchema   // Items of an array or values of a map
+	branches []*avroSchema // Branches of a union
+}
+
+type avroField struct {
+	name       string
+	schema     *avroSchema
+	def        interface{} // Default value as decoded from JSON
+	hasDefault bool
+}
+
+// parseAvroSchema validates schema with models.ValidateAvroSchema and parses it for encoding and decoding.
+func parseAvroSchema(schema string) (*avroSchema, error) {
+	if err := models.ValidateAvroSchema([]byte(schema)); err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(schema))
+	decoder.UseNumber()
+	var root interface{}
+	if err := decoder.Decode(&root); err != nil {
+		return nil, errors.Wrap(err, "failed to parse Avro schema")
+	}
+
+	p := avroParser{named: make(map[string]*avroSchema)}
+	return p.parse(root, ""), nil
+}
+
+type avroParser struct {
+	named map[string]*avroSchema
+}
+
+// parse builds the schema of a node of a schema document that passed models.ValidateAvroSchema.
+func (p *avroParser) parse(node interface{}, namespace string) *avroSchema {
+	switch n := node.(type) {
+	case string:
+		if avroPrimitive(n) {
+			return &avroSchema{typ: n}
+		}
+		if named, ok := p.named[avroFullname(n, namespace)]; ok {
+			return named
+		}
+		return p.named[n]
+
+	case []interface{}:
+		union := &avroSchema{typ: "union"}
+		for _, branch := range n {
+			union.branches = append(union.branches, p.parse(branch, namespace))
+		}
+		return union
+	}
+
+	s := node.(map[string]interface{})
+	typeName, ok := s["type"].(string)
+	if !ok {
+		return p.parse(s["type"], namespace)
+	}
+
+	switch typeName {
+	case "array":
+		return &avroSchema{typ: "array", items: p.parse(s["items"], namespace)}
+	case "map":
+		return &avroSchema{typ: "map", items: p.parse(s["values"], namespace)}
+	case "record", "error", "enum", "fixed":
+	default:
+		return p.parse(typeName, namespace)
+	}
+
+	name := s["name"].(string)
+	if ns, ok := s["namespace"].(string); ok && !strings.Contains(name, ".") {
+		namespace = ns
+	}
+	named := &avroSchema{typ: typeName, name: avroFullname(name, namespace)}
+	p.named[named.name] = named
+
+	switch typeName {
+	case "record", "error":
+		named.typ = "record"
+		if i := strings.LastIndex(named.name, "."); i >= 0 {
+			namespace = named.name[:i]
+		} else {
+			namespace = ""
+		}
+		for _, f := range s["fields"].([]interface{}) {
+			field := f.(map[string]interface{})
+			def, hasDefault := field["default"]
+			named.fields = append(named.fields, avroField{
+				name:       field["name"].(string),
+				schema:     p.parse(field["type"], namespace),
+				def:        def,
+				hasDefault: hasDefault,
+			})
+		}
+	case "enum":
+		for _, symbol := range s["symbols"].([]interface{}) {
+			named.symbols = append(named.symbols, symbol.(string))
+		}
+	case "fixed":
+		size, _ := s["size"].(json.Number).Int64()
+		named.size = int(size)
+	}
+	return named
+}
+
+func avroPrimitive(name string) bool {
+	switch name {
+	case "null", "boolean", "int", "long", "float", "double", "bytes", "string":
+		return true
+	}
+	return false
+}
+
+// avroFullname resolves a (possibly short) name against the enclosing namespace.
+func avroFullname(name, namespace string) string {
+	if strings.Contains(name, ".") || namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// encode appends the Avro binary encoding of v to buf.
+//
+// Records are encoded from structs, whose fields are matched by their `avro` tag or else by name, or from maps
+// with string keys. Missing fields take their schema default. Values are matched to union branches in order:
+// nil selects null and otherwise the first branch that can hold the value is used. Integer types may be
+// written as float and double, and floats without a fractional part as int and long.
+func (s *avroSchema) encode(buf []byte, v reflect.Value) ([]byte, error) {
+	v = indirect(v)
+	if s.typ == "union" {
+		for i, branch := range s.branches {
+			if branch.accepts(v) {
+				return branch.encode(appendLong(buf, int64(i)), v)
+			}
+		}
+		return nil, errors.Errorf("%s matches no branch of the union", describe(v))
+	}
+	if s.typ == "null" {
+		if v.IsValid() {
+			return nil, errors.Errorf("expected nil, got %s", describe(v))
+		}
+		return buf, nil
+	}
+	if !v.IsValid() {
+		return nil, errors.Errorf("expected %s, got nil", s.describe())
+	}
+
+	switch s.typ {
+	case "boolean":
+		if v.Kind() == reflect.Bool {
+			if v.Bool() {
+				return append(buf, 1), nil
+			}
+			return append(buf, 0), nil
+		}
+	case "int", "long":
+		if n, ok := intValue(v); ok {
+			if s.typ == "int" && (n < math.MinInt32 || n > math.MaxInt32) {
+				return nil, errors.Errorf("%d overflows int", n)
+			}
+			return appendLong(buf, n), nil
+		}
+	case "float":
+		if f, ok := floatValue(v); ok {
+			return binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(f))), nil
+		}
+	case "double":
+		if f, ok := floatValue(v); ok {
+			return binary.LittleEndian.AppendUint64(buf, math.Float64bits(f)), nil
+		}
+	case "string":
+		if v.Kind() == reflect.String {
+			return appendBytes(buf, []byte(v.String())), nil
+		}
+	case "bytes":
+		if b, ok := bytesValue(v); ok {
+			return appendBytes(buf, b), nil
+		}
+	case "fixed":
+		if b, ok := bytesValue(v); ok {
+			if len(b) != s.size {
+				return nil, errors.Errorf("fixed %s holds %d bytes, got %d", s.name, s.size, len(b))
+			}
+			return append(buf, b...), nil
+		}
+	case "enum":
+		if v.Kind() == reflect.String {
+			for i, symbol := range s.symbols {
+				if symbol == v.String() {
+					return appendLong(buf, int64(i)), nil
+				}
+			}
+			return nil, errors.Errorf("%q is not a symbol of enum %s", v.String(), s.name)
+		}
+	case "array":
+		if isList(v) {
+			return s.encodeArray(buf, v)
+		}
+	case "map":
+		if v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String {
+			return s.encodeMap(buf, v)
+		}
+	case "record":
+		switch {
+		case v.Kind() == reflect.Struct:
+			return s.encodeStruct(buf, v)
+		case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+			return s.encodeRecordMap(buf, v)
+		}
+	}
+	return nil, errors.Errorf("expected %s, got %s", s.describe(), describe(v))
+}
+
+func (s *avroSchema) encodeArray(buf []byte, v reflect.Value) ([]byte, error) {
+	if v.Len() > 0 {
+		buf = appendLong(buf, int64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			var err error
+			if buf, err = s.items.encode(buf, v.Index(i)); err != nil {
+				return nil, errors.Wrapf(err, "item %d", i)
+			}
+		}
+	}
+	return appendLong(buf, 0), nil
+}
+
+func (s *avroSchema) encodeMap(buf []byte, v reflect.Value) ([]byte, error) {
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	if len(keys) > 0 {
+		buf = appendLong(buf, int64(len(keys)))
+		for _, key := range keys {
+			buf = appendBytes(buf, []byte(key.String()))
+			var err error
+			if buf, err = s.items.encode(buf, v.MapIndex(key)); err != nil {
+				return nil, errors.Wrapf(err, "value %q", key.String())
+			}
+		}
+	}
+	return appendLong(buf, 0), nil
+}
+
+func (s *avroSchema) encodeStruct(buf []byte, v reflect.Value) ([]byte, error) {
+	index := structFields(v.Type())
+	for _, field := range s.fields {
+		var value reflect.Value
+		i, found := index.lookup(field.name)
+		if found {
+			value = v.Field(i)
+		}
+		var err error
+		if buf, err = field.encode(buf, value, found, s.name); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+func (s *avroSchema) encodeRecordMap(buf []byte, v reflect.Value) ([]byte, error) {
+	for _, field := range s.fields {
+		value := v.MapIndex(reflect.ValueOf(field.name).Convert(v.Type().Key()))
+		var err error
+		if buf, err = field.encode(buf, value, value.IsValid(), s.name); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// encode writes the field value, or its default when the value is missing.
+func (f avroField) encode(buf []byte, v reflect.Value, found bool, record string) ([]byte, error) {
+	if !found {
+		if !f.hasDefault {
+			return nil, errors.Errorf("record %s: field %s is missing and has no default", record, f.name)
+		}
+		out, err := f.schema.encodeDefault(buf, f.def)
+		return out, errors.Wrapf(err, "record %s: default of field %s", record, f.name)
+	}
+	out, err := f.schema.encode(buf, v)
+	return out, errors.Wrapf(err, "record %s: field %s", record, f.name)
+}
+
+// encodeDefault writes a default value as decoded from the schema document. Defaults of unions belong to
+// their first branch, and defaults of bytes and fixed types are strings of code points 0-255.
+func (s *avroSchema) encodeDefault(buf []byte, def interface{}) ([]byte, error) {
+	switch s.typ {
+	case "union":
+		return s.branches[0].encodeDefault(appendLong(buf, 0), def)
+	case "bytes", "fixed":
+		if text, ok := def.(string); ok {
+			b := make([]byte, 0, len(text))
+			for _, r := range text {
+				b = append(b, byte(r))
+			}
+			def = b
+		}
+	}
+	return s.encode(buf, reflect.ValueOf(def))
+}
+
+// accepts reports whether v can be written as a value of the schema, to pick a union branch.
+func (s *avroSchema) accepts(v reflect.Value) bool {
+	if s.typ == "null" || !v.IsValid() {
+		return s.typ == "null" && !v.IsValid()
+	}
+
+	switch s.typ {
+	case "boolean":
+		return v.Kind() == reflect.Bool
+	case "int", "long":
+		n, ok := intValue(v)
+		return ok && isInteger(v) && (s.typ == "long" || (n >= math.MinInt32 && n <= math.MaxInt32))
+	case "float", "double":
+		_, ok := floatValue(v)
+		return ok
+	case "string":
+		return v.Kind() == reflect.String && v.Type() != jsonNumberType
+	case "bytes":
+		_, ok := bytesValue(v)
+		return ok
+	case "fixed":
+		b, ok := bytesValue(v)
+		return ok && len(b) == s.size
+	case "enum":
+		if v.Kind() != reflect.String {
+			return false
+		}
+		for _, symbol := range s.symbols {
+			if symbol == v.String() {
+				return true
+			}
+		}
+		return false
+	case "array":
+		return isList(v)
+	case "map":
+		return v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String
+	case "record":
+		return v.Kind() == reflect.Struct || (v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String)
+	}
+	return false
+}
+
+// describe names the schema for error messages.
+func (s *avroSchema) describe() string {
+	if s.name != "" {
+		return s.typ + " " + s.name
+	}
+	return s.typ
+}
+
+var (
+	jsonNumberType = reflect.TypeOf(json.Number(""))
+	byteType       = reflect.TypeOf(byte(0))
+)
+
+// indirect follows pointers and interfaces, returning the zero Value for nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func describe(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return v.Type().String()
+}
+
+func isInteger(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	if v.Type() == jsonNumberType {
+		_, err := json.Number(v.String()).Int64()
+		return err == nil
+	}
+	return false
+}
+
+func intValue(v reflect.Value) (int64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(f), true
+	}
+	if v.Type() == jsonNumberType {
+		n, err := json.Number(v.String()).Int64()
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func floatValue(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	}
+	if v.Type() == jsonNumberType {
+		f, err := json.Number(v.String()).Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// bytesValue returns the content of a byte slice or byte array.
+func bytesValue(v reflect.Value) ([]byte, bool) {
+	switch {
+	case v.Kind() == reflect.Slice && v.Type().Elem() == byteType:
+		return v.Bytes(), true
+	case v.Kind() == reflect.Array && v.Type().Elem() == byteType:
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return b, true
+	}
+	return nil, false
+}
+
+// isList reports whether v is a slice or array other than bytes.
+func isList(v reflect.Value) bool {
+	return (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem() != byteType
+}
+
+// fieldIndex maps Avro field names to the indexes of struct fields.
+type fieldIndex struct {
+	exact  map[string]int
+	folded map[string]int
+}
+
+// structFields indexes the exported fields of a struct type by their `avro` tag, or else by their name.
+// Fields tagged `avro:"-"` are skipped.
+func structFields(t reflect.Type) fieldIndex {
+	index := fieldIndex{exact: make(map[string]int), folded: make(map[string]int)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("avro"); ok {
+			if tag == "-" {
+				continue
+			}
+			name = tag
+		}
+		index.exact[name] = i
+		if _, found := index.folded[strings.ToLower(name)]; !found {
+			index.folded[strings.ToLower(name)] = i
+		}
+	}
+	return index
+}
+
+// lookup finds the struct field for an Avro field name, falling back to a case-insensitive match.
+func (f fieldIndex) lookup(name string) (int, bool) {
+	if i, ok := f.exact[name]; ok {
+		return i, true
+	}
+	i, ok := f.folded[strings.ToLower(name)]
+	return i, ok
+}
+
+// appendLong appends n as a zig-zag encoded variable-length integer.
+func appendLong(buf []byte, n int64) []byte {
+	return binary.AppendUvarint(buf, uint64((n<<1)^(n>>63)))
+}
+
+func appendBytes(buf, b []byte) []byte {
+	return append(appendLong(buf, int64(len(b))), b...)
+}
+
+// avroReader reads Avro binary data.
+type avroReader struct {
+	buf []byte
+	pos int
+}
+
+var errShortBuffer = errors.New("unexpected end of data")
+
+func (r *avroReader) long() (int64, error) {
+	u, n := binary.Uvarint(r.buf[r.pos:])
+	if n <= 0 {
+		return 0, errors.Wrap(errShortBuffer, "invalid variable-length integer")
+	}
+	r.pos += n
+	return int64(u>>1) ^ -int64(u&1), nil
+}
+
+func (r *avroReader) next(n int) ([]byte, error) {
+	if n < 0 || n > len(r.buf)-r.pos {
+		return nil, errShortBuffer
+	}
+	b := r.buf[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *avroReader) bytes() ([]byte, error) {
+	n, err := r.long()
+	if err != nil {
+		return nil, err
+	}
+	if n > int64(len(r.buf)-r.pos) {
+		return nil, errShortBuffer
+	}
+	return r.next(int(n))
+}
+
+// decode reads a value of the schema as a generic value: nil, bool, int32, int64, float32, float64, string,
+// []byte, []any or map[string]any. Enums decode to their symbol and unions to the value of their branch.
+func (s *avroSchema) decode(r *avroReader) (interface{}, error) {
+	switch s.typ {
+	case "null":
+		return nil, nil
+	case "boolean":
+		b, err := r.next(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0] != 0, nil
+	case "int":
+		n, err := r.long()
+		if err != nil {
+			return nil, err
+		}
+		if n < math.MinInt32 || n > math.MaxInt32 {
+			return nil, errors.Errorf("%d overflows int", n)
+		}
+		return int32(n), nil
+	case "long":
+		return r.long()
+	case "float":
+		b, err := r.next(4)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float32frombits(binary.LittleEndian.Uint32(b)), nil
+	case "double":
+		b, err := r.next(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case "string":
+		b, err := r.bytes()
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case "bytes":
+		b, err := r.bytes()
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case "fixed":
+		b, err := r.next(s.size)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case "enum":
+		i, err := r.long()
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || i >= int64(len(s.symbols)) {
+			return nil, errors.Errorf("enum %s has no symbol %d", s.name, i)
+		}
+		return s.symbols[i], nil
+	case "union":
+		i, err := r.long()
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || i >= int64(len(s.branches)) {
+			return nil, errors.Errorf("union has no branch %d", i)
+		}
+		return s.branches[i].decode(r)
+	case "array":
+		items := []interface{}{}
+		err := s.decodeBlocks(r, func() error {
+			item, err := s.items.decode(r)
+			if err != nil {
+				return errors.Wrapf(err, "item %d", len(items))
+			}
+			items = append(items, item)
+			return nil
+		})
+		return items, err
+	case "map":
+		values := map[string]interface{}{}
+		err := s.decodeBlocks(r, func() error {
+			key, err := r.bytes()
+			if err != nil {
+				return err
+			}
+			value, err := s.items.decode(r)
+			if err != nil {
+				return errors.Wrapf(err, "value %q", key)
+			}
+			values[string(key)] = value
+			return nil
+		})
+		return values, err
+	}
+
+	record := make(map[string]interface{}, len(s.fields))
+	for _, field := range s.fields {
+		value, err := field.schema.decode(r)
+		if err != nil {
+			return nil, errors.Wrapf(err, "record %s: field %s", s.name, field.name)
+		}
+		record[field.name] = value
+	}
+	return record, nil
+}
+
+// decodeBlocks reads the blocks of an array or map, calling item for every item.
+func (s *avroSchema) decodeBlocks(r *avroReader, item func() error) error {
+	for {
+		n, err := r.long()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return nil
+		}
+		if n < 0 {
+			// A negative count is followed by the size of the block in bytes.
+			if _, err := r.long(); err != nil {
+				return err
+			}
+			n = -n
+		}
+		for ; n > 0; n-- {
+			if err := item(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// assign stores a generic value produced by decode in dst. Records and maps fill structs, whose fields are
+// matched as for encoding, and maps with string keys; numbers convert to any numeric type they fit in.
+func assign(dst reflect.Value, value interface{}) error {
+	if dst.Kind() == reflect.Interface {
+		if value == nil {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		v := reflect.ValueOf(value)
+		if !v.Type().AssignableTo(dst.Type()) {
+			return errors.Errorf("cannot store %s in %s", v.Type(), dst.Type())
+		}
+		dst.Set(v)
+		return nil
+	}
+	if dst.Kind() == reflect.Pointer {
+		if value == nil {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return assign(dst.Elem(), value)
+	}
+	if value == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch dst.Kind() {
+	case reflect.Bool:
+		if b, ok := value.(bool); ok {
+			dst.SetBool(b)
+			return nil
+		}
+	case reflect.String:
+		if text, ok := value.(string); ok {
+			dst.SetString(text)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := intValue(v); ok && isInteger(v) {
+			if dst.OverflowInt(n) {
+				return errors.Errorf("%d overflows %s", n, dst.Type())
+			}
+			dst.SetInt(n)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n, ok := intValue(v); ok && isInteger(v) {
+			if n < 0 || dst.OverflowUint(uint64(n)) {
+				return errors.Errorf("%d overflows %s", n, dst.Type())
+			}
+			dst.SetUint(uint64(n))
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, ok := floatValue(v); ok {
+			dst.SetFloat(f)
+			return nil
+		}
+	case reflect.Slice:
+		if b, ok := value.([]byte); ok && dst.Type().Elem() == byteType {
+			dst.SetBytes(b)
+			return nil
+		}
+		if items, ok := value.([]interface{}); ok {
+			slice := reflect.MakeSlice(dst.Type(), len(items), len(items))
+			for i, item := range items {
+				if err := assign(slice.Index(i), item); err != nil {
+					return errors.Wrapf(err, "item %d", i)
+				}
+			}
+			dst.Set(slice)
+			return nil
+		}
+	case reflect.Array:
+		if b, ok := value.([]byte); ok && dst.Type().Elem() == byteType && len(b) == dst.Len() {
+			reflect.Copy(dst, reflect.ValueOf(b))
+			return nil
+		}
+	case reflect.Map:
+		if values, ok := value.(map[string]interface{}); ok && dst.Type().Key().Kind() == reflect.String {
+			m := reflect.MakeMapWithSize(dst.Type(), len(values))
+			for key, item := range values {
+				elem := reflect.New(dst.Type().Elem()).Elem()
+				if err := assign(elem, item); err != nil {
+					return errors.Wrapf(err, "value %q", key)
+				}
+				m.SetMapIndex(reflect.ValueOf(key).Convert(dst.Type().Key()), elem)
+			}
+			dst.Set(m)
+			return nil
+		}
+	case reflect.Struct:
+		if values, ok := value.(map[string]interface{}); ok {
+			index := structFields(dst.Type())
+			for name, item := range values {
+				i, found := index.lookup(name)
+				if !found {
+					continue
+				}
+				if err := assign(dst.Field(i), item); err != nil {
+					return errors.Wrapf(err, "field %s", name)
+				}
+			}
+			return nil
+		}
+	}
+	return errors.Errorf("cannot store %s in %s", v.Type(), dst.Type())
+}
@@ -0,0 +1,91 @@
+package serde
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+
+	"github.com/mollie/go-apicurio-registry/apis"
+	"github.com/mollie/go-apicurio-registry/internal/lru"
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownMagicByte is returned for messages that do not start with the magic byte of the Confluent wire
+// format, such as raw Avro payloads or messages written by another serializer.
+var ErrUnknownMagicByte = fmt.Errorf("unknown magic byte: message is not in the Confluent wire format")
+
+// AvroDeserializer decodes messages in the Confluent wire format written by AvroSerializer, whose schema ID is
+// the global ID of the writer schema. Writer schemas are looked up through an apis.SchemaResolver, which caches
+// them. Messages that carry content IDs, as written by the Confluent serializers through the registry's
+// Confluent compatibility API, are not supported. It is safe for concurrent use.
+type AvroDeserializer struct {
+	resolver *apis.SchemaResolver
+	parsed   *lru.Cache[string, *avroSchema] // Parsed writer schema by schema content
+}
+
+// NewAvroDeserializer creates a deserializer that looks up writer schemas with ResolveByGlobalID of resolver.
+// The resolver may be shared with other code looking up the same schemas.
+func NewAvroDeserializer(resolver *apis.SchemaResolver) *AvroDeserializer {
+	return &AvroDeserializer{
+		resolver: resolver,
+		parsed:   lru.New[string, *avroSchema](apis.DefaultSchemaCacheSize),
+	}
+}
+
+// Deserialize decodes a message of topic into target, which must be a non-nil pointer. Records decode into
+// structs, whose fields are matched by their `avro` tag or else by name, and into maps with string keys; a
+// target of type *any receives generic values (map[string]any for records, []any for arrays, int32 and int64
+// for int and long, and so on). A message without the leading magic byte fails with ErrUnknownMagicByte.
+func (d *AvroDeserializer) Deserialize(ctx context.Context, topic string, data []byte, target any) error {
+	dst := reflect.ValueOf(target)
+	if dst.Kind() != reflect.Pointer || dst.IsNil() {
+		return errors.Errorf("target must be a non-nil pointer, got %T", target)
+	}
+	if len(data) == 0 || data[0] != magicByte {
+		if len(data) == 0 {
+			return errors.Wrap(ErrUnknownMagicByte, "empty message")
+		}
+		return errors.Wrapf(ErrUnknownMagicByte, "got 0x%02x", data[0])
+	}
+	if len(data) < headerSize {
+		return errors.Errorf("message of %d bytes is shorter than the wire format header", len(data))
+	}
+
+	id := binary.BigEndian.Uint32(data[1:headerSize])
+	schema, err := d.schema(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	r := &avroReader{buf: data[headerSize:]}
+	value, err := schema.decode(r)
+	if err != nil {
+		return errors.Wrapf(err, "failed to decode message of topic %s with schema %d", topic, id)
+	}
+	if r.pos != len(r.buf) {
+		return errors.Errorf("failed to decode message of topic %s with schema %d: %d trailing bytes", topic, id, len(r.buf)-r.pos)
+	}
+	if err := assign(dst.Elem(), value); err != nil {
+		return errors.Wrapf(err, "failed to store message of topic %s", topic)
+	}
+	return nil
+}
+
+// schema returns the writer schema with the given ID, parsing its content unless it was parsed before.
+func (d *AvroDeserializer) schema(ctx context.Context, id uint32) (*avroSchema, error) {
+	content, err := d.resolver.ResolveByGlobalID(ctx, int64(id))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch schema %d", id)
+	}
+	if schema, ok := d.parsed.Get(content.Content); ok {
+		return schema, nil
+	}
+
+	schema, err := parseAvroSchema(content.Content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid writer schema %d", id)
+	}
+	d.parsed.Add(content.Content, schema)
+	return schema, nil
+}
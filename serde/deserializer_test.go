@@ -0,0 +1,180 @@
+package serde_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/mollie/go-apicurio-registry/apis"
+	"github.com/mollie/go-apicurio-registry/client"
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/mollie/go-apicurio-registry/serde"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSchemaServer serves schemas by global ID and counts the fetches. Unknown IDs are answered with 404, and
+// searches for them find no version.
+func newSchemaServer(t *testing.T, schemas map[int64]string, fetches *int) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /ids/globalIds/{id}", func(w http.ResponseWriter, r *http.Request) {
+		*fetches++
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		assert.NoError(t, err)
+		schema, ok := schemas[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status": 404, "title": "No artifact with ID '` + r.PathValue("id") + `' was found."}`))
+			return
+		}
+		w.Header().Set("X-Registry-ArtifactType", string(models.Avro))
+		_, _ = w.Write([]byte(schema))
+	})
+	mux.HandleFunc("GET /search/versions", func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.URL.Query().Get("globalId"), 10, 64)
+		assert.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		if _, ok := schemas[id]; !ok {
+			_, _ = w.Write([]byte(`{"count": 0, "versions": []}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"count": 1, "versions": [{"artifactId": "orders", "version": "1", "globalId": %d, "state": "ENABLED"}]}`, id)
+	})
+	return httptest.NewServer(mux)
+}
+
+func newDeserializer(server *httptest.Server) *serde.AvroDeserializer {
+	c := client.NewClient(server.URL, client.WithHTTPClient(server.Client()))
+	return serde.NewAvroDeserializer(apis.NewSchemaResolver(apis.NewArtifactsAPI(c), 0))
+}
+
+// frame prefixes payload with the Confluent wire format header for the schema ID.
+func frame(id byte, payload []byte) []byte {
+	return append([]byte{0, 0, 0, 0, id}, payload...)
+}
+
+func TestAvroDeserializer_Deserialize(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var fetches int
+		server := newSchemaServer(t, map[int64]string{42: orderSchema}, &fetches)
+		defer server.Close()
+
+		deserializer := newDeserializer(server)
+		message := frame(42, orderPayload)
+
+		var decoded order
+		assert.NoError(t, deserializer.Deserialize(context.Background(), "orders", message, &decoded))
+		assert.Equal(t, order{ID: "order-1", Amount: 1250}, decoded)
+
+		var generic map[string]any
+		assert.NoError(t, deserializer.Deserialize(context.Background(), "orders", message, &generic))
+		assert.Equal(t, map[string]any{"id": "order-1", "amount": int64(1250), "note": nil}, generic)
+
+		var value any
+		assert.NoError(t, deserializer.Deserialize(context.Background(), "orders", message, &value))
+		assert.Equal(t, generic, value)
+		assert.Equal(t, 1, fetches, "the schema is cached by ID")
+	})
+
+	t.Run("Round Trip", func(t *testing.T) {
+		type line struct {
+			SKU      string
+			Quantity int `avro:"qty"`
+		}
+		type invoice struct {
+			Number string
+			Status string
+			Lines  []line
+			Totals map[string]float64
+			Hash   [2]byte
+			Paid   *bool
+		}
+		schema := `{"type": "record", "name": "Invoice", "fields": [
+			{"name": "number", "type": "string"},
+			{"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["OPEN", "PAID"]}},
+			{"name": "lines", "type": {"type": "array", "items": {"type": "record", "name": "Line", "fields": [
+				{"name": "sku", "type": "string"},
+				{"name": "qty", "type": "int"}
+			]}}},
+			{"name": "totals", "type": {"type": "map", "values": "double"}},
+			{"name": "hash", "type": {"type": "fixed", "name": "Hash", "size": 2}},
+			{"name": "paid", "type": ["null", "boolean"]}
+		]}`
+
+		var registrations, fetches int
+		registry := newRegistry(t, 3, &registrations)
+		defer registry.Close()
+		schemas := newSchemaServer(t, map[int64]string{3: schema}, &fetches)
+		defer schemas.Close()
+
+		serializer, err := serde.NewAvroSerializer(newResolver(registry), schema)
+		assert.NoError(t, err)
+
+		paid := true
+		original := invoice{
+			Number: "INV-1",
+			Status: "PAID",
+			Lines:  []line{{SKU: "a", Quantity: 2}, {SKU: "b", Quantity: -1}},
+			Totals: map[string]float64{"net": 10.5, "vat": 2.2},
+			Hash:   [2]byte{1, 2},
+			Paid:   &paid,
+		}
+		message, err := serializer.Serialize(context.Background(), "invoices", original)
+		assert.NoError(t, err)
+
+		var decoded invoice
+		assert.NoError(t, newDeserializer(schemas).Deserialize(context.Background(), "invoices", message, &decoded))
+		assert.Equal(t, original, decoded)
+	})
+
+	t.Run("Unknown Schema ID", func(t *testing.T) {
+		var fetches int
+		server := newSchemaServer(t, map[int64]string{42: orderSchema}, &fetches)
+		defer server.Close()
+
+		deserializer := newDeserializer(server)
+		var decoded order
+		for i := 1; i <= 2; i++ {
+			err := deserializer.Deserialize(context.Background(), "orders", frame(99, orderPayload), &decoded)
+			assert.ErrorContains(t, err, "failed to fetch schema 99")
+			var apiErr *models.APIError
+			if assert.ErrorAs(t, err, &apiErr) {
+				assert.Equal(t, http.StatusNotFound, apiErr.Status)
+			}
+			assert.Equal(t, i, fetches, "a failed fetch is not cached")
+		}
+	})
+
+	t.Run("Unknown Magic Byte", func(t *testing.T) {
+		var fetches int
+		server := newSchemaServer(t, nil, &fetches)
+		defer server.Close()
+
+		deserializer := newDeserializer(server)
+		var decoded order
+		err := deserializer.Deserialize(context.Background(), "orders", orderPayload, &decoded)
+		assert.ErrorIs(t, err, serde.ErrUnknownMagicByte)
+		assert.ErrorContains(t, err, "got 0x0e")
+		assert.ErrorIs(t, deserializer.Deserialize(context.Background(), "orders", nil, &decoded), serde.ErrUnknownMagicByte)
+		assert.Equal(t, 0, fetches)
+	})
+
+	t.Run("Malformed Payload", func(t *testing.T) {
+		var fetches int
+		server := newSchemaServer(t, map[int64]string{42: orderSchema}, &fetches)
+		defer server.Close()
+
+		deserializer := newDeserializer(server)
+		var decoded order
+		err := deserializer.Deserialize(context.Background(), "orders", frame(42, orderPayload[:4]), &decoded)
+		assert.ErrorContains(t, err, "record com.example.Order: field id: unexpected end of data")
+
+		err = deserializer.Deserialize(context.Background(), "orders", frame(42, append(orderPayload, 0)), &decoded)
+		assert.ErrorContains(t, err, "1 trailing bytes")
+
+		assert.ErrorContains(t, deserializer.Deserialize(context.Background(), "orders", frame(42, orderPayload), decoded),
+			"target must be a non-nil pointer")
+	})
+}
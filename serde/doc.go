@@ -0,0 +1,25 @@
+// Package serde provides Kafka message serializers that are wire-compatible with the Confluent Schema Registry
+// serializers, backed by the Apicurio Registry.
+//
+// Messages use the Confluent framing: a zero magic byte, the 4-byte big-endian ID of the writer schema and
+// the encoded payload. Schemas are registered as artifacts of the registry, one artifact per subject, and
+// identified by the global ID of their artifact version.
+//
+// Example Usage:
+//
+//	c := client.NewClient("https://registry.example.com/apis/registry/v3")
+//	serializer, err := serde.NewAvroSerializer(
+//		serde.NewRegistryResolver(apis.NewArtifactsAPI(c), serde.DefaultGroupID),
+//		`{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "string"}]}`,
+//	)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	message, err := serializer.Serialize(ctx, "orders", map[string]any{"id": "order-1"})
+//
+// Consumers decode such messages with an AvroDeserializer, which looks up the writer schema by its ID:
+//
+//	deserializer := serde.NewAvroDeserializer(apis.NewSchemaResolver(apis.NewArtifactsAPI(c), 0))
+//	var order map[string]any
+//	err = deserializer.Deserialize(ctx, "orders", message, &order)
+package serde
@@ -0,0 +1,135 @@
+package serde
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"sync"
+
+	"github.com/mollie/go-apicurio-registry/apis"
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/pkg/errors"
+)
+
+// DefaultGroupID is the registry group that holds artifacts registered without a group.
+const DefaultGroupID = "default"
+
+// magicByte starts every message in the Confluent wire format.
+const magicByte = 0
+
+// headerSize is the length of the magic byte and the schema ID that precede the payload.
+const headerSize = 5
+
+// SchemaIDResolver looks up the IDs of writer schemas.
+type SchemaIDResolver interface {
+	// ResolveSchemaID returns the global ID of schema under subject, registering the schema when the subject
+	// has no version with that content yet.
+	ResolveSchemaID(ctx context.Context, subject, schema string) (int64, error)
+}
+
+// RegistryResolver is a SchemaIDResolver that registers schemas as Avro artifacts of a registry group,
+// using the subject as the artifact ID.
+type RegistryResolver struct {
+	Artifacts *apis.ArtifactsAPI
+	GroupID   string
+}
+
+// NewRegistryResolver creates a RegistryResolver for the given group; an empty group means DefaultGroupID.
+func NewRegistryResolver(artifacts *apis.ArtifactsAPI, groupID string) *RegistryResolver {
+	if groupID == "" {
+		groupID = DefaultGroupID
+	}
+	return &RegistryResolver{Artifacts: artifacts, GroupID: groupID}
+}
+
+// ResolveSchemaID creates the artifact of the subject, or finds or creates the version of an existing artifact
+// holding the schema, and returns the global ID of that version as reported in the create response.
+func (r *RegistryResolver) ResolveSchemaID(ctx context.Context, subject, schema string) (int64, error) {
+	response, err := r.Artifacts.CreateArtifactDetailed(ctx, r.GroupID, models.CreateArtifactRequest{
+		ArtifactID:   subject,
+		ArtifactType: models.Avro,
+		FirstVersion: models.CreateVersionRequest{
+			Content: models.CreateContentRequest{Content: schema, ContentType: "application/json"},
+		},
+	}, &models.CreateArtifactParams{IfExists: models.IfExistsFindOrCreateVersion})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to register schema of subject %s", subject)
+	}
+	if response.Version == nil || response.Version.GlobalID == 0 {
+		return 0, errors.Errorf("registry did not report the version registered for subject %s", subject)
+	}
+	return response.Version.GlobalID, nil
+}
+
+// AvroSerializer encodes values with a single Avro schema and frames them in the Confluent wire format.
+// The schema ID is resolved once per subject and cached. It is safe for concurrent use.
+type AvroSerializer struct {
+	resolver SchemaIDResolver
+	schema   string
+	parsed   *avroSchema
+
+	mu  sync.Mutex
+	ids map[string]uint32 // Schema ID by subject
+}
+
+// NewAvroSerializer creates a serializer for the given writer schema, which is checked with
+// models.ValidateAvroSchema.
+func NewAvroSerializer(resolver SchemaIDResolver, schema string) (*AvroSerializer, error) {
+	parsed, err := parseAvroSchema(schema)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid Avro schema provided")
+	}
+	return &AvroSerializer{
+		resolver: resolver,
+		schema:   schema,
+		parsed:   parsed,
+		ids:      make(map[string]uint32),
+	}, nil
+}
+
+// Serialize encodes value for topic. The schema is registered under the subject "<topic>-value", following
+// the Confluent TopicNameStrategy. The message is a zero magic byte, the 4-byte big-endian schema ID and the
+// Avro binary encoding of value.
+//
+// Records are encoded from structs, whose fields are matched by their `avro` tag or else by name, or from
+// maps with string keys; fields missing from the value take their schema default. In unions, nil selects
+// null and any other value the first branch that can hold it.
+func (s *AvroSerializer) Serialize(ctx context.Context, topic string, value any) ([]byte, error) {
+	id, err := s.schemaID(ctx, topic+"-value")
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, headerSize, 64)
+	buf[0] = magicByte
+	binary.BigEndian.PutUint32(buf[1:headerSize], id)
+	buf, err = s.parsed.encode(buf, reflect.ValueOf(value))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to encode value for topic %s", topic)
+	}
+	return buf, nil
+}
+
+// schemaID returns the cached schema ID of subject, resolving it on first use.
+func (s *AvroSerializer) schemaID(ctx context.Context, subject string) (uint32, error) {
+	s.mu.Lock()
+	id, ok := s.ids[subject]
+	s.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	globalID, err := s.resolver.ResolveSchemaID(ctx, subject, s.schema)
+	if err != nil {
+		return 0, err
+	}
+	if globalID < 0 || globalID > math.MaxInt32 {
+		return 0, errors.Errorf("schema ID %d of subject %s does not fit the 4-byte wire format", globalID, subject)
+	}
+
+	s.mu.Lock()
+	s.ids[subject] = uint32(globalID)
+	s.mu.Unlock()
+	return uint32(globalID), nil
+}
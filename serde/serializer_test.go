@@ -0,0 +1,194 @@
+package serde_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mollie/go-apicurio-registry/apis"
+	"github.com/mollie/go-apicurio-registry/client"
+	"github.com/mollie/go-apicurio-registry/models"
+	"github.com/mollie/go-apicurio-registry/serde"
+	"github.com/stretchr/testify/assert"
+)
+
+const orderSchema = `{
+	"type": "record",
+	"name": "Order",
+	"namespace": "com.example",
+	"fields": [
+		{"name": "id", "type": "string"},
+		{"name": "amount", "type": "long"},
+		{"name": "note", "type": ["null", "string"], "default": null}
+	]
+}`
+
+// orderPayload is the Avro encoding of {"id": "order-1", "amount": 1250, "note": null}.
+var orderPayload = []byte{0x0e, 'o', 'r', 'd', 'e', 'r', '-', '1', 0xc4, 0x13, 0x00}
+
+type order struct {
+	ID     string  `avro:"id"`
+	Amount int64   `avro:"amount"`
+	Note   *string `avro:"note"`
+}
+
+// newRegistry serves the endpoints used by RegistryResolver, registering every schema as version 1 with the
+// given global ID. It counts the registrations.
+func newRegistry(t *testing.T, globalID int64, registrations *int) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /groups/{group}/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		*registrations++
+		assert.Equal(t, serde.DefaultGroupID, r.PathValue("group"))
+		assert.Equal(t, string(models.IfExistsFindOrCreateVersion), r.URL.Query().Get("ifExists"))
+
+		var request models.CreateArtifactRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, models.Avro, request.ArtifactType)
+		assert.NoError(t, json.NewEncoder(w).Encode(models.CreateArtifactResponse{
+			Artifact: models.ArtifactDetail{GroupID: serde.DefaultGroupID, ArtifactID: request.ArtifactID},
+			Version: &models.ArtifactVersionMetadata{
+				BaseMetadata: models.BaseMetadata{
+					GroupID:      serde.DefaultGroupID,
+					ArtifactID:   request.ArtifactID,
+					ArtifactType: string(models.Avro),
+				},
+				Version:  "1",
+				GlobalID: globalID,
+			},
+		}))
+	})
+	return httptest.NewServer(mux)
+}
+
+func newResolver(server *httptest.Server) *serde.RegistryResolver {
+	c := client.NewClient(server.URL, client.WithHTTPClient(server.Client()))
+	return serde.NewRegistryResolver(apis.NewArtifactsAPI(c), "")
+}
+
+func TestAvroSerializer_Serialize(t *testing.T) {
+	t.Run("Confluent Framing", func(t *testing.T) {
+		var registrations int
+		server := newRegistry(t, 42, &registrations)
+		defer server.Close()
+
+		serializer, err := serde.NewAvroSerializer(newResolver(server), orderSchema)
+		assert.NoError(t, err)
+
+		message, err := serializer.Serialize(context.Background(), "orders", map[string]any{"id": "order-1", "amount": 1250})
+		assert.NoError(t, err)
+		if assert.Len(t, message, 5+len(orderPayload)) {
+			assert.Equal(t, byte(0), message[0], "magic byte")
+			assert.Equal(t, uint32(42), binary.BigEndian.Uint32(message[1:5]), "schema ID is the global ID")
+			assert.Equal(t, orderPayload, message[5:])
+		}
+
+		fromStruct, err := serializer.Serialize(context.Background(), "orders", &order{ID: "order-1", Amount: 1250})
+		assert.NoError(t, err)
+		assert.Equal(t, message, fromStruct)
+		assert.Equal(t, 1, registrations, "the schema ID is cached per subject")
+
+		_, err = serializer.Serialize(context.Background(), "refunds", order{ID: "order-1", Amount: 1250})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, registrations)
+	})
+
+	t.Run("Union Branches", func(t *testing.T) {
+		var registrations int
+		server := newRegistry(t, 7, &registrations)
+		defer server.Close()
+
+		serializer, err := serde.NewAvroSerializer(newResolver(server), orderSchema)
+		assert.NoError(t, err)
+
+		note := "gift"
+		message, err := serializer.Serialize(context.Background(), "orders", order{ID: "a", Amount: -1, Note: &note})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x02, 'a', 0x01, 0x02, 0x08, 'g', 'i', 'f', 't'}, message[5:])
+	})
+
+	t.Run("Complex Types", func(t *testing.T) {
+		var registrations int
+		server := newRegistry(t, 7, &registrations)
+		defer server.Close()
+
+		schema := `{"type": "record", "name": "Event", "fields": [
+			{"name": "kind", "type": {"type": "enum", "name": "Kind", "symbols": ["CREATED", "PAID"]}},
+			{"name": "tags", "type": {"type": "array", "items": "string"}},
+			{"name": "counts", "type": {"type": "map", "values": "int"}},
+			{"name": "hash", "type": {"type": "fixed", "name": "Hash", "size": 2}},
+			{"name": "ratio", "type": "float", "default": 0.5},
+			{"name": "ok", "type": "boolean"}
+		]}`
+		serializer, err := serde.NewAvroSerializer(newResolver(server), schema)
+		assert.NoError(t, err)
+
+		message, err := serializer.Serialize(context.Background(), "events", map[string]any{
+			"kind":   "PAID",
+			"tags":   []string{"x"},
+			"counts": map[string]int{"b": 1, "a": -1},
+			"hash":   [2]byte{0xab, 0xcd},
+			"ok":     true,
+		})
+		assert.NoError(t, err)
+		expected := []byte{
+			0x02,                  // enum index 1
+			0x02, 0x02, 'x', 0x00, // one block of one string, end of array
+			0x04, 0x02, 'a', 0x01, 0x02, 'b', 0x02, 0x00, // map entries in key order, end of map
+			0xab, 0xcd, // fixed
+			0x00, 0x00, 0x00, 0x3f, // default float 0.5, little endian
+			0x01, // true
+		}
+		assert.Equal(t, expected, message[5:])
+	})
+
+	t.Run("Invalid Values", func(t *testing.T) {
+		var registrations int
+		server := newRegistry(t, 7, &registrations)
+		defer server.Close()
+
+		serializer, err := serde.NewAvroSerializer(newResolver(server), orderSchema)
+		assert.NoError(t, err)
+
+		_, err = serializer.Serialize(context.Background(), "orders", map[string]any{"id": "order-1"})
+		assert.ErrorContains(t, err, "record com.example.Order: field amount is missing and has no default")
+
+		_, err = serializer.Serialize(context.Background(), "orders", map[string]any{"id": 1, "amount": 1})
+		assert.ErrorContains(t, err, "field id: expected string, got int")
+
+		_, err = serializer.Serialize(context.Background(), "orders", map[string]any{"id": "a", "amount": 1, "note": 5})
+		assert.ErrorContains(t, err, "field note: int matches no branch of the union")
+	})
+
+	t.Run("Invalid Schema", func(t *testing.T) {
+		_, err := serde.NewAvroSerializer(nil, `{"type": "record", "name": "Order", "fields": [{"name": "id"}]}`)
+		assert.ErrorIs(t, err, models.ErrInvalidContent)
+	})
+
+	t.Run("Schema ID Out Of Range", func(t *testing.T) {
+		var registrations int
+		server := newRegistry(t, 1<<32, &registrations)
+		defer server.Close()
+
+		serializer, err := serde.NewAvroSerializer(newResolver(server), orderSchema)
+		assert.NoError(t, err)
+
+		_, err = serializer.Serialize(context.Background(), "orders", order{ID: "a"})
+		assert.ErrorContains(t, err, "does not fit the 4-byte wire format")
+	})
+	t.Run("Version Not Reported", func(t *testing.T) {
+		// Registry releases that return the artifact at the top level of the create response omit the version.
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"groupId": "default", "artifactId": "orders-value", "version": "1"}`))
+		}))
+		defer server.Close()
+
+		serializer, err := serde.NewAvroSerializer(newResolver(server), orderSchema)
+		assert.NoError(t, err)
+
+		_, err = serializer.Serialize(context.Background(), "orders", order{ID: "a"})
+		assert.ErrorContains(t, err, "registry did not report the version registered for subject orders-value")
+	})
+}